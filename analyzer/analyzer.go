@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -12,9 +13,13 @@ import (
 	"github.com/ConfigMate/configmate/parsers"
 )
 
+// Analyzer checks the config files of a specification. When configFiles
+// is not empty, it replaces the config declaration of the specification.
+// In both cases it can be a file path, a glob or a directory. When strict
+// is set, the whole specification is checked as if it was strict.
 type Analyzer interface {
-	AnalyzeSpecification(specFilePath string, specFileContent []byte) (*spec.Specification, []CheckResult, *SpecError)
-	AllFilesContent(specFilePath string) map[string][]byte
+	AnalyzeSpecification(specFilePath string, specFileContent []byte, configFiles string, strict bool) (*spec.Specification, []CheckResult, []SpecError)
+	AllFilesContent(specFilePath string, configFiles string) map[string][]byte
 }
 
 type SpecError struct {
@@ -37,6 +42,10 @@ type CheckResult struct {
 	Field         spec.FieldSpec          `json:"field"`          // the rule that was checked
 	CheckNum      int                     `json:"check_num"`      // the number of the check that was evaluated
 	TokenList     []TokenLocationWithFile `json:"token_list"`     // list of tokens involved in the check
+	File          string                  `json:"file"`           // the main config file checked
+	Document      int                     `json:"document"`       // number of the document checked (starting at 1), 0 if the file has a single document
+	FromDefault   bool                    `json:"from_default"`   // whether the field was missing and its default value was checked
+	Severity      string                  `json:"severity"`       // severity of the check, which tells how much its failure matters
 }
 
 // TokenLocationWithFile is a TokenLocation enhanced with a file path;
@@ -50,131 +59,73 @@ type TokenLocationWithFile struct {
 const mainFileAlias = "main"
 
 type analyzerImpl struct {
-	specParser     spec.SpecParser
-	checkEvaluator check.CheckEvaluator
-	fileFetcher    files.FileFetcher
-	parserProvider parsers.ParserProvider
+	specParser       spec.SpecParser
+	checkEvaluator   check.CheckEvaluator
+	checkTypeChecker check.CheckTypeChecker
+	fileFetcher      files.FileFetcher
+	parserProvider   parsers.ParserProvider
 }
 
 func NewAnalyzer(
 	specParser spec.SpecParser,
 	checkEvaluator check.CheckEvaluator,
+	checkTypeChecker check.CheckTypeChecker,
 	fileFetcher files.FileFetcher,
 	parserProvider parsers.ParserProvider) Analyzer {
 	return &analyzerImpl{
-		specParser:     specParser,
-		checkEvaluator: checkEvaluator,
-		fileFetcher:    fileFetcher,
-		parserProvider: parserProvider,
+		specParser:       specParser,
+		checkEvaluator:   checkEvaluator,
+		checkTypeChecker: checkTypeChecker,
+		fileFetcher:      fileFetcher,
+		parserProvider:   parserProvider,
 	}
 }
 
-func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent []byte) (*spec.Specification, []CheckResult, *SpecError) {
-	// Check if contents were not provided, and get them from the file path then
-	if specFileContent == nil {
-		var err error
-		specFileContent, err = a.fileFetcher.FetchFile(specFilePath)
-		if err != nil {
-			return nil, nil, &SpecError{
-				AnalyzerMsg: "Failed to get specification file",
-				ErrorMsgs:   []string{err.Error()},
-			}
-		}
+func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent []byte, configFiles string, strict bool) (*spec.Specification, []CheckResult, []SpecError) {
+	// Parse specification, merged into the specifications it extends
+	// The sources of the specification files locate the errors found in their checks
+	// Imported specifications are loaded later with the same maps
+	loading := make(map[string]bool)
+	specSources := make(map[string][]byte)
+	mainSpec, loadErrors := a.loadSpecification(specFilePath, specFileContent, loading, specSources)
+	if len(loadErrors) > 0 {
+		return nil, nil, loadErrors
 	}
 
-	// Parse specification from contents
-	mainSpec, parserErrors := a.specParser.Parse(specFileContent)
-	if len(parserErrors) > 0 { // Check for parser errors
-		specError := &SpecError{
-			AnalyzerMsg: "Failed to parse specification file",
-			ErrorMsgs:   []string{},
-			TokenList:   []TokenLocationWithFile{},
-		}
-		for _, parserError := range parserErrors {
-			specError.ErrorMsgs = append(specError.ErrorMsgs, parserError.ErrorMessage)
-			specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
-				File:     specFilePath,
-				Location: parserError.Location,
-			})
-		}
-		return nil, nil, specError
+	// Replace config declaration if config files were provided
+	if configFiles != "" {
+		mainSpec.File = configFiles
+	}
+
+	// Make the whole specification strict if requested
+	if strict {
+		mainSpec.Strict = true
 	}
 
 	// Create fields map
 	fields := make(map[string][]spec.FieldSpec)
-	fields[mainFileAlias] = mainSpec.Fields
+	// Checks of object properties run on every field of the object type
+	fields[mainFileAlias] = addObjectPropertyFields(mainSpec.Fields, mainSpec.Objects)
 
 	// Add custom types to type factory
 	types.AddCustomObjTypes(mainSpec.Objects)
 
-	// Get main config file
-	mainConfigContent, err := a.fileFetcher.FetchFile(mainSpec.File)
-	if err != nil {
-		specError := &SpecError{
-			AnalyzerMsg: "Failed to get main config file",
-			ErrorMsgs:   []string{err.Error()},
-			TokenList: []TokenLocationWithFile{
-				{
-					File:     specFilePath,
-					Location: mainSpec.FileLocation,
-				},
-			},
-		}
-		return mainSpec, nil, specError
-	}
-
-	// Get parser for main config file
-	mainConfigParser, err := a.parserProvider.GetParser(mainSpec.FileFormat)
-	if err != nil {
-		specError := &SpecError{
-			AnalyzerMsg: "Failed to get parser for main config file",
-			ErrorMsgs:   []string{err.Error()},
-			TokenList: []TokenLocationWithFile{
-				{
-					File:     specFilePath,
-					Location: mainSpec.FileFormatLocation,
-				},
-			},
-		}
-		return mainSpec, nil, specError
-	}
-
-	// Parse main config file
-	mainConfig, parserErrs := mainConfigParser.Parse(mainConfigContent)
-	if len(parserErrs) > 0 {
-		specError := &SpecError{
-			AnalyzerMsg: "Failed to parse main config file",
-			ErrorMsgs:   []string{},
-			TokenList: []TokenLocationWithFile{
-				{
-					File:     specFilePath,
-					Location: mainSpec.FileLocation,
-				},
-			},
-		}
-		for _, parserError := range parserErrs {
-			specError.ErrorMsgs = append(specError.ErrorMsgs, parserError.Message)
-			specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
-				File:     mainSpec.File,
-				Location: parserError.Location,
-			})
-		}
-		return mainSpec, nil, specError
-	}
-
 	// Create file paths maps for token locations in errors
 	specFilePaths := make(map[string]string)
 	configFilePaths := make(map[string]string)
 
 	// Add main spec and config file path map
 	specFilePaths[mainFileAlias] = specFilePath
-	configFilePaths[mainFileAlias] = mainSpec.File
 
-	// Create files map
+	// Create files map, the main config file is added for each document
 	files := make(map[string]*parsers.Node)
-	files[mainFileAlias] = mainConfig
+
+	// Create predicates map, predicates of the main spec take precedence over imported ones
+	predicates := make(map[string]check.Predicate)
+	importedPredicates := make(map[string]spec.PredicateDef)
 
 	// Fetch imported spec files
+	importedSpecs := make(map[string]*spec.Specification)
 	for alias, importedSpecFilePath := range mainSpec.Imports {
 		// Check that alias doesn't conflict with main spec
 		if alias == mainFileAlias {
@@ -188,11 +139,11 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 					},
 				},
 			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, []SpecError{*specError}
 		}
 
 		// Check that alias doesn't conflict with other imported specs
-		if _, ok := files[alias]; ok {
+		if _, ok := importedSpecs[alias]; ok {
 			specError := &SpecError{
 				AnalyzerMsg: fmt.Sprintf("Alias conflicts: '%s' is already used for another imported spec", alias),
 				ErrorMsgs:   []string{},
@@ -203,7 +154,7 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 					},
 				},
 			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, []SpecError{*specError}
 		}
 
 		// Get imported spec file
@@ -219,30 +170,22 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 					},
 				},
 			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, []SpecError{*specError}
 		}
 
-		// Parse imported spec file
-		importedSpec, parserErrors := a.specParser.Parse(importedSpecBytes)
-		if len(parserErrors) > 0 {
-			specError := &SpecError{
-				AnalyzerMsg: fmt.Sprintf("Failed to parse imported spec file %s", importedSpecFilePath),
-				ErrorMsgs:   []string{},
-				TokenList: []TokenLocationWithFile{
+		// Load imported spec file, merged into the specifications it extends
+		importedSpec, loadErrors := a.loadSpecification(importedSpecFilePath, importedSpecBytes, loading, specSources)
+		if len(loadErrors) > 0 {
+			// Errors of the imported spec also point to where it is imported
+			for i := range loadErrors {
+				loadErrors[i].TokenList = append([]TokenLocationWithFile{
 					{
 						File:     specFilePath,
 						Location: mainSpec.ImportsLocation[alias],
 					},
-				},
+				}, loadErrors[i].TokenList...)
 			}
-			for _, parserError := range parserErrors {
-				specError.ErrorMsgs = append(specError.ErrorMsgs, parserError.ErrorMessage)
-				specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
-					File:     importedSpecFilePath,
-					Location: parserError.Location,
-				})
-			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, loadErrors
 		}
 
 		// Add imported spec file to fields map
@@ -251,6 +194,51 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 		// Add custom types to type factory
 		types.AddCustomObjTypes(importedSpec.Objects)
 
+		// Add predicates of imported spec, they can't be defined by two imported specs
+		for _, predicate := range importedSpec.Predicates {
+			if defined, ok := importedPredicates[predicate.Name]; ok {
+				specError := &SpecError{
+					AnalyzerMsg: fmt.Sprintf("Predicate conflicts: '%s' is defined in more than one imported spec", predicate.Name),
+					ErrorMsgs:   []string{},
+					TokenList: []TokenLocationWithFile{
+						{
+							File:     defined.SpecFile,
+							Location: defined.NameLocation,
+						},
+						{
+							File:     predicate.SpecFile,
+							Location: predicate.NameLocation,
+						},
+					},
+				}
+				return mainSpec, nil, []SpecError{*specError}
+			}
+			importedPredicates[predicate.Name] = predicate
+			predicates[predicate.Name] = check.Predicate{Params: predicate.Params, Check: predicate.Check}
+		}
+
+		specFilePaths[alias] = importedSpecFilePath
+		importedSpecs[alias] = importedSpec
+	}
+
+	// Add predicates of the main spec
+	for _, predicate := range mainSpec.Predicates {
+		predicates[predicate.Name] = check.Predicate{Params: predicate.Params, Check: predicate.Check}
+	}
+
+	// Check the types of the checks before any config file is read
+	if typeErrors := a.typeCheckChecks(fields, mainSpec.Predicates, importedPredicates, predicates, specSources); len(typeErrors) > 0 {
+		return mainSpec, nil, typeErrors
+	}
+
+	// Fetch the config files of imported specs
+	for alias, importedSpec := range importedSpecs {
+		// Specs without fields, like libraries of predicates, don't need their config file
+		importedSpecFilePath := mainSpec.Imports[alias]
+		if len(importedSpec.Fields) == 0 {
+			continue
+		}
+
 		// Get imported config file
 		importedConfigContent, err := a.fileFetcher.FetchFile(importedSpec.File)
 		if err != nil {
@@ -268,7 +256,29 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 					},
 				},
 			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, []SpecError{*specError}
+		}
+
+		// Detect format of imported config file if it was not specified
+		if importedSpec.FileFormat == "" {
+			importedSpec.FileFormat, err = a.parserProvider.DetectFormat(importedSpec.File, importedConfigContent)
+			if err != nil {
+				specError := &SpecError{
+					AnalyzerMsg: fmt.Sprintf("Failed to detect format of imported config file from spec %s", alias),
+					ErrorMsgs:   []string{err.Error()},
+					TokenList: []TokenLocationWithFile{
+						{
+							File:     specFilePath,
+							Location: mainSpec.ImportsLocation[alias],
+						},
+						{
+							File:     importedSpecFilePath,
+							Location: importedSpec.FileLocation,
+						},
+					},
+				}
+				return mainSpec, nil, []SpecError{*specError}
+			}
 		}
 
 		// Get parser for imported config file
@@ -288,11 +298,11 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 					},
 				},
 			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, []SpecError{*specError}
 		}
 
 		// Parse imported config file
-		importedConfig, parserErrs := importedConfigParser.Parse(importedConfigContent)
+		importedConfig, parserErrs := parsers.ParseFile(importedConfigParser, importedSpec.File, importedConfigContent)
 		if len(parserErrs) > 0 {
 			specError := &SpecError{
 				AnalyzerMsg: fmt.Sprintf("Failed to parse imported config file from spec %s", alias),
@@ -315,46 +325,324 @@ func (a *analyzerImpl) AnalyzeSpecification(specFilePath string, specFileContent
 					Location: parserError.Location,
 				})
 			}
-			return mainSpec, nil, specError
+			return mainSpec, nil, []SpecError{*specError}
 		}
 
 		// Add imported config file to files map
 		files[alias] = importedConfig
 
-		// Add imported spec and config file to path map
-		specFilePaths[alias] = importedSpecFilePath
+		// Add imported config file to path map
 		configFilePaths[alias] = importedSpec.File
 	}
 
-	// Find all fields and parse them
-	// optMissingFields is a map of optional fields that are missing
-	fieldValues, fieldLocations, optMissingFields, specError := a.findAndParseAllFields(
-		files,
-		fields,
-		specFilePaths,
-		configFilePaths,
-	)
-	if specError != nil {
-		return mainSpec, nil, specError
+	// Find the main config files, the config declaration can be a glob or a directory
+	mainConfigFiles, err := a.fileFetcher.MatchFiles(mainSpec.File)
+	if err != nil {
+		specError := &SpecError{
+			AnalyzerMsg: "Failed to find main config files",
+			ErrorMsgs:   []string{err.Error()},
+			TokenList: []TokenLocationWithFile{
+				{
+					File:     specFilePath,
+					Location: mainSpec.FileLocation,
+				},
+			},
+		}
+		return mainSpec, nil, []SpecError{*specError}
+	} else if len(mainConfigFiles) == 0 {
+		specError := &SpecError{
+			AnalyzerMsg: fmt.Sprintf("No config files match %s", mainSpec.File),
+			ErrorMsgs:   []string{},
+			TokenList: []TokenLocationWithFile{
+				{
+					File:     specFilePath,
+					Location: mainSpec.FileLocation,
+				},
+			},
+		}
+		return mainSpec, nil, []SpecError{*specError}
 	}
 
-	// Run checks
-	res, specError := a.runChecks(
-		mainSpec.Fields,
-		fieldValues,
-		fieldLocations,
-		optMissingFields,
-		specFilePaths,
-	)
-	if specError != nil {
-		return mainSpec, nil, specError
+	// Check each main config file, errors in a file don't stop the others from being checked
+	res := []CheckResult{}
+	specErrors := []SpecError{}
+	for _, mainConfigFile := range mainConfigFiles {
+		configFilePaths[mainFileAlias] = mainConfigFile
+		fileRes, fileSpecErrors := a.analyzeMainConfigFile(
+			mainSpec,
+			mainConfigFile,
+			files,
+			fields,
+			predicates,
+			specFilePaths,
+			configFilePaths,
+		)
+		res = append(res, fileRes...)
+		specErrors = append(specErrors, fileSpecErrors...)
 	}
 
 	// Analyze config files
+	if len(specErrors) > 0 {
+		return mainSpec, res, specErrors
+	}
 	return mainSpec, res, nil
 }
 
-func (a *analyzerImpl) AllFilesContent(specFilePath string) map[string][]byte {
+// loadSpecification parses the specification at specFilePath, from its
+// content if provided, and merges it into the specification it extends,
+// which is loaded the same way. Loading records the specifications being
+// loaded, so that a specification extending itself is reported, and the
+// sources of the specifications loaded.
+func (a *analyzerImpl) loadSpecification(specFilePath string, specFileContent []byte, loading map[string]bool, sources map[string][]byte) (*spec.Specification, []SpecError) {
+	// Check if contents were not provided, and get them from the file path then
+	if specFileContent == nil {
+		var err error
+		specFileContent, err = a.fileFetcher.FetchFile(specFilePath)
+		if err != nil {
+			return nil, []SpecError{{
+				AnalyzerMsg: "Failed to get specification file",
+				ErrorMsgs:   []string{err.Error()},
+			}}
+		}
+	}
+
+	sources[specFilePath] = specFileContent
+
+	// Parse specification from contents
+	loadedSpec, parserErrors := a.specParser.Parse(specFileContent)
+	if len(parserErrors) > 0 { // Check for parser errors
+		specError := &SpecError{
+			AnalyzerMsg: "Failed to parse specification file",
+			ErrorMsgs:   []string{},
+			TokenList:   []TokenLocationWithFile{},
+		}
+		for _, parserError := range parserErrors {
+			specError.ErrorMsgs = append(specError.ErrorMsgs, parserError.ErrorMessage)
+			specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
+				File:     specFilePath,
+				Location: parserError.Location,
+			})
+		}
+		return nil, []SpecError{*specError}
+	}
+
+	// Errors of fields and checks point to the file they were defined in
+	loadedSpec.SetSpecFile(specFilePath)
+	if loadedSpec.Extends == "" {
+		return loadedSpec, nil
+	}
+
+	// Check that the specification doesn't extend itself
+	loading[specFilePath] = true
+	defer delete(loading, specFilePath)
+	extendsLocation := TokenLocationWithFile{
+		File:     specFilePath,
+		Location: loadedSpec.ExtendsLocation,
+	}
+	if loading[loadedSpec.Extends] {
+		return nil, []SpecError{{
+			AnalyzerMsg: fmt.Sprintf("Specification %s extends itself", loadedSpec.Extends),
+			ErrorMsgs:   []string{},
+			TokenList:   []TokenLocationWithFile{extendsLocation},
+		}}
+	}
+
+	// Load the extended specification
+	baseContent, err := a.fileFetcher.FetchFile(loadedSpec.Extends)
+	if err != nil {
+		return nil, []SpecError{{
+			AnalyzerMsg: "Failed to get extended specification file",
+			ErrorMsgs:   []string{err.Error()},
+			TokenList:   []TokenLocationWithFile{extendsLocation},
+		}}
+	}
+	baseSpec, baseErrors := a.loadSpecification(loadedSpec.Extends, baseContent, loading, sources)
+	if len(baseErrors) > 0 {
+		return nil, baseErrors
+	}
+
+	// Merge the specification into the extended one
+	mergedSpec, extendErrors := spec.Extend(baseSpec, loadedSpec)
+	if len(extendErrors) > 0 {
+		specError := &SpecError{
+			AnalyzerMsg: fmt.Sprintf("Failed to extend specification %s", loadedSpec.Extends),
+			ErrorMsgs:   []string{},
+			TokenList:   []TokenLocationWithFile{extendsLocation},
+		}
+		for _, extendError := range extendErrors {
+			specError.ErrorMsgs = append(specError.ErrorMsgs, extendError.ErrorMessage)
+			specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
+				File:     specFilePath,
+				Location: extendError.Location,
+			})
+		}
+		return nil, []SpecError{*specError}
+	}
+
+	return mergedSpec, nil
+}
+
+// analyzeMainConfigFile parses a main config file and runs the checks
+// of the main spec on each of its documents. Errors in a document don't
+// stop the checks that can still be evaluated.
+func (a *analyzerImpl) analyzeMainConfigFile(
+	mainSpec *spec.Specification,
+	configFile string,
+	files map[string]*parsers.Node,
+	fields map[string][]spec.FieldSpec,
+	predicates map[string]check.Predicate,
+	specFilePaths map[string]string,
+	configFilePaths map[string]string) ([]CheckResult, []SpecError) {
+	// Get main config file
+	mainConfigContent, err := a.fileFetcher.FetchFile(configFile)
+	if err != nil {
+		specError := &SpecError{
+			AnalyzerMsg: "Failed to get main config file",
+			ErrorMsgs:   []string{err.Error()},
+			TokenList: []TokenLocationWithFile{
+				{
+					File:     specFilePaths[mainFileAlias],
+					Location: mainSpec.FileLocation,
+				},
+			},
+		}
+		return nil, []SpecError{*specError}
+	}
+
+	// Detect format of main config file if it was not specified
+	format := mainSpec.FileFormat
+	if format == "" {
+		format, err = a.parserProvider.DetectFormat(configFile, mainConfigContent)
+		if err != nil {
+			specError := &SpecError{
+				AnalyzerMsg: "Failed to detect format of main config file",
+				ErrorMsgs:   []string{err.Error()},
+				TokenList: []TokenLocationWithFile{
+					{
+						File:     specFilePaths[mainFileAlias],
+						Location: mainSpec.FileLocation,
+					},
+				},
+			}
+			return nil, []SpecError{*specError}
+		}
+	}
+
+	// Get parser for main config file
+	mainConfigParser, err := a.parserProvider.GetParser(format)
+	if err != nil {
+		specError := &SpecError{
+			AnalyzerMsg: "Failed to get parser for main config file",
+			ErrorMsgs:   []string{err.Error()},
+			TokenList: []TokenLocationWithFile{
+				{
+					File:     specFilePaths[mainFileAlias],
+					Location: mainSpec.FileFormatLocation,
+				},
+			},
+		}
+		return nil, []SpecError{*specError}
+	}
+
+	// Parse main config file, files with multiple documents are split
+	// so that each document is checked in turn
+	var mainConfigDocuments []*parsers.Node
+	var parserErrs []parsers.CMParserError
+	if multiDocumentParser, ok := mainConfigParser.(parsers.MultiDocumentParser); ok {
+		mainConfigDocuments, parserErrs = multiDocumentParser.ParseDocuments(mainConfigContent)
+	} else {
+		var mainConfig *parsers.Node
+		mainConfig, parserErrs = parsers.ParseFile(mainConfigParser, configFile, mainConfigContent)
+		mainConfigDocuments = []*parsers.Node{mainConfig}
+	}
+	if len(parserErrs) > 0 {
+		specError := &SpecError{
+			AnalyzerMsg: "Failed to parse main config file",
+			ErrorMsgs:   []string{},
+			TokenList: []TokenLocationWithFile{
+				{
+					File:     specFilePaths[mainFileAlias],
+					Location: mainSpec.FileLocation,
+				},
+			},
+		}
+		for _, parserError := range parserErrs {
+			specError.ErrorMsgs = append(specError.ErrorMsgs, parserError.Message)
+			specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
+				File:     configFile,
+				Location: parserError.Location,
+			})
+		}
+		return nil, []SpecError{*specError}
+	}
+
+	// Check each document of the main config file
+	res := []CheckResult{}
+	specErrors := []SpecError{}
+	for index, document := range mainConfigDocuments {
+		files[mainFileAlias] = document
+
+		// Expand fields with wildcards into one field per element
+		documentFields, expandErrors := a.expandWildcardFields(
+			files,
+			fields,
+			configFilePaths,
+		)
+
+		// Find all fields and parse them
+		// optMissingFields is a map of optional fields that are missing
+		// defaultFields is a map of missing fields that took their default value
+		// brokenFields is a map of fields that couldn't be read to the reason why
+		// nullFields is a map of nullable fields whose value is null
+		fieldValues, fieldLocations, optMissingFields, defaultFields, brokenFields, nullFields, fieldErrors := a.findAndParseAllFields(
+			files,
+			documentFields,
+			configFilePaths,
+		)
+
+		// Run checks
+		documentRes, checkErrors := a.runChecks(
+			documentFields[mainFileAlias],
+			fieldValues,
+			fieldLocations,
+			optMissingFields,
+			defaultFields,
+			brokenFields,
+			nullFields,
+			predicates,
+		)
+
+		// Find keys that are not declared in the spec
+		undeclaredErrors := findUndeclaredKeys(
+			document,
+			fields[mainFileAlias],
+			mainSpec.Objects,
+			mainSpec.Strict,
+			specFilePaths[mainFileAlias],
+			configFile,
+		)
+
+		// Documents are only numbered in files with multiple documents
+		documentErrors := append(append(append(expandErrors, fieldErrors...), checkErrors...), undeclaredErrors...)
+		for i := range documentErrors {
+			if len(mainConfigDocuments) > 1 {
+				documentErrors[i].AnalyzerMsg = fmt.Sprintf("%s (document %d)", documentErrors[i].AnalyzerMsg, index+1)
+			}
+		}
+		for i := range documentRes {
+			documentRes[i].File = configFile
+			if len(mainConfigDocuments) > 1 {
+				documentRes[i].Document = index + 1
+			}
+		}
+		res = append(res, documentRes...)
+		specErrors = append(specErrors, documentErrors...)
+	}
+
+	return res, specErrors
+}
+
+func (a *analyzerImpl) AllFilesContent(specFilePath string, configFiles string) map[string][]byte {
 	// Create files map
 	files := make(map[string][]byte)
 
@@ -373,14 +661,40 @@ func (a *analyzerImpl) AllFilesContent(specFilePath string) map[string][]byte {
 		return files
 	}
 
-	// Get config file
-	configBytes, err := a.fileFetcher.FetchFile(spec.File)
+	// Add extended spec files, following each specification to the one it extends
+	for extends := spec.Extends; extends != "" && files[extends] == nil; {
+		extendedSpecBytes, err := a.fileFetcher.FetchFile(extends)
+		if err != nil {
+			break
+		}
+		files[extends] = extendedSpecBytes
+
+		extendedSpec, parserErrors := a.specParser.Parse(extendedSpecBytes)
+		if len(parserErrors) > 0 {
+			break
+		}
+		extends = extendedSpec.Extends
+	}
+
+	// Replace config declaration if config files were provided
+	if configFiles != "" {
+		spec.File = configFiles
+	}
+
+	// Get config files
+	configFilePaths, err := a.fileFetcher.MatchFiles(spec.File)
 	if err != nil {
 		return files
 	}
+	for _, configFilePath := range configFilePaths {
+		configBytes, err := a.fileFetcher.FetchFile(configFilePath)
+		if err != nil {
+			continue
+		}
 
-	// Add config file to files map
-	files[spec.File] = configBytes
+		// Add config file to files map
+		files[configFilePath] = configBytes
+	}
 
 	// Fetch imported spec files
 	for _, importedSpecFilePath := range spec.Imports {
@@ -412,15 +726,67 @@ func (a *analyzerImpl) AllFilesContent(specFilePath string) map[string][]byte {
 	return files
 }
 
+// expandWildcardFields replaces each field with wildcards by one field
+// for each element found in the file. Fields without wildcards are kept
+// as they are, and fields that can't be expanded are left out.
+func (a *analyzerImpl) expandWildcardFields(
+	files map[string]*parsers.Node,
+	fields map[string][]spec.FieldSpec,
+	configFilePaths map[string]string) (map[string][]spec.FieldSpec, []SpecError) {
+	expandedFields := make(map[string][]spec.FieldSpec)
+	specErrors := []SpecError{}
+
+	for fileAlias, fileFields := range fields {
+		expandedFields[fileAlias] = make([]spec.FieldSpec, 0, len(fileFields))
+		for _, fspec := range fileFields {
+			if !fspec.Field.HasWildcard() {
+				expandedFields[fileAlias] = append(expandedFields[fileAlias], fspec)
+				continue
+			}
+
+			keys, err := files[fileAlias].Expand(fspec.Field)
+			if err != nil {
+				specErrors = append(specErrors, SpecError{
+					AnalyzerMsg: fmt.Sprintf("Failed to expand field %s from file %s", fspec.Field.String(), configFilePaths[fileAlias]),
+					ErrorMsgs:   []string{err.Error()},
+					TokenList: []TokenLocationWithFile{
+						{
+							File:     fspec.SpecFile,
+							Location: fspec.FieldLocation,
+						},
+					},
+				})
+				continue
+			}
+
+			// Each element is checked as a field of its own
+			for _, key := range keys {
+				elementSpec := fspec
+				elementSpec.Field = key
+				expandedFields[fileAlias] = append(expandedFields[fileAlias], elementSpec)
+			}
+		}
+	}
+
+	return expandedFields, specErrors
+}
+
+// findAndParseAllFields gets the value of each field from its file. Fields that
+// can't be read are added to the broken fields, with the reason, and a SpecError
+// is returned for each of them; their children are broken as well. Nullable
+// fields whose value is null are added to the null fields.
 func (a *analyzerImpl) findAndParseAllFields(
 	files map[string]*parsers.Node,
 	fields map[string][]spec.FieldSpec,
-	specFilePaths map[string]string,
-	configFilePaths map[string]string) (map[string]types.IType, map[string]TokenLocationWithFile, map[string]bool, *SpecError) {
+	configFilePaths map[string]string) (map[string]types.IType, map[string]TokenLocationWithFile, map[string]bool, map[string]bool, map[string]string, map[string]bool, []SpecError) {
 	// Create maps
 	fieldValues := make(map[string]types.IType)
 	fieldLocations := make(map[string]TokenLocationWithFile)
 	optMissingFields := make(map[string]bool)
+	defaultFields := make(map[string]bool)
+	brokenFields := make(map[string]string)
+	nullFields := make(map[string]bool)
+	specErrors := []SpecError{}
 
 	for fileAlias, fileFields := range fields {
 		// Sort file specs by field name lenght (shortest first)
@@ -430,56 +796,121 @@ func (a *analyzerImpl) findAndParseAllFields(
 		})
 
 		for _, fspec := range fileFields {
+			fieldName := getUniqueName(fileAlias, fspec.Field.String())
+
 			// Check if a parent field is an optional
 			// field that is missing, which makes the
 			// current field optional as well
 			for optMissingField := range optMissingFields {
-				if strings.HasPrefix(getUniqueName(fileAlias, fspec.Field.String()), optMissingField) {
-					optMissingFields[getUniqueName(fileAlias, fspec.Field.String())] = true
+				if isChildField(fieldName, optMissingField) {
+					optMissingFields[fieldName] = true
+					break
+				}
+			}
+
+			// Check if a parent field is null, which
+			// makes the current field missing
+			for nullField := range nullFields {
+				if isChildField(fieldName, nullField) {
+					optMissingFields[fieldName] = true
 					break
 				}
 			}
-			if optMissingFields[getUniqueName(fileAlias, fspec.Field.String())] {
+			if optMissingFields[fieldName] {
+				continue
+			}
+
+			// Check if a parent field is broken, which
+			// makes the current field broken as well
+			for brokenField := range brokenFields {
+				if isChildField(fieldName, brokenField) {
+					brokenFields[fieldName] = fmt.Sprintf("parent field '%s' is broken", brokenField)
+					break
+				}
+			}
+			if _, ok := brokenFields[fieldName]; ok {
 				continue
 			}
 
 			// Get field from file tree
 			fnode, err := files[fileAlias].Get(fspec.Field)
 			if err != nil {
-				return nil, nil, nil, &SpecError{
+				specError := SpecError{
 					AnalyzerMsg: fmt.Sprintf("Failed to get field %s from file %s", fspec.Field.String(), configFilePaths[fileAlias]),
 					ErrorMsgs:   []string{err.Error()},
 					TokenList: []TokenLocationWithFile{
 						{
-							File:     specFilePaths[fileAlias],
+							File:     fspec.SpecFile,
 							Location: fspec.FieldLocation,
 						},
 					},
 				}
+				specErrors = append(specErrors, specError)
+				brokenFields[fieldName] = specError.AnalyzerMsg
+			} else if fnode == nil && fspec.DefaultValue != nil { // Field not found and has a default value
+				t, err := types.MakeType(fspec.Type, fspec.DefaultValue)
+				if err != nil {
+					specError := SpecError{
+						AnalyzerMsg: fmt.Sprintf("failed to parse default value of field %s as type %s",
+							fspec.Field.String(), fspec.Type,
+						),
+						ErrorMsgs: []string{err.Error()},
+						TokenList: []TokenLocationWithFile{
+							{
+								File:     fspec.SpecFile,
+								Location: fspec.TypeLocation,
+							},
+							{
+								File:     fspec.SpecFile,
+								Location: fspec.DefaultLocation,
+							},
+						},
+					}
+					specErrors = append(specErrors, specError)
+					brokenFields[fieldName] = specError.AnalyzerMsg
+					continue
+				}
+
+				// The location of the value is the default in the spec
+				fieldValues[fieldName] = t
+				fieldLocations[fieldName] = TokenLocationWithFile{
+					File:     fspec.SpecFile,
+					Location: fspec.DefaultLocation,
+				}
+				defaultFields[fieldName] = true
 			} else if fnode == nil && !fspec.Optional { // Field not found and not optial
-				return nil, nil, nil, &SpecError{
+				specError := SpecError{
 					AnalyzerMsg: fmt.Sprintf("Field %s not found in file %s", fspec.Field.String(), configFilePaths[fileAlias]),
 					ErrorMsgs:   []string{},
 					TokenList: []TokenLocationWithFile{
 						{
-							File:     specFilePaths[fileAlias],
+							File:     fspec.SpecFile,
 							Location: fspec.FieldLocation,
 						},
 					},
 				}
+				specErrors = append(specErrors, specError)
+				brokenFields[fieldName] = specError.AnalyzerMsg
 			} else if fnode == nil && fspec.Optional { // Field not found and optional
-				optMissingFields[getUniqueName(fileAlias, fspec.Field.String())] = true
+				optMissingFields[fieldName] = true
 			} else { // Field found
-				t, err := types.MakeType(fspec.Type, fnode.Value)
+				// Null values of nullable fields don't need to match the type
+				typename := fspec.Type
+				if fnode.Type == parsers.Null && fspec.Nullable {
+					typename = "null"
+					nullFields[fieldName] = true
+				}
+
+				t, err := types.MakeType(typename, fnode.Value)
 				if err != nil {
-					return nil, nil, nil, &SpecError{
+					specError := SpecError{
 						AnalyzerMsg: fmt.Sprintf("failed to parse field %s from file %s as type %s",
 							fspec.Field.String(), configFilePaths[fileAlias], fspec.Type,
 						),
 						ErrorMsgs: []string{err.Error()},
 						TokenList: []TokenLocationWithFile{
 							{
-								File:     specFilePaths[fileAlias],
+								File:     fspec.SpecFile,
 								Location: fspec.TypeLocation,
 							},
 							{
@@ -488,9 +919,24 @@ func (a *analyzerImpl) findAndParseAllFields(
 							},
 						},
 					}
+
+					// Null values are only allowed in nullable fields
+					if fnode.Type == parsers.Null {
+						specError.ErrorMsgs = append(specError.ErrorMsgs, "value is null, add 'nullable: true' to the field to allow it")
+					}
+
+					// Point to the entry of a map that doesn't match the map type
+					if entryErr := (*types.EntryError)(nil); errors.As(err, &entryErr) {
+						specError.TokenList = append(specError.TokenList, TokenLocationWithFile{
+							File:     configFilePaths[fileAlias],
+							Location: entryErr.Location,
+						})
+					}
+					specErrors = append(specErrors, specError)
+					brokenFields[fieldName] = specError.AnalyzerMsg
 				} else {
-					fieldValues[getUniqueName(fileAlias, fspec.Field.String())] = t
-					fieldLocations[getUniqueName(fileAlias, fspec.Field.String())] = TokenLocationWithFile{
+					fieldValues[fieldName] = t
+					fieldLocations[fieldName] = TokenLocationWithFile{
 						File:     configFilePaths[fileAlias],
 						Location: fnode.ValueLocation,
 					}
@@ -499,18 +945,26 @@ func (a *analyzerImpl) findAndParseAllFields(
 		}
 	}
 
-	return fieldValues, fieldLocations, optMissingFields, nil
+	return fieldValues, fieldLocations, optMissingFields, defaultFields, brokenFields, nullFields, specErrors
 }
 
+// runChecks evaluates the checks of the main fields. Checks that can't
+// be evaluated are returned as SpecErrors, and checks on broken fields
+// or referencing them are skipped, as well as checks other than isNull
+// on null values of nullable fields.
 func (a *analyzerImpl) runChecks(
 	mainFieldSpecs []spec.FieldSpec,
 	fieldValues map[string]types.IType,
 	fieldLocations map[string]TokenLocationWithFile,
 	optMissingFields map[string]bool,
-	specFilePaths map[string]string) (res []CheckResult, err *SpecError) {
+	defaultFields map[string]bool,
+	brokenFields map[string]string,
+	nullFields map[string]bool,
+	predicates map[string]check.Predicate) (res []CheckResult, specErrors []SpecError) {
 
-	// Create results list
+	// Create results and errors lists
 	res = []CheckResult{}
+	specErrors = []SpecError{}
 
 	for index, fspec := range mainFieldSpecs {
 		// Evaluate checks
@@ -521,18 +975,21 @@ func (a *analyzerImpl) runChecks(
 				fspec.Field.String(),
 				fieldValues,
 				optMissingFields,
+				brokenFields,
+				nullFields,
+				predicates,
 			)
 			if result == nil {
-				return nil, &SpecError{
+				specErrors = append(specErrors, SpecError{
 					AnalyzerMsg: fmt.Sprintf("failed to evaluate check %s for field %s", checkInfo.Check, fspec.Field.String()),
 					ErrorMsgs:   []string{err.Error()},
 					TokenList: []TokenLocationWithFile{
 						{
-							File:     specFilePaths[mainFileAlias],
-							Location: fspec.Checks[checkNum].Location,
+							File:     checkInfo.SpecFile,
+							Location: checkInfo.Location,
 						},
 					},
-				}
+				})
 			} else if skipping {
 				res = append(res, CheckResult{
 					Status:        CheckSkipped,
@@ -540,6 +997,8 @@ func (a *analyzerImpl) runChecks(
 					Field:         mainFieldSpecs[index],
 					CheckNum:      checkNum,
 					TokenList:     []TokenLocationWithFile{},
+					FromDefault:   defaultFields[fspec.Field.String()],
+					Severity:      fspec.CheckSeverity(checkNum),
 				})
 			} else {
 				resComment := ""
@@ -560,12 +1019,20 @@ func (a *analyzerImpl) runChecks(
 					TokenList: []TokenLocationWithFile{
 						fieldLocations[fspec.Field.String()],
 					},
+					FromDefault: defaultFields[fspec.Field.String()],
+					Severity:    fspec.CheckSeverity(checkNum),
 				})
 			}
 		}
 	}
 
-	return res, nil
+	return res, specErrors
+}
+
+// isChildField returns whether field is nested in parent,
+// either as a property or as an element.
+func isChildField(field string, parent string) bool {
+	return strings.HasPrefix(field, parent+".") || strings.HasPrefix(field, parent+"[")
 }
 
 func getUniqueName(fileAlias string, fieldName string) string {
@@ -1,5 +1,15 @@
 package analyzer
 
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer/check"
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/analyzer/types"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
 // // Valid Setup
 // func TestAnalyzeConfigFiles_ValidRuleArgument(t *testing.T) {
 // 	// Create check mock
@@ -182,3 +192,273 @@ package analyzer
 // 	assert.False(t, res[0].Passed)
 // 	assert.Contains(t, res[0].ResultComment, "Value at server.port in file test must be a int, got string")
 // }
+
+// TestFindAndParseAllFieldsDefaults tests that missing fields take their
+// default value, typed as the field, and are reported as defaults, while
+// fields found in the file keep their value.
+func TestFindAndParseAllFieldsDefaults(t *testing.T) {
+	// Test cases
+	type testCase struct {
+		typename      string
+		defaultValue  interface{}
+		fileValue     *parsers.Node
+		expected      interface{}
+		expectDefault bool
+		expectedErr   string
+	}
+
+	defaultLocation := parsers.TokenLocation{
+		Start: parsers.CharLocation{Line: 3, Column: 20},
+		End:   parsers.CharLocation{Line: 3, Column: 22},
+	}
+	valueLocation := parsers.TokenLocation{
+		Start: parsers.CharLocation{Line: 1, Column: 10},
+		End:   parsers.CharLocation{Line: 1, Column: 14},
+	}
+
+	testCases := []testCase{
+		{typename: "int", defaultValue: 80, expected: 80, expectDefault: true},
+		{typename: "float", defaultValue: 0.5, expected: 0.5, expectDefault: true},
+		{typename: "string", defaultValue: "localhost", expected: "localhost", expectDefault: true},
+		{typename: "bool", defaultValue: false, expected: false, expectDefault: true},
+		{
+			typename:     "int",
+			defaultValue: 80,
+			fileValue:    &parsers.Node{Type: parsers.Int, Value: 8080, ValueLocation: valueLocation},
+			expected:     8080,
+		},
+		{typename: "int", defaultValue: "eighty", expectedErr: "failed to parse default value of field server.port as type int"},
+	}
+
+	// Run tests
+	a := &analyzerImpl{}
+	for _, test := range testCases {
+		server := map[string]*parsers.Node{}
+		if test.fileValue != nil {
+			server["port"] = test.fileValue
+		}
+		files := map[string]*parsers.Node{
+			mainFileAlias: {
+				Type:  parsers.Object,
+				Value: map[string]*parsers.Node{"server": {Type: parsers.Object, Value: server}},
+			},
+		}
+		fields := map[string][]spec.FieldSpec{
+			mainFileAlias: {
+				{Field: &parsers.NodeKey{Segments: []string{"server"}}, Type: "object"},
+				{
+					Field:           &parsers.NodeKey{Segments: []string{"server", "port"}},
+					Type:            test.typename,
+					DefaultValue:    test.defaultValue,
+					DefaultLocation: defaultLocation,
+					SpecFile:        "./spec.cms",
+				},
+			},
+		}
+		configFilePaths := map[string]string{mainFileAlias: "./config.json"}
+
+		fieldValues, fieldLocations, _, defaultFields, brokenFields, _, specErrors := a.findAndParseAllFields(files, fields, configFilePaths)
+
+		if test.expectedErr != "" {
+			if len(specErrors) != 1 || specErrors[0].AnalyzerMsg != test.expectedErr {
+				t.Errorf("Expected error %q, got %#v", test.expectedErr, specErrors)
+			}
+			if _, ok := brokenFields["server.port"]; !ok {
+				t.Errorf("Expected server.port to be broken, got %v", brokenFields)
+			}
+			continue
+		}
+
+		if len(specErrors) > 0 {
+			t.Errorf("Unexpected errors: %#v", specErrors)
+			continue
+		}
+		expected, _ := types.MakeType(test.typename, test.expected)
+		if !reflect.DeepEqual(fieldValues["server.port"], expected) {
+			t.Errorf("Expected value %#v, got %#v", expected, fieldValues["server.port"])
+		}
+		if defaultFields["server.port"] != test.expectDefault {
+			t.Errorf("Expected server.port from default to be %t, got %t", test.expectDefault, defaultFields["server.port"])
+		}
+
+		// Defaults are located in the spec, values in the config file
+		expectedLocation := TokenLocationWithFile{File: "./config.json", Location: valueLocation}
+		if test.expectDefault {
+			expectedLocation = TokenLocationWithFile{File: "./spec.cms", Location: defaultLocation}
+		}
+		if fieldLocations["server.port"] != expectedLocation {
+			t.Errorf("Expected location %#v, got %#v", expectedLocation, fieldLocations["server.port"])
+		}
+	}
+}
+
+// TestFindAndParseAllFieldsOptionalMissing tests that the children of a
+// missing optional field are missing too, but not the fields whose names
+// only start with its name.
+func TestFindAndParseAllFieldsOptionalMissing(t *testing.T) {
+	files := map[string]*parsers.Node{
+		mainFileAlias: {
+			Type: parsers.Object,
+			Value: map[string]*parsers.Node{
+				"serverName": {Type: parsers.String, Value: "api"},
+			},
+		},
+	}
+	fields := map[string][]spec.FieldSpec{
+		mainFileAlias: {
+			{Field: &parsers.NodeKey{Segments: []string{"server"}}, Type: "object", Optional: true},
+			{Field: &parsers.NodeKey{Segments: []string{"server", "port"}}, Type: "int"},
+			{Field: &parsers.NodeKey{Segments: []string{"serverName"}}, Type: "string"},
+		},
+	}
+	configFilePaths := map[string]string{mainFileAlias: "./config.json"}
+
+	a := &analyzerImpl{}
+	fieldValues, _, optMissingFields, _, _, _, specErrors := a.findAndParseAllFields(files, fields, configFilePaths)
+	if len(specErrors) > 0 {
+		t.Errorf("Unexpected errors: %#v", specErrors)
+	}
+
+	expectedMissing := map[string]bool{"server": true, "server.port": true}
+	if !reflect.DeepEqual(optMissingFields, expectedMissing) {
+		t.Errorf("Expected missing fields %v, got %v", expectedMissing, optMissingFields)
+	}
+
+	expected, _ := types.MakeType("string", "api")
+	if !reflect.DeepEqual(fieldValues["serverName"], expected) {
+		t.Errorf("Expected value %#v, got %#v", expected, fieldValues["serverName"])
+	}
+}
+
+// TestRunChecksWithDefaults tests that the checks of a field that took its
+// default value run on the default and are reported as such, and that
+// checks of other fields referencing it see the default.
+func TestRunChecksWithDefaults(t *testing.T) {
+	files := map[string]*parsers.Node{
+		mainFileAlias: {
+			Type:  parsers.Object,
+			Value: map[string]*parsers.Node{"limit": {Type: parsers.Int, Value: 100}},
+		},
+	}
+	fields := map[string][]spec.FieldSpec{
+		mainFileAlias: {
+			{
+				Field:        &parsers.NodeKey{Segments: []string{"port"}},
+				Type:         "int",
+				DefaultValue: 80,
+				Checks:       []spec.CheckWithLocation{{Check: "eq(80)"}},
+			},
+			{
+				Field:  &parsers.NodeKey{Segments: []string{"limit"}},
+				Type:   "int",
+				Checks: []spec.CheckWithLocation{{Check: "gt(port)"}},
+			},
+		},
+	}
+	configFilePaths := map[string]string{mainFileAlias: "./config.json"}
+
+	a := &analyzerImpl{checkEvaluator: check.NewCheckEvaluator()}
+	fieldValues, fieldLocations, optMissingFields, defaultFields, brokenFields, nullFields, specErrors := a.findAndParseAllFields(files, fields, configFilePaths)
+	if len(specErrors) > 0 {
+		t.Fatalf("Unexpected errors: %#v", specErrors)
+	}
+
+	res, specErrors := a.runChecks(fields[mainFileAlias], fieldValues, fieldLocations, optMissingFields, defaultFields, brokenFields, nullFields, map[string]check.Predicate{})
+	if len(specErrors) > 0 {
+		t.Fatalf("Unexpected errors: %#v", specErrors)
+	}
+
+	expectedFromDefault := map[string]bool{"port": true, "limit": false}
+	if len(res) != len(expectedFromDefault) {
+		t.Fatalf("Expected %d results, got %#v", len(expectedFromDefault), res)
+	}
+	for _, result := range res {
+		field := result.Field.Field.String()
+		if result.Status != CheckPassed {
+			t.Errorf("Expected check of %s to pass, got %#v", field, result)
+		}
+		if result.FromDefault != expectedFromDefault[field] {
+			t.Errorf("Expected check of %s from default to be %t, got %t", field, expectedFromDefault[field], result.FromDefault)
+		}
+	}
+}
+
+// TestRunChecksWithBrokenFields tests that every field that can't be read is
+// reported, that the checks on them or referencing them are skipped with the
+// reason, and that the checks of the other fields still run.
+func TestRunChecksWithBrokenFields(t *testing.T) {
+	files := map[string]*parsers.Node{
+		mainFileAlias: {
+			Type: parsers.Object,
+			Value: map[string]*parsers.Node{
+				"a": {Type: parsers.String, Value: "x"},
+				"b": {Type: parsers.String, Value: "y"},
+				"c": {Type: parsers.Int, Value: 3},
+			},
+		},
+	}
+	fields := map[string][]spec.FieldSpec{
+		mainFileAlias: {
+			{
+				Field:  &parsers.NodeKey{Segments: []string{"a"}},
+				Type:   "int",
+				Checks: []spec.CheckWithLocation{{Check: "gt(0)"}},
+			},
+			{
+				Field:  &parsers.NodeKey{Segments: []string{"b"}},
+				Type:   "int",
+				Checks: []spec.CheckWithLocation{{Check: "gt(0)"}},
+			},
+			{
+				Field:  &parsers.NodeKey{Segments: []string{"c"}},
+				Type:   "int",
+				Checks: []spec.CheckWithLocation{{Check: "gt(0)"}, {Check: "lt(a)"}, {Check: "lt(b)"}},
+			},
+		},
+	}
+	configFilePaths := map[string]string{mainFileAlias: "./config.json"}
+
+	a := &analyzerImpl{checkEvaluator: check.NewCheckEvaluator()}
+	fieldValues, fieldLocations, optMissingFields, defaultFields, brokenFields, nullFields, specErrors := a.findAndParseAllFields(files, fields, configFilePaths)
+
+	// Both broken fields are reported
+	expectedErrors := map[string]bool{
+		"failed to parse field a from file ./config.json as type int": true,
+		"failed to parse field b from file ./config.json as type int": true,
+	}
+	if len(specErrors) != len(expectedErrors) {
+		t.Fatalf("Expected %d errors, got %#v", len(expectedErrors), specErrors)
+	}
+	for _, specError := range specErrors {
+		if !expectedErrors[specError.AnalyzerMsg] {
+			t.Errorf("Unexpected error: %#v", specError)
+		}
+	}
+
+	res, specErrors := a.runChecks(fields[mainFileAlias], fieldValues, fieldLocations, optMissingFields, defaultFields, brokenFields, nullFields, map[string]check.Predicate{})
+	if len(specErrors) > 0 {
+		t.Fatalf("Unexpected errors: %#v", specErrors)
+	}
+
+	// Results by field and check
+	type expectedResult struct {
+		status  CheckStatus
+		comment string
+	}
+	expected := map[string]expectedResult{
+		"a: gt(0)": {CheckSkipped, "skipping check because primary field 'a' is broken: failed to parse field a from file ./config.json as type int"},
+		"b: gt(0)": {CheckSkipped, "skipping check because primary field 'b' is broken: failed to parse field b from file ./config.json as type int"},
+		"c: gt(0)": {CheckPassed, ""},
+		"c: lt(a)": {CheckSkipped, "skipping check because referenced field 'a' is broken: failed to parse field a from file ./config.json as type int"},
+		"c: lt(b)": {CheckSkipped, "skipping check because referenced field 'b' is broken: failed to parse field b from file ./config.json as type int"},
+	}
+	if len(res) != len(expected) {
+		t.Fatalf("Expected %d results, got %#v", len(expected), res)
+	}
+	for _, result := range res {
+		name := result.Field.Field.String() + ": " + result.Field.Checks[result.CheckNum].Check
+		if actual := (expectedResult{result.Status, result.ResultComment}); actual != expected[name] {
+			t.Errorf("Expected %s to be %#v, got %#v", name, expected[name], actual)
+		}
+	}
+}
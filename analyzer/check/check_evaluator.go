@@ -11,8 +11,21 @@ import (
 	"github.com/golang-collections/collections/stack"
 )
 
+// CheckEvaluator evaluates checks written in CMCL. Checks on optional
+// fields that are missing, or on broken fields (fields whose value
+// couldn't be read, mapped to the reason), are skipped, as well as
+// checks other than isNull on null values of nullable fields. Checks
+// can call the predicates, by name, like methods of a value.
 type CheckEvaluator interface {
-	Evaluate(check string, primaryField string, fields map[string]types.IType, optMissingFields map[string]bool) (types.IType, bool, error)
+	Evaluate(check string, primaryField string, fields map[string]types.IType, optMissingFields map[string]bool, brokenFields map[string]string, nullableFields map[string]bool, predicates map[string]Predicate) (types.IType, bool, error)
+}
+
+// Predicate is a named check with parameters. When called on a value,
+// its check is evaluated with 'this' bound to the value and each
+// parameter bound to its argument.
+type Predicate struct {
+	Params []string // Names of the parameters
+	Check  string   // Check the predicate stands for
 }
 
 type cmclNodeType int
@@ -40,15 +53,30 @@ type cmclNode struct {
 	value    string
 	children []*cmclNode
 
+	// Offsets of the first character of the node in the
+	// check, and of the character after its last one
+	start int
+	end   int
+
 	// Used by cmclIfCheck
 	elseIfStatements []*cmclNode
 	elseStatement    *cmclNode
+
+	// Used by cmclFieldExpr, the default is its first child
+	coalesce bool
 }
 
 type checkEvaluatorImpl struct {
 	primaryField     string
 	fields           map[string]types.IType
 	optMissingFields map[string]bool
+	brokenFields     map[string]string
+	nullableFields   map[string]bool
+	predicates       map[string]Predicate
+
+	// The predicateCalls are the names of the
+	// predicates being evaluated, in order
+	predicateCalls []string
 
 	// The evalFieldStack stores the ITypes of
 	// the fields that functions
@@ -60,11 +88,15 @@ func NewCheckEvaluator() CheckEvaluator {
 	return &checkEvaluatorImpl{}
 }
 
-func (ce *checkEvaluatorImpl) Evaluate(check string, primaryField string, fields map[string]types.IType, optMissingFields map[string]bool) (types.IType, bool, error) {
+func (ce *checkEvaluatorImpl) Evaluate(check string, primaryField string, fields map[string]types.IType, optMissingFields map[string]bool, brokenFields map[string]string, nullableFields map[string]bool, predicates map[string]Predicate) (types.IType, bool, error) {
 	// Set fields
 	ce.primaryField = primaryField
 	ce.fields = fields
 	ce.optMissingFields = optMissingFields
+	ce.brokenFields = brokenFields
+	ce.nullableFields = nullableFields
+	ce.predicates = predicates
+	ce.predicateCalls = nil
 	ce.evalFieldStack = stack.Stack{}
 
 	// Parse check
@@ -83,6 +115,11 @@ func (ce *checkEvaluatorImpl) Evaluate(check string, primaryField string, fields
 		// Make bool false to return
 		t, _ := types.MakeType("bool", false)
 		return t, true, fmt.Errorf("skipping check because primary field '%s' is optional and missing", ce.primaryField)
+	} else if reason, ok := ce.brokenFields[ce.primaryField]; ok {
+		// Skipping check because primary field is broken
+		// Make bool false to return
+		t, _ := types.MakeType("bool", false)
+		return t, true, fmt.Errorf("skipping check because primary field '%s' is broken: %s", ce.primaryField, reason)
 	} else {
 		return nil, false, fmt.Errorf("primary field '%s' does not exist", ce.primaryField)
 	}
@@ -198,7 +235,12 @@ func (ce *checkEvaluatorImpl) visitForeachCheck(node *cmclNode) (types.IType, bo
 	// Get list to iterate over
 	listFieldName := node.children[1].value
 	list, ok := ce.fields[listFieldName]
-	if !ok {
+	if reason, broken := ce.brokenFields[listFieldName]; !ok && broken {
+		// Skipping check because list field is broken
+		// Make bool false to return
+		t, _ := types.MakeType("bool", false)
+		return t, true, fmt.Errorf("skipping check because referenced field '%s' is broken: %s", listFieldName, reason)
+	} else if !ok {
 		return nil, false, fmt.Errorf("field '%s' does not exist", listFieldName)
 	}
 
@@ -250,14 +292,45 @@ func (ce *checkEvaluatorImpl) visitForeachCheck(node *cmclNode) (types.IType, bo
 func (ce *checkEvaluatorImpl) visitFieldExpr(node *cmclNode) (types.IType, bool, error) {
 	// Get field name
 	fieldName := node.value
+	field, ok := ce.fields[fieldName]
+	functions := node.children
+
+	// Use the default if the field is null or an optional field that is missing
+	if node.coalesce {
+		functions = node.children[1:]
+		if (ok && field.TypeName() == "null") || (!ok && ce.optMissingFields[fieldName]) {
+			defaultValue, _, err := ce.visit(node.children[0])
+			if defaultValue == nil {
+				return nil, false, err
+			}
+			field, ok = defaultValue, true
+		}
+	}
+
 	// Check if the field exists
-	if field, ok := ce.fields[fieldName]; ok {
+	if ok {
 		// Push field value to stack
 		ce.evalFieldStack.Push(field)
 
 		// Apply functions
 		var fErr error // Function error
-		for _, f := range node.children {
+		for i, f := range functions {
+			// Functions other than isNull can't be applied to null values
+			current := ce.evalFieldStack.Peek().(types.IType)
+			if current.TypeName() == "null" && f.value != "isNull" {
+				ce.evalFieldStack.Pop()
+
+				// Make bool false to return
+				t, _ := types.MakeType("bool", false)
+
+				// Null values are allowed in nullable fields, so their checks are skipped
+				if i == 0 && ce.nullableFields[ce.fieldDisplayName(fieldName)] {
+					return t, true, fmt.Errorf("skipping check because field '%s' is null", ce.fieldDisplayName(fieldName))
+				}
+
+				return t, false, fmt.Errorf("field '%s' is null", ce.fieldDisplayName(fieldName))
+			}
+
 			// Evaluate function
 			result, skipping, err := ce.visit(f)
 			if result == nil {
@@ -285,11 +358,25 @@ func (ce *checkEvaluatorImpl) visitFieldExpr(node *cmclNode) (types.IType, bool,
 		// Make bool false to return
 		t, _ := types.MakeType("bool", false)
 		return t, true, fmt.Errorf("skipping check because referenced optional field '%s' is missing", fieldName)
+	} else if reason, ok := ce.brokenFields[fieldName]; ok {
+		// Skipping check because field is broken
+		// Make bool false to return
+		t, _ := types.MakeType("bool", false)
+		return t, true, fmt.Errorf("skipping check because referenced field '%s' is broken: %s", fieldName, reason)
 	}
 
 	return nil, false, fmt.Errorf("field '%s' does not exist", fieldName)
 }
 
+// fieldDisplayName returns the name of the field for messages,
+// which is the primary field for 'this'.
+func (ce *checkEvaluatorImpl) fieldDisplayName(fieldName string) string {
+	if fieldName == "this" {
+		return ce.primaryField
+	}
+	return fieldName
+}
+
 func (ce *checkEvaluatorImpl) visitFuncExpr(node *cmclNode) (types.IType, bool, error) {
 	// Place this as the node the function applies to
 	node.value = "this"
@@ -461,6 +548,22 @@ func (ce *checkEvaluatorImpl) visitFunction(node *cmclNode) (types.IType, bool,
 	// Get field value
 	field := ce.evalFieldStack.Peek().(types.IType)
 
+	// Predicates take precedence over the methods of the value
+	if predicate, ok := ce.predicates[functionName]; ok {
+		return ce.visitPredicate(functionName, predicate, field, args)
+	}
+
+	// isNull can be used on values of every type
+	if functionName == "isNull" && field.TypeName() != "null" {
+		if len(args) != 0 {
+			return nil, false, fmt.Errorf("isNull expects 0 arguments")
+		}
+
+		// Make bool false to return
+		t, _ := types.MakeType("bool", false)
+		return t, false, fmt.Errorf("value is not null")
+	}
+
 	// Apply function
 	result, err := field.GetMethod(functionName)(args)
 	if result == nil {
@@ -477,6 +580,69 @@ func (ce *checkEvaluatorImpl) visitFunction(node *cmclNode) (types.IType, bool,
 	return result, false, err
 }
 
+// visitPredicate evaluates the check of a predicate on value. During the
+// evaluation, 'this' and the parameters shadow the fields with their names.
+func (ce *checkEvaluatorImpl) visitPredicate(name string, predicate Predicate, value types.IType, args []types.IType) (types.IType, bool, error) {
+	// Check that the predicate doesn't call itself
+	for i, call := range ce.predicateCalls {
+		if call == name {
+			return nil, false, fmt.Errorf("predicate %s is recursive: %s -> %s", name, strings.Join(ce.predicateCalls[i:], " -> "), name)
+		}
+	}
+
+	// Check that the correct number of arguments were passed
+	if len(args) != len(predicate.Params) {
+		return nil, false, fmt.Errorf("predicate %s expects %d arguments", name, len(predicate.Params))
+	}
+
+	// Parse predicate check
+	parser := &CheckParser{}
+	node, err := parser.parse(predicate.Check)
+	if err != nil {
+		return nil, false, fmt.Errorf("predicate %s: %v", name, err)
+	}
+
+	// Bind 'this' and the parameters, restoring the shadowed fields afterwards
+	names := append([]string{"this"}, predicate.Params...)
+	values := append([]types.IType{value}, args...)
+	shadowed := make(map[string]types.IType)
+	for i, boundName := range names {
+		if field, ok := ce.fields[boundName]; ok {
+			shadowed[boundName] = field
+		}
+		ce.fields[boundName] = values[i]
+	}
+	defer func() {
+		for _, boundName := range names {
+			if field, ok := shadowed[boundName]; ok {
+				ce.fields[boundName] = field
+			} else {
+				delete(ce.fields, boundName)
+			}
+		}
+	}()
+
+	// Evaluate predicate check
+	ce.predicateCalls = append(ce.predicateCalls, name)
+	result, skipping, err := ce.visit(node)
+	ce.predicateCalls = ce.predicateCalls[:len(ce.predicateCalls)-1]
+	if result == nil {
+		return nil, false, fmt.Errorf("predicate %s: %v", name, err)
+	} else if skipping {
+		return result, true, err
+	}
+
+	// Check if the result is a bool
+	if result.TypeName() != "bool" {
+		return nil, false, fmt.Errorf("predicate %s must evaluate to a bool", name)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("predicate %s: %v", name, err)
+	}
+	return result, false, err
+}
+
 func (ce *checkEvaluatorImpl) visitString(node *cmclNode) (types.IType, bool, error) {
 	// Remove quotes
 	value := node.value[1 : len(node.value)-1]
@@ -14,6 +14,9 @@ type checkEvaluatorTestStructure struct {
 	primaryField     string                 // Primary field name
 	fields           map[string]types.IType // Fields
 	optMissingFields map[string]bool        // Optional missing fields
+	brokenFields     map[string]string      // Broken fields and the reason
+	nullableFields   map[string]bool        // Nullable fields
+	predicates       map[string]Predicate   // Predicates of the spec
 	checks           []string               // Checks
 
 	// Expected values
@@ -313,7 +316,7 @@ func TestEvaluateBasicFunctionality(t *testing.T) {
 
 		// Evaluate checks
 		for _, check := range test.checks {
-			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields)
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
 			errMessage := ""
 			if err != nil {
 				errMessage = err.Error()
@@ -400,7 +403,7 @@ func TestEvaluateOptionalMissingFields(t *testing.T) {
 
 		// Evaluate checks
 		for _, check := range test.checks {
-			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields)
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
 			errMessage := ""
 			if err != nil {
 				errMessage = err.Error()
@@ -416,6 +419,475 @@ func TestEvaluateOptionalMissingFields(t *testing.T) {
 	}
 }
 
+// TestEvaluateBrokenFields tests the functionality of the check evaluator
+// when broken fields, which couldn't be read from the file, are involved.
+func TestEvaluateBrokenFields(t *testing.T) {
+	// Test cases
+	tests := []checkEvaluatorTestStructure{
+		// Test 1:
+		// This test verifies the functionality when the primary field is broken.
+		// In this case, the check should skip with the reason.
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary.field" // Create primary field
+
+			fields := make(map[string]types.IType) // Create fields
+
+			brokenFields := make(map[string]string) // Create broken fields
+			brokenFields[primaryField] = "Field primary.field not found in file ./config.json"
+			checks := []string{"eq(5)"} // Create checks
+
+			expectedRes, _ := types.MakeType("bool", false)                                                                                                  // Create expected result
+			expectedSkipped := true                                                                                                                          // Create expected skipped value
+			expectedErr := fmt.Errorf("skipping check because primary field 'primary.field' is broken: Field primary.field not found in file ./config.json") // Create expected error
+
+			return checkEvaluatorTestStructure{
+				primaryField:    primaryField,
+				fields:          fields,
+				brokenFields:    brokenFields,
+				checks:          checks,
+				expectedRes:     expectedRes,
+				expectedSkipped: expectedSkipped,
+				expectedErr:     expectedErr,
+			}
+		}(),
+		// Test 2:
+		// This test verifies the functionality when a referenced field is broken.
+		// In this case, the check should skip with the reason.
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary.field" // Create primary field
+
+			fields := make(map[string]types.IType) // Create fields
+			pFValue, _ := types.MakeType("int", 5) // Create primary field value
+			fields[primaryField] = pFValue         // Add primary field to fields
+
+			brokenFields := make(map[string]string) // Create broken fields
+			brokenFields["config.brokenField"] = "failed to parse field config.brokenField from file ./config.json as type int"
+			checks := []string{"eq(5) && lt(config.brokenField)"} // Create checks
+
+			expectedRes, _ := types.MakeType("bool", false)                                                                                                                                   // Create expected result
+			expectedSkipped := true                                                                                                                                                           // Create expected skipped value
+			expectedErr := fmt.Errorf("skipping check because referenced field 'config.brokenField' is broken: failed to parse field config.brokenField from file ./config.json as type int") // Create expected error
+
+			return checkEvaluatorTestStructure{
+				primaryField:    primaryField,
+				fields:          fields,
+				brokenFields:    brokenFields,
+				checks:          checks,
+				expectedRes:     expectedRes,
+				expectedSkipped: expectedSkipped,
+				expectedErr:     expectedErr,
+			}
+		}(),
+	}
+
+	for _, test := range tests {
+		// Create evaluator
+		evaluator := NewCheckEvaluator()
+
+		// Evaluate checks
+		for _, check := range test.checks {
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
+			errMessage := ""
+			if err != nil {
+				errMessage = err.Error()
+			}
+			expectedErrMessage := ""
+			if test.expectedErr != nil {
+				expectedErrMessage = test.expectedErr.Error()
+			}
+			if !reflect.DeepEqual(res, test.expectedRes) || !reflect.DeepEqual(skipped, test.expectedSkipped) || errMessage != expectedErrMessage {
+				t.Errorf("Evaluate(%v, %v, %v, %v) = %v, %v, %v, want %v, %v, %v", test.primaryField, test.fields, test.brokenFields, check, res, skipped, errMessage, test.expectedRes, test.expectedSkipped, expectedErrMessage)
+			}
+		}
+	}
+}
+
+// TestEvaluateNullValues tests the functionality of the check evaluator
+// when null values are involved, in nullable fields or not. It tests checks like:
+//   - isNull()
+//   - lt((config.other ?? 10))
+//   - (config.other ?? 10).lt(4)
+func TestEvaluateNullValues(t *testing.T) {
+	// Test cases
+	tests := []checkEvaluatorTestStructure{
+		// Test 1: isNull on a null value
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("null", nil)
+			fields := map[string]types.IType{primaryField: pFValue}
+			optMissingFields := make(map[string]bool)
+
+			checks := []string{"isNull()"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+		// Test 2: isNull on a value that is not null
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+			optMissingFields := make(map[string]bool)
+
+			checks := []string{"isNull()"}
+
+			expectedRes, _ := types.MakeType("bool", false)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      fmt.Errorf("value is not null"),
+			}
+		}(),
+		// Test 3: functions other than isNull fail on a null value
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("null", nil)
+			fields := map[string]types.IType{primaryField: pFValue}
+			optMissingFields := make(map[string]bool)
+
+			checks := []string{"gt(5)"}
+
+			expectedRes, _ := types.MakeType("bool", false)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      fmt.Errorf("field 'primary' is null"),
+			}
+		}(),
+		// Test 4: the default is used for a null field
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+			fields["config.other"], _ = types.MakeType("null", nil)
+			optMissingFields := make(map[string]bool)
+
+			checks := []string{"lt((config.other ?? 10))"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+		// Test 5: the default is used for an optional field that is missing
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+			optMissingFields := map[string]bool{"config.missing": true}
+
+			checks := []string{"eq((config.missing ?? 5))"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+		// Test 6: the default is not used for a field that is not null
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+			fields["config.other"], _ = types.MakeType("int", 3)
+			optMissingFields := make(map[string]bool)
+
+			checks := []string{"(config.other ?? 10).lt(4)"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+		// Test 7: functions other than isNull are skipped on a null value of a nullable field
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("null", nil)
+			fields := map[string]types.IType{primaryField: pFValue}
+			optMissingFields := make(map[string]bool)
+			nullableFields := map[string]bool{primaryField: true}
+
+			checks := []string{"gt(5)"}
+
+			expectedRes, _ := types.MakeType("bool", false)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				nullableFields:   nullableFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  true,
+				expectedErr:      fmt.Errorf("skipping check because field 'primary' is null"),
+			}
+		}(),
+		// Test 8: checks referencing a null value of a nullable field are skipped
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+			fields["config.other"], _ = types.MakeType("null", nil)
+			optMissingFields := make(map[string]bool)
+			nullableFields := map[string]bool{"config.other": true}
+
+			checks := []string{"config.other.lt(10)"}
+
+			expectedRes, _ := types.MakeType("bool", false)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				nullableFields:   nullableFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  true,
+				expectedErr:      fmt.Errorf("skipping check because field 'config.other' is null"),
+			}
+		}(),
+		// Test 9: isNull still runs on a null value of a nullable field
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("null", nil)
+			fields := map[string]types.IType{primaryField: pFValue}
+			optMissingFields := make(map[string]bool)
+			nullableFields := map[string]bool{primaryField: true}
+
+			checks := []string{"isNull() || gt(5)"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: optMissingFields,
+				nullableFields:   nullableFields,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+	}
+
+	for _, test := range tests {
+		// Create evaluator
+		evaluator := NewCheckEvaluator()
+
+		// Evaluate checks
+		for _, check := range test.checks {
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
+			errMessage := ""
+			if err != nil {
+				errMessage = err.Error()
+			}
+			expectedErrMessage := ""
+			if test.expectedErr != nil {
+				expectedErrMessage = test.expectedErr.Error()
+			}
+			if !reflect.DeepEqual(res, test.expectedRes) || !reflect.DeepEqual(skipped, test.expectedSkipped) || errMessage != expectedErrMessage {
+				t.Errorf("Evaluate(%v, %v, %v, %v) = %v, %v, %v, want %v, %v, %v", test.primaryField, test.fields, test.optMissingFields, check, res, skipped, errMessage, test.expectedRes, test.expectedSkipped, expectedErrMessage)
+			}
+		}
+	}
+}
+
+// TestEvaluatePredicates tests that predicates are evaluated on the
+// value they are called on, with their parameters bound to the
+// arguments, and that recursive predicates are reported.
+func TestEvaluatePredicates(t *testing.T) {
+	predicates := map[string]Predicate{
+		"within": {Params: []string{"min", "max"}, Check: "gte(min)&&lte(max)"},
+		"loop":   {Params: []string{"n"}, Check: "loop(n)"},
+	}
+
+	// Test cases
+	tests := []checkEvaluatorTestStructure{
+		// Test 1: predicate that holds for the primary field
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+
+			checks := []string{"within(1, 10)"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: map[string]bool{},
+				predicates:       predicates,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+		// Test 2: predicate that doesn't hold for the primary field
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 50)
+			fields := map[string]types.IType{primaryField: pFValue}
+
+			checks := []string{"within(1, 10)"}
+
+			expectedRes, _ := types.MakeType("bool", false)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: map[string]bool{},
+				predicates:       predicates,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      fmt.Errorf("predicate within: int.lte failed: 50 > 10"),
+			}
+		}(),
+		// Test 3: predicate called on another field
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 50)
+			fields := map[string]types.IType{primaryField: pFValue}
+			fields["config.other"], _ = types.MakeType("int", 3)
+
+			checks := []string{"config.other.within(1, 10)"}
+
+			expectedRes, _ := types.MakeType("bool", true)
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: map[string]bool{},
+				predicates:       predicates,
+				checks:           checks,
+				expectedRes:      expectedRes,
+				expectedSkipped:  false,
+				expectedErr:      error(nil),
+			}
+		}(),
+		// Test 4: wrong number of arguments
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+
+			checks := []string{"within(1)"}
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: map[string]bool{},
+				predicates:       predicates,
+				checks:           checks,
+				expectedRes:      nil,
+				expectedSkipped:  false,
+				expectedErr:      fmt.Errorf("predicate within expects 2 arguments"),
+			}
+		}(),
+		// Test 5: recursive predicate
+		func() checkEvaluatorTestStructure {
+			primaryField := "primary"
+
+			pFValue, _ := types.MakeType("int", 5)
+			fields := map[string]types.IType{primaryField: pFValue}
+
+			checks := []string{"loop(1)"}
+
+			return checkEvaluatorTestStructure{
+				primaryField:     primaryField,
+				fields:           fields,
+				optMissingFields: map[string]bool{},
+				predicates:       predicates,
+				checks:           checks,
+				expectedRes:      nil,
+				expectedSkipped:  false,
+				expectedErr:      fmt.Errorf("predicate loop: predicate loop is recursive: loop -> loop"),
+			}
+		}(),
+	}
+
+	for _, test := range tests {
+		// Create evaluator
+		evaluator := NewCheckEvaluator()
+
+		// Evaluate check
+		for _, check := range test.checks {
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
+			errMessage := ""
+			if err != nil {
+				errMessage = err.Error()
+			}
+			expectedErrMessage := ""
+			if test.expectedErr != nil {
+				expectedErrMessage = test.expectedErr.Error()
+			}
+			if !reflect.DeepEqual(res, test.expectedRes) || !reflect.DeepEqual(skipped, test.expectedSkipped) || errMessage != expectedErrMessage {
+				t.Errorf("Evaluate(%v, %v, %v, %v) = %v, %v, %v, want %v, %v, %v", test.primaryField, test.fields, test.optMissingFields, check, res, skipped, errMessage, test.expectedRes, test.expectedSkipped, expectedErrMessage)
+			}
+
+			// Parameters are only bound during the evaluation
+			if _, ok := test.fields["min"]; ok {
+				t.Errorf("Expected parameter min to be unbound after evaluating %s", check)
+			}
+		}
+	}
+}
+
 // TestEvaluateLogicalExpressions tests the functionality of the check
 // evaluator when logical expressions are involved. It tests checks like:
 //   - eq(5) && eq(10)
@@ -654,7 +1126,7 @@ func TestEvaluateLogicalExpressions(t *testing.T) {
 
 		// Evaluate check
 		for _, check := range test.checks {
-			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields)
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
 			errMessage := ""
 			if err != nil {
 				errMessage = err.Error()
@@ -783,7 +1255,7 @@ func TestEvaluateControlExpressions(t *testing.T) {
 
 		// Evaluate check
 		for _, check := range test.checks {
-			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields)
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
 			errMessage := ""
 			if err != nil {
 				errMessage = err.Error()
@@ -963,7 +1435,7 @@ func TestEvaluateErroneousExpressions(t *testing.T) {
 
 		// Evaluate check
 		for _, check := range test.checks {
-			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields)
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
 			errMessage := ""
 			if err != nil {
 				errMessage = err.Error()
@@ -1087,7 +1559,7 @@ func TestEvaluateSyntaxErrors(t *testing.T) {
 
 		// Evaluate check
 		for _, check := range test.checks {
-			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields)
+			res, skipped, err := evaluator.Evaluate(check, test.primaryField, test.fields, test.optMissingFields, test.brokenFields, test.nullableFields, test.predicates)
 			errMessage := ""
 			if err != nil {
 				errMessage = err.Error()
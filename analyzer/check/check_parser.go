@@ -71,6 +71,8 @@ func (p *CheckParser) EnterIfCheck(ctx *parser_cmcl.IfCheckContext) {
 	// Create new node for if statement
 	newNode := &cmclNode{
 		nodeType:         cmclIfCheck,
+		start:            ctx.GetStart().GetStart(),
+		end:              ctx.GetStop().GetStop() + 1,
 		children:         make([]*cmclNode, 0),
 		elseIfStatements: make([]*cmclNode, 0),
 	}
@@ -98,6 +100,8 @@ func (p *CheckParser) EnterForeachCheck(ctx *parser_cmcl.ForeachCheckContext) {
 	// Create new node for foreach statement
 	newNode := &cmclNode{
 		nodeType: cmclForeachCheck,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -105,12 +109,16 @@ func (p *CheckParser) EnterForeachCheck(ctx *parser_cmcl.ForeachCheckContext) {
 	newNode.children = append(newNode.children, &cmclNode{
 		nodeType: cmclForeachItemAlias,
 		value:    ctx.Foreach().IDENTIFIER().GetText(),
+		start:    ctx.Foreach().IDENTIFIER().GetSymbol().GetStart(),
+		end:      ctx.Foreach().IDENTIFIER().GetSymbol().GetStop() + 1,
 	})
 
 	// Add field being iterated over to the node as a child
 	newNode.children = append(newNode.children, &cmclNode{
 		nodeType: cmclForeachListArg,
 		value:    ctx.Foreach().FieldName().GetText(),
+		start:    ctx.Foreach().FieldName().GetStart().GetStart(),
+		end:      ctx.Foreach().FieldName().GetStop().GetStop() + 1,
 	})
 
 	// Add node to execution tree
@@ -136,6 +144,8 @@ func (p *CheckParser) EnterOrExpr(ctx *parser_cmcl.OrExprContext) {
 	// Create new node for or expression
 	newNode := &cmclNode{
 		nodeType: cmclOrExpr,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -162,6 +172,8 @@ func (p *CheckParser) EnterAndExpr(ctx *parser_cmcl.AndExprContext) {
 	// Create new node for and expression
 	newNode := &cmclNode{
 		nodeType: cmclAndExpr,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -188,6 +200,8 @@ func (p *CheckParser) EnterNotExpr(ctx *parser_cmcl.NotExprContext) {
 	// Create new node for not expression
 	newNode := &cmclNode{
 		nodeType: cmclNotExpr,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -214,8 +228,11 @@ func (p *CheckParser) EnterFieldExpr(ctx *parser_cmcl.FieldExprContext) {
 	// Create new node for field expression
 	newNode := &cmclNode{
 		nodeType: cmclFieldExpr,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		value:    ctx.FieldExpression().FieldName().GetText(),
 		children: make([]*cmclNode, 0),
+		coalesce: ctx.FieldExpression().COALESCE_SYM() != nil,
 	}
 
 	// Add node to execution tree
@@ -241,6 +258,8 @@ func (p *CheckParser) EnterParenExpr(ctx *parser_cmcl.ParenExprContext) {
 	// Create new node for paren expression
 	newNode := &cmclNode{
 		nodeType: cmclParenExpr,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -267,6 +286,8 @@ func (p *CheckParser) EnterElseif(ctx *parser_cmcl.ElseifContext) {
 	// Create new node for else if statement
 	newNode := &cmclNode{
 		nodeType: cmclIfCheck,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -289,6 +310,8 @@ func (p *CheckParser) EnterElse(ctx *parser_cmcl.ElseContext) {
 	// Create new node for else statement
 	newNode := &cmclNode{
 		nodeType: cmclIfCheck,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -311,6 +334,8 @@ func (p *CheckParser) EnterFuncExpr(ctx *parser_cmcl.FuncExprContext) {
 	// Create new node for function
 	newNode := &cmclNode{
 		nodeType: cmclFuncExpr,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		children: make([]*cmclNode, 0),
 	}
 
@@ -336,6 +361,8 @@ func (p *CheckParser) EnterFunction(ctx *parser_cmcl.FunctionContext) {
 	// Create new node for function
 	newNode := &cmclNode{
 		nodeType: cmclFunction,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		value:    ctx.IDENTIFIER().GetText(),
 		children: make([]*cmclNode, 0),
 	}
@@ -363,6 +390,8 @@ func (p *CheckParser) EnterString(ctx *parser_cmcl.StringContext) {
 	// Create new node for string
 	newNode := &cmclNode{
 		nodeType: cmclString,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		value:    ctx.GetText(),
 	}
 
@@ -376,6 +405,8 @@ func (p *CheckParser) EnterInt(ctx *parser_cmcl.IntContext) {
 	// Create new node for int
 	newNode := &cmclNode{
 		nodeType: cmclInt,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		value:    ctx.GetText(),
 	}
 
@@ -389,6 +420,8 @@ func (p *CheckParser) EnterFloat(ctx *parser_cmcl.FloatContext) {
 	// Create new node for float
 	newNode := &cmclNode{
 		nodeType: cmclFloat,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		value:    ctx.GetText(),
 	}
 
@@ -402,6 +435,8 @@ func (p *CheckParser) EnterBoolean(ctx *parser_cmcl.BooleanContext) {
 	// Create new node for boolean
 	newNode := &cmclNode{
 		nodeType: cmclBool,
+		start:    ctx.GetStart().GetStart(),
+		end:      ctx.GetStop().GetStop() + 1,
 		value:    ctx.GetText(),
 	}
 
@@ -0,0 +1,299 @@
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer/types"
+)
+
+// CheckTypeChecker finds the errors of checks written in CMCL before they
+// are evaluated. It infers the type of every sub-expression from the types
+// of the fields and the signatures of the methods, and reports what would
+// fail whatever the values of the fields are: unknown methods, wrong number
+// or types of arguments and conditions that are not bools. Expressions whose
+// type can't be known before the values are read are not checked.
+type CheckTypeChecker interface {
+	TypeCheck(check string, primaryField string, fieldTypes map[string]string, predicates map[string]Predicate) []TypeError
+}
+
+// TypeError is an error found in a check, or in the check of a
+// predicate it calls, at the offsets of the erroneous expression.
+type TypeError struct {
+	Message   string
+	Predicate string // Name of the predicate whose check has the error, empty if it is in the check
+	Start     int    // Offset of the first character of the expression
+	End       int    // Offset of the character after its last one
+}
+
+// indexRegexp matches the indexes of list elements in field names.
+var indexRegexp = regexp.MustCompile(`\[[0-9]+\]`)
+
+type checkTypeCheckerImpl struct {
+	fieldTypes map[string]string
+	predicates map[string]Predicate
+
+	// The scope has the types of 'this', and of the
+	// parameters and list item aliases being checked
+	scope map[string]string
+
+	// The predicateCalls are the names of the
+	// predicates being checked, in order
+	predicateCalls []string
+
+	errs  []TypeError
+	found map[TypeError]bool
+}
+
+func NewCheckTypeChecker() CheckTypeChecker {
+	return &checkTypeCheckerImpl{}
+}
+
+func (tc *checkTypeCheckerImpl) TypeCheck(check string, primaryField string, fieldTypes map[string]string, predicates map[string]Predicate) []TypeError {
+	// Set fields
+	tc.fieldTypes = fieldTypes
+	tc.predicates = predicates
+	tc.scope = map[string]string{}
+	tc.predicateCalls = nil
+	tc.errs = []TypeError{}
+	tc.found = make(map[TypeError]bool)
+
+	// Parse check
+	parser := &CheckParser{}
+	node, err := parser.parse(check)
+	if err != nil {
+		return []TypeError{{Message: err.Error(), Start: 0, End: len([]rune(check))}}
+	}
+
+	// Check the check on the primary field
+	tc.scope["this"] = tc.fieldType(primaryField)
+	tc.requireBool(node, tc.visit(node), "check")
+
+	return tc.errs
+}
+
+// visit returns the type of the value of a node, or an empty type if it
+// can't be known before the check is evaluated.
+func (tc *checkTypeCheckerImpl) visit(node *cmclNode) string {
+	switch node.nodeType {
+	case cmclIfCheck:
+		return tc.visitIfCheck(node)
+	case cmclForeachCheck:
+		return tc.visitForeachCheck(node)
+	case cmclFieldExpr:
+		return tc.visitFieldExpr(node)
+	case cmclFuncExpr:
+		return tc.visitFunctions(tc.scope["this"], node.children)
+	case cmclOrExpr:
+		return tc.visitLogicalExpr(node, "or expression")
+	case cmclAndExpr:
+		return tc.visitLogicalExpr(node, "and expression")
+	case cmclNotExpr:
+		tc.requireBool(node.children[0], tc.visit(node.children[0]), "not expression value")
+		return "bool"
+	case cmclParenExpr:
+		return tc.visit(node.children[0])
+	case cmclString:
+		return "string"
+	case cmclInt:
+		return "int"
+	case cmclFloat:
+		return "float"
+	case cmclBool:
+		return "bool"
+	default:
+		return ""
+	}
+}
+
+func (tc *checkTypeCheckerImpl) visitIfCheck(node *cmclNode) string {
+	// Conditions must be bools, and each branch is a check
+	statements := append([]*cmclNode{node}, node.elseIfStatements...)
+	for _, statement := range statements {
+		tc.requireBool(statement.children[0], tc.visit(statement.children[0]), "if statement condition")
+		tc.requireBool(statement.children[1], tc.visit(statement.children[1]), "check")
+	}
+	if node.elseStatement != nil {
+		body := node.elseStatement.children[0]
+		tc.requireBool(body, tc.visit(body), "check")
+	}
+
+	return "bool"
+}
+
+func (tc *checkTypeCheckerImpl) visitForeachCheck(node *cmclNode) string {
+	aliasNode, listNode, body := node.children[0], node.children[1], node.children[2]
+
+	// Check that the alias doesn't hide a field
+	alias := aliasNode.value
+	if _, ok := tc.scope[alias]; ok {
+		tc.addError(aliasNode, "list item alias '%s' in foreach conflicts with existing field", alias)
+	} else if _, ok := tc.fieldTypes[alias]; ok {
+		tc.addError(aliasNode, "list item alias '%s' in foreach conflicts with existing field", alias)
+	}
+
+	// Get the type of the list items
+	itemType := ""
+	if listType := tc.fieldType(listNode.value); strings.HasPrefix(listType, "list<") && strings.HasSuffix(listType, ">") {
+		itemType = listType[5 : len(listType)-1]
+	} else if listType != "" {
+		tc.addError(listNode, "foreach argument must be a list, got %s", listType)
+	}
+
+	// Check the body with the alias bound to the list items
+	shadowed, ok := tc.scope[alias]
+	tc.scope[alias] = itemType
+	tc.requireBool(body, tc.visit(body), "foreach body")
+	if ok {
+		tc.scope[alias] = shadowed
+	} else {
+		delete(tc.scope, alias)
+	}
+
+	return "bool"
+}
+
+func (tc *checkTypeCheckerImpl) visitFieldExpr(node *cmclNode) string {
+	fieldType := tc.fieldType(node.value)
+	functions := node.children
+
+	// The default is used when the field is null or missing
+	if node.coalesce {
+		defaultType := tc.visit(node.children[0])
+		if fieldType == "" {
+			fieldType = defaultType
+		}
+		functions = node.children[1:]
+	}
+
+	return tc.visitFunctions(fieldType, functions)
+}
+
+// visitFunctions returns the type of the result of applying functions,
+// in order, to a value of the given type.
+func (tc *checkTypeCheckerImpl) visitFunctions(typename string, functions []*cmclNode) string {
+	for _, function := range functions {
+		typename = tc.visitFunction(typename, function)
+	}
+	return typename
+}
+
+func (tc *checkTypeCheckerImpl) visitFunction(typename string, node *cmclNode) string {
+	// Get argument types
+	argTypes := make([]string, 0, len(node.children))
+	for _, arg := range node.children {
+		argTypes = append(argTypes, tc.visit(arg))
+	}
+
+	// Predicates take precedence over the methods of the value
+	if predicate, ok := tc.predicates[node.value]; ok {
+		tc.visitPredicate(node, predicate, typename, argTypes)
+		return "bool"
+	}
+
+	result, err := types.MethodType(typename, node.value, argTypes)
+	if err != nil {
+		tc.addError(node, "%s", err.Error())
+		return ""
+	}
+
+	return result
+}
+
+// visitPredicate checks a call to a predicate, and the check of the
+// predicate with 'this' and the parameters bound to the types of the
+// value and the arguments.
+func (tc *checkTypeCheckerImpl) visitPredicate(node *cmclNode, predicate Predicate, typename string, argTypes []string) {
+	name := node.value
+
+	// Check that the predicate doesn't call itself
+	for i, call := range tc.predicateCalls {
+		if call == name {
+			tc.addError(node, "predicate %s is recursive: %s -> %s", name, strings.Join(tc.predicateCalls[i:], " -> "), name)
+			return
+		}
+	}
+
+	// Check that the correct number of arguments were passed
+	if len(argTypes) != len(predicate.Params) {
+		tc.addError(node, "predicate %s expects %d arguments, got %d", name, len(predicate.Params), len(argTypes))
+		return
+	}
+
+	// Parse predicate check
+	parser := &CheckParser{}
+	predicateNode, err := parser.parse(predicate.Check)
+	if err != nil {
+		tc.addError(node, "predicate %s: %v", name, err)
+		return
+	}
+
+	// Bind 'this' and the parameters, restoring the shadowed types afterwards
+	scope := tc.scope
+	tc.scope = make(map[string]string)
+	for boundName, boundType := range scope {
+		tc.scope[boundName] = boundType
+	}
+	tc.scope["this"] = typename
+	for i, param := range predicate.Params {
+		tc.scope[param] = argTypes[i]
+	}
+
+	// Check predicate check
+	tc.predicateCalls = append(tc.predicateCalls, name)
+	tc.requireBool(predicateNode, tc.visit(predicateNode), "predicate "+name)
+	tc.predicateCalls = tc.predicateCalls[:len(tc.predicateCalls)-1]
+	tc.scope = scope
+}
+
+func (tc *checkTypeCheckerImpl) visitLogicalExpr(node *cmclNode, expression string) string {
+	// A single operand is the value of the expression
+	if len(node.children) == 1 {
+		return tc.visit(node.children[0])
+	}
+
+	for _, operand := range node.children {
+		tc.requireBool(operand, tc.visit(operand), expression+" operand")
+	}
+	return "bool"
+}
+
+// fieldType returns the type of a field, or of 'this', a parameter or a list
+// item alias. Elements of lists have the type of the field with a wildcard.
+func (tc *checkTypeCheckerImpl) fieldType(fieldName string) string {
+	if typename, ok := tc.scope[fieldName]; ok {
+		return typename
+	}
+	if typename, ok := tc.fieldTypes[fieldName]; ok {
+		return typename
+	}
+	return tc.fieldTypes[indexRegexp.ReplaceAllString(fieldName, "[*]")]
+}
+
+// requireBool adds an error if the type of the value of a node is known
+// and is not bool.
+func (tc *checkTypeCheckerImpl) requireBool(node *cmclNode, typename string, what string) {
+	if typename != "" && typename != "bool" {
+		tc.addError(node, "%s must evaluate to a bool, got %s", what, typename)
+	}
+}
+
+// addError adds an error at a node of the check being checked. Errors in
+// predicates are only added once, however many times they are called.
+func (tc *checkTypeCheckerImpl) addError(node *cmclNode, format string, args ...interface{}) {
+	typeError := TypeError{
+		Message: fmt.Sprintf(format, args...),
+		Start:   node.start,
+		End:     node.end,
+	}
+	if len(tc.predicateCalls) > 0 {
+		typeError.Predicate = tc.predicateCalls[len(tc.predicateCalls)-1]
+	}
+
+	if tc.found[typeError] {
+		return
+	}
+	tc.found[typeError] = true
+	tc.errs = append(tc.errs, typeError)
+}
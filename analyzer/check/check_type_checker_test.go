@@ -0,0 +1,110 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTypeCheck tests that the type checker infers the types of the
+// expressions of checks from the types of the fields, and reports
+// unknown methods, wrong arguments and values that are not bools at
+// the offsets of the expressions, in the check or in a predicate.
+func TestTypeCheck(t *testing.T) {
+	fieldTypes := map[string]string{
+		"port":       "int",
+		"ratio":      "float",
+		"host":       "host",
+		"mode":       "int|string",
+		"servers":    "list<string>",
+		"servers[*]": "string",
+		"limits":     "map<string,int>",
+	}
+	predicates := map[string]Predicate{
+		"between": {Params: []string{"min", "max"}, Check: "gte(min) && lte(max)"},
+	}
+
+	tests := []struct {
+		name     string
+		check    string
+		expected []TypeError
+	}{
+		{
+			name:     "valid check",
+			check:    `ratio.gt(0.5) && range(1, 10) && host.addPort(this).live() && limits.get("a").lt(3)`,
+			expected: []TypeError{},
+		},
+		{
+			name:     "unknown method",
+			check:    "rnage(1, 2)",
+			expected: []TypeError{{Message: "int does not have a method rnage", Start: 0, End: 11}},
+		},
+		{
+			name:     "method of another type",
+			check:    "reachable()",
+			expected: []TypeError{{Message: "int does not have a method reachable", Start: 0, End: 11}},
+		},
+		{
+			name:     "wrong number of arguments",
+			check:    "range(1)",
+			expected: []TypeError{{Message: "int.range expects 2 arguments, got 1", Start: 0, End: 8}},
+		},
+		{
+			name:     "wrong type of argument",
+			check:    `gt("a")`,
+			expected: []TypeError{{Message: "argument 1 of int.gt must be int, got string", Start: 0, End: 7}},
+		},
+		{
+			name:     "check is not a bool",
+			check:    "toString()",
+			expected: []TypeError{{Message: "check must evaluate to a bool, got string", Start: 0, End: 10}},
+		},
+		{
+			name:     "operand is not a bool",
+			check:    "gte(1) && toFloat()",
+			expected: []TypeError{{Message: "and expression operand must evaluate to a bool, got float", Start: 10, End: 19}},
+		},
+		{
+			name:     "condition is not a bool",
+			check:    "if (toString()) { eq(1) }",
+			expected: []TypeError{{Message: "if statement condition must evaluate to a bool, got string", Start: 4, End: 14}},
+		},
+		{
+			name:     "list items",
+			check:    `foreach(s : servers) { s.regex("^a") }`,
+			expected: []TypeError{},
+		},
+		{
+			name:     "list element",
+			check:    "servers[0].regex(1)",
+			expected: []TypeError{{Message: "argument 1 of string.regex must be string, got int", Start: 11, End: 19}},
+		},
+		{
+			name:     "foreach on a value that is not a list",
+			check:    "foreach(p : port) { p.eq(1) }",
+			expected: []TypeError{{Message: "foreach argument must be a list, got int", Start: 12, End: 16}},
+		},
+		{
+			name:     "types known once read",
+			check:    "mode.anything()",
+			expected: []TypeError{},
+		},
+		{
+			name:     "wrong number of arguments of predicate",
+			check:    "between(1)",
+			expected: []TypeError{{Message: "predicate between expects 2 arguments, got 1", Start: 0, End: 10}},
+		},
+		{
+			name:     "error in predicate",
+			check:    `between(1, "a")`,
+			expected: []TypeError{{Message: "argument 1 of int.lte must be int, got string", Predicate: "between", Start: 12, End: 20}},
+		},
+	}
+
+	for _, test := range tests {
+		typeChecker := NewCheckTypeChecker()
+		result := typeChecker.TypeCheck(test.check, "port", fieldTypes, predicates)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("%s: TypeCheck(%s) = %v, want %v", test.name, test.check, result, test.expected)
+		}
+	}
+}
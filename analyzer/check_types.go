@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/ConfigMate/configmate/analyzer/check"
+	"github.com/ConfigMate/configmate/analyzer/spec"
+)
+
+// typeCheckChecks checks the types of the checks of the main spec, and of
+// the predicates they call, before any config file is read. Each error is
+// located at the expression it was found in, in the check or in the check
+// of the predicate, using the sources of the spec files.
+func (a *analyzerImpl) typeCheckChecks(
+	fields map[string][]spec.FieldSpec,
+	mainPredicates []spec.PredicateDef,
+	importedPredicates map[string]spec.PredicateDef,
+	predicates map[string]check.Predicate,
+	specSources map[string][]byte) []SpecError {
+	// Types of the fields, by the names checks reference them with
+	fieldTypes := make(map[string]string)
+	for fileAlias, fileFields := range fields {
+		for _, fspec := range fileFields {
+			fieldTypes[getUniqueName(fileAlias, fspec.Field.String())] = fspec.Type
+		}
+	}
+
+	// Definitions of the predicates, the ones of the main spec take precedence
+	predicateDefs := make(map[string]spec.PredicateDef)
+	for name, predicate := range importedPredicates {
+		predicateDefs[name] = predicate
+	}
+	for _, predicate := range mainPredicates {
+		predicateDefs[predicate.Name] = predicate
+	}
+
+	// Checks of object properties are checked once for each field of the
+	// object type, and predicates once for each call, so errors are repeated
+	type foundError struct {
+		location TokenLocationWithFile
+		message  string
+	}
+	found := make(map[foundError]bool)
+
+	specErrors := []SpecError{}
+	for _, fspec := range fields[mainFileAlias] {
+		for _, checkInfo := range fspec.Checks {
+			typeErrors := a.checkTypeChecker.TypeCheck(checkInfo.Check, fspec.Field.String(), fieldTypes, predicates)
+			for _, typeError := range typeErrors {
+				file, checkLocation := checkInfo.SpecFile, checkInfo.Location
+				errorMsg := typeError.Message
+				if typeError.Predicate != "" {
+					predicate := predicateDefs[typeError.Predicate]
+					file, checkLocation = predicate.SpecFile, predicate.CheckLocation
+					errorMsg = fmt.Sprintf("predicate %s: %s", typeError.Predicate, typeError.Message)
+				}
+
+				location := TokenLocationWithFile{
+					File:     file,
+					Location: spec.LocateInCheck(specSources[file], checkLocation, typeError.Start, typeError.End),
+				}
+				if found[foundError{location, errorMsg}] {
+					continue
+				}
+				found[foundError{location, errorMsg}] = true
+
+				specErrors = append(specErrors, SpecError{
+					AnalyzerMsg: fmt.Sprintf("Type error in check %s for field %s", checkInfo.Check, fspec.Field.String()),
+					ErrorMsgs:   []string{errorMsg},
+					TokenList:   []TokenLocationWithFile{location},
+				})
+			}
+		}
+	}
+
+	return specErrors
+}
@@ -0,0 +1,312 @@
+package infer
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/analyzer/types"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// Words in the names of fields that hint at the type of their values.
+var (
+	portHints = []string{"port"}
+	hostHints = []string{"host", "addr", "server", "domain"}
+	fileHints = []string{"path", "file", "dir", "cert", "key"}
+)
+
+// baseTypes maps the types guessed from values to the type they refine.
+var baseTypes = map[string]string{
+	"port":      "int",
+	"host":      "string",
+	"host_port": "string",
+	"file":      "string",
+}
+
+// inferredField holds what the samples show about a field.
+type inferredField struct {
+	key       *parsers.NodeKey
+	typenames []string // Types of the values, in the order they were found
+	nullable  bool     // Whether a value was null
+	count     int      // Number of values found
+
+	objects       int // Number of object values, properties missing in any of them are optional
+	properties    []*inferredField
+	propertyIndex map[string]*inferredField
+
+	elements *inferredField // Elements of the list values
+}
+
+// Infer returns a specification for the config file with the fields, nested
+// objects and types found in the samples, which are parsed config files of the
+// same kind. Fields missing in some of the samples are optional, and the types
+// of values that look like ports, hosts, host:port pairs or files are guessed.
+// Samples that aren't objects have no fields and are skipped.
+func Infer(file string, format string, samples []*parsers.Node) *spec.Specification {
+	root := newInferredField(&parsers.NodeKey{Segments: []string{}})
+	for _, sample := range samples {
+		if sample != nil && sample.Type == parsers.Object {
+			root.add(sample)
+		}
+	}
+
+	return &spec.Specification{
+		File:                 file,
+		FileFormat:           format,
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields:               root.fieldSpecs(),
+	}
+}
+
+func newInferredField(key *parsers.NodeKey) *inferredField {
+	return &inferredField{
+		key:           key,
+		typenames:     []string{},
+		properties:    []*inferredField{},
+		propertyIndex: make(map[string]*inferredField),
+	}
+}
+
+// add records the value of the field in a sample.
+func (f *inferredField) add(node *parsers.Node) {
+	if node == nil {
+		return
+	}
+	f.count++
+
+	switch node.Type {
+	case parsers.Null:
+		f.nullable = true
+
+	case parsers.Object:
+		f.addType("object")
+		f.objects++
+
+		// Properties are added in the order they are in the file
+		values := node.Value.(map[string]*parsers.Node)
+		for _, name := range node.Keys() {
+			f.property(name).add(values[name])
+		}
+
+	case parsers.Array:
+		f.addType("list")
+		if f.elements == nil {
+			f.elements = newInferredField(joinSegment(f.key, parsers.WildcardSegment))
+		}
+		for _, element := range node.Value.([]*parsers.Node) {
+			f.elements.add(element)
+		}
+
+	default:
+		f.addType(guessType(f.name(), node))
+	}
+}
+
+// addType records a type of the values of the field.
+func (f *inferredField) addType(typename string) {
+	for _, t := range f.typenames {
+		if t == typename {
+			return
+		}
+	}
+	f.typenames = append(f.typenames, typename)
+}
+
+// property returns the property with the given name, created if not found yet.
+func (f *inferredField) property(name string) *inferredField {
+	if property, ok := f.propertyIndex[name]; ok {
+		return property
+	}
+
+	property := newInferredField(joinSegment(f.key, name))
+	f.propertyIndex[name] = property
+	f.properties = append(f.properties, property)
+	return property
+}
+
+// name returns the last name in the key of the field, so
+// elements of lists are named after the list.
+func (f *inferredField) name() string {
+	for i := len(f.key.Segments) - 1; i >= 0; i-- {
+		if segment := f.key.Segments[i]; segment != parsers.WildcardSegment {
+			return segment
+		}
+	}
+	return ""
+}
+
+// typename returns the type of the field, a union if the values have several types.
+func (f *inferredField) typename() string {
+	typenames := make([]string, 0, len(f.typenames))
+	for _, t := range mergeTypes(f.typenames) {
+		if t == "list" {
+			t = "list<" + f.elements.typename() + ">"
+		}
+		typenames = append(typenames, t)
+	}
+
+	// Fields that were always null, or empty lists, don't show their type
+	if len(typenames) == 0 {
+		if f.nullable {
+			return "null"
+		}
+		return "string"
+	}
+
+	return strings.Join(typenames, " | ")
+}
+
+// fieldSpecs returns the specifications of the properties of the field,
+// followed by those of their own properties and list elements.
+func (f *inferredField) fieldSpecs() []spec.FieldSpec {
+	fieldSpecs := []spec.FieldSpec{}
+	for _, property := range f.properties {
+		fieldSpecs = append(fieldSpecs, spec.FieldSpec{
+			Field:    property.key,
+			Type:     property.typename(),
+			Optional: property.count < f.objects,
+			Nullable: property.nullable && len(property.typenames) > 0,
+			Checks:   []spec.CheckWithLocation{},
+		})
+		fieldSpecs = append(fieldSpecs, property.fieldSpecs()...)
+	}
+
+	if f.elements != nil {
+		fieldSpecs = append(fieldSpecs, f.elements.fieldSpecs()...)
+	}
+
+	return fieldSpecs
+}
+
+// mergeTypes returns the types found for a field without the ones covered
+// by others. Guessed types are replaced by their base type when the values
+// don't agree on them, and ints are covered by floats.
+func mergeTypes(typenames []string) []string {
+	// Count the different types refining each base type
+	refinements := make(map[string]int)
+	for _, t := range typenames {
+		refinements[baseType(t)]++
+	}
+
+	merged := []string{}
+	added := make(map[string]bool)
+	for _, t := range typenames {
+		if refinements[baseType(t)] > 1 {
+			t = baseType(t)
+		}
+		if baseType(t) == "int" && refinements["float"] > 0 {
+			t = "float"
+		}
+		if !added[t] {
+			added[t] = true
+			merged = append(merged, t)
+		}
+	}
+
+	return merged
+}
+
+// baseType returns the type refined by a guessed type, or the type itself.
+func baseType(typename string) string {
+	if base, ok := baseTypes[typename]; ok {
+		return base
+	}
+	return typename
+}
+
+// guessType returns the type of a primitive value, refined from its
+// value and the name of its field when it looks like a richer type.
+func guessType(name string, node *parsers.Node) string {
+	switch node.Type {
+	case parsers.Bool:
+		return "bool"
+
+	case parsers.Int:
+		if hasHint(name, portHints) && isValid("port", node.Value) {
+			return "port"
+		}
+		return "int"
+
+	case parsers.Float:
+		return "float"
+
+	case parsers.String:
+		value, _ := node.Value.(string)
+		if isHostPort(value) {
+			return "host_port"
+		} else if isHost(name, value) {
+			return "host"
+		} else if isFile(name, value) {
+			return "file"
+		}
+		return "string"
+	}
+
+	return "string"
+}
+
+// isHostPort checks if the value is a host:port pair with an IP address or a
+// host name with a domain, so values like times aren't taken for them.
+func isHostPort(value string) bool {
+	host, _, err := net.SplitHostPort(value)
+	if err != nil || !isValid("host_port", value) {
+		return false
+	}
+
+	// The host of a host:port pair is always named as a host
+	return isHost("host", host)
+}
+
+// isHost checks if the value is an IP address or localhost, or a host
+// name with a domain in a field whose name hints at a host.
+func isHost(name string, value string) bool {
+	if !isValid("host", value) {
+		return false
+	}
+
+	return net.ParseIP(value) != nil || value == "localhost" || (hasHint(name, hostHints) && strings.Contains(value, "."))
+}
+
+// isFile checks if the value is an absolute or relative path, to a file
+// with an extension or in a field whose name hints at a file.
+func isFile(name string, value string) bool {
+	isPath := false
+	for _, prefix := range []string{"/", "./", "../", "~/"} {
+		if strings.HasPrefix(value, prefix) {
+			isPath = true
+		}
+	}
+	if !isPath || strings.ContainsAny(value, " \t\n") {
+		return false
+	}
+
+	return filepath.Ext(value) != "" || hasHint(name, fileHints)
+}
+
+// isValid checks if the value can be made into the type.
+func isValid(typename string, value interface{}) bool {
+	_, err := types.MakeType(typename, value)
+	return err == nil
+}
+
+// hasHint checks if the name of a field contains any of the hints.
+func hasHint(name string, hints []string) bool {
+	name = strings.ToLower(name)
+	for _, hint := range hints {
+		if strings.Contains(name, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinSegment returns a new key with the segment added to key.
+func joinSegment(key *parsers.NodeKey, segment string) *parsers.NodeKey {
+	segments := make([]string, 0, len(key.Segments)+1)
+	segments = append(segments, key.Segments...)
+	return &parsers.NodeKey{Segments: append(segments, segment)}
+}
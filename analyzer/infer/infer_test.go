@@ -0,0 +1,142 @@
+package infer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestInfer tests that fields are inferred in file order with their types,
+// guessed types for ports, hosts, host:port pairs and files, and optional
+// fields when they are missing in some samples or list elements.
+func TestInfer(t *testing.T) {
+	nameOnLine := func(line int) parsers.TokenLocation {
+		return parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: line, Column: 0},
+			End:   parsers.CharLocation{Line: line, Column: 1},
+		}
+	}
+
+	samples := []*parsers.Node{
+		{
+			Type: parsers.Object,
+			Value: map[string]*parsers.Node{
+				"server": {
+					Type: parsers.Object,
+					Value: map[string]*parsers.Node{
+						"host": {Type: parsers.String, Value: "db.example.com", NameLocation: nameOnLine(1)},
+						"port": {Type: parsers.Int, Value: 5432, NameLocation: nameOnLine(2)},
+						"cert": {Type: parsers.String, Value: "/etc/ssl/cert.pem", NameLocation: nameOnLine(3)},
+					},
+					NameLocation: nameOnLine(0),
+				},
+				"dns_servers": {
+					Type:         parsers.Array,
+					Value:        []*parsers.Node{{Type: parsers.String, Value: "8.8.8.8"}},
+					NameLocation: nameOnLine(4),
+				},
+				"items": {
+					Type: parsers.Array,
+					Value: []*parsers.Node{
+						{
+							Type: parsers.Object,
+							Value: map[string]*parsers.Node{
+								"name": {Type: parsers.String, Value: "a", NameLocation: nameOnLine(6)},
+							},
+						},
+						{
+							Type: parsers.Object,
+							Value: map[string]*parsers.Node{
+								"name":   {Type: parsers.String, Value: "b", NameLocation: nameOnLine(7)},
+								"weight": {Type: parsers.Int, Value: 2, NameLocation: nameOnLine(8)},
+							},
+						},
+					},
+					NameLocation: nameOnLine(5),
+				},
+				"timeout": {Type: parsers.Int, Value: 30, NameLocation: nameOnLine(9)},
+				"proxy":   {Type: parsers.Null, NameLocation: nameOnLine(10)},
+				"debug":   {Type: parsers.Bool, Value: true, NameLocation: nameOnLine(11)},
+			},
+		},
+		{
+			Type: parsers.Object,
+			Value: map[string]*parsers.Node{
+				"server": {
+					Type: parsers.Object,
+					Value: map[string]*parsers.Node{
+						"host": {Type: parsers.String, Value: "localhost", NameLocation: nameOnLine(1)},
+						"port": {Type: parsers.Int, Value: 8080, NameLocation: nameOnLine(2)},
+					},
+					NameLocation: nameOnLine(0),
+				},
+				"dns_servers": {Type: parsers.Array, Value: []*parsers.Node{}, NameLocation: nameOnLine(3)},
+				"items":       {Type: parsers.Array, Value: []*parsers.Node{}, NameLocation: nameOnLine(4)},
+				"timeout":     {Type: parsers.Float, Value: 2.5, NameLocation: nameOnLine(5)},
+				"proxy":       {Type: parsers.String, Value: "proxy.example.com:3128", NameLocation: nameOnLine(6)},
+				"mode":        {Type: parsers.String, Value: "fast", NameLocation: nameOnLine(7)},
+			},
+		},
+	}
+
+	field := func(segments []string, typename string, optional bool, nullable bool) spec.FieldSpec {
+		return spec.FieldSpec{
+			Field:    &parsers.NodeKey{Segments: segments},
+			Type:     typename,
+			Optional: optional,
+			Nullable: nullable,
+			Checks:   []spec.CheckWithLocation{},
+		}
+	}
+
+	expectedSpec := &spec.Specification{
+		File:                 "./config.json",
+		FileFormat:           "json",
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []spec.FieldSpec{
+			field([]string{"server"}, "object", false, false),
+			field([]string{"server", "host"}, "host", false, false),
+			field([]string{"server", "port"}, "port", false, false),
+			field([]string{"server", "cert"}, "file", true, false),
+			field([]string{"dns_servers"}, "list<host>", false, false),
+			field([]string{"items"}, "list<object>", false, false),
+			field([]string{"items", "[*]", "name"}, "string", false, false),
+			field([]string{"items", "[*]", "weight"}, "int", true, false),
+			field([]string{"timeout"}, "float", false, false),
+			field([]string{"proxy"}, "host_port", false, true),
+			field([]string{"debug"}, "bool", true, false),
+			field([]string{"mode"}, "string", true, false),
+		},
+	}
+
+	result := Infer("./config.json", "json", samples)
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestMergeTypes tests that guessed types the values don't agree on are
+// replaced by their base type, and that ints are covered by floats.
+func TestMergeTypes(t *testing.T) {
+	tests := []struct {
+		typenames []string
+		expected  []string
+	}{
+		{typenames: []string{"port"}, expected: []string{"port"}},
+		{typenames: []string{"port", "int"}, expected: []string{"int"}},
+		{typenames: []string{"host", "host_port"}, expected: []string{"string"}},
+		{typenames: []string{"port", "float"}, expected: []string{"float"}},
+		{typenames: []string{"int", "string"}, expected: []string{"int", "string"}},
+		{typenames: []string{"file", "bool"}, expected: []string{"file", "bool"}},
+	}
+
+	for _, test := range tests {
+		if result := mergeTypes(test.typenames); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("mergeTypes(%v) = %v, want %v", test.typenames, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/analyzer/types"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// addObjectPropertyFields returns the fields with a field added for each
+// property with checks of the custom objects they use, so the checks of
+// object definitions run wherever the objects are used. Properties of
+// objects in lists and maps are added with wildcards, to be expanded for
+// each element.
+func addObjectPropertyFields(fieldSpecs []spec.FieldSpec, objects []spec.ObjectDef) []spec.FieldSpec {
+	objectsByName := make(map[string]spec.ObjectDef)
+	for _, object := range objects {
+		objectsByName[object.Name] = object
+	}
+
+	result := make([]spec.FieldSpec, 0, len(fieldSpecs))
+	result = append(result, fieldSpecs...)
+	for _, fspec := range fieldSpecs {
+		result = append(result, objectPropertyFields(fspec.Field, fspec.Type, objectsByName, map[string]bool{})...)
+	}
+
+	return result
+}
+
+// objectPropertyFields returns the fields for the properties with checks of
+// the object at key, declared with typename, and of the objects nested in it.
+// Objects already being visited are skipped, so recursive types end.
+func objectPropertyFields(key *parsers.NodeKey, typename string, objects map[string]spec.ObjectDef, visiting map[string]bool) []spec.FieldSpec {
+	// Elements of lists and values of maps are matched with a wildcard
+	if strings.HasPrefix(typename, "list<") && strings.HasSuffix(typename, ">") {
+		return objectPropertyFields(joinSegment(key, parsers.WildcardSegment), typename[5:len(typename)-1], objects, visiting)
+	}
+	if _, valueType, ok := types.MapTypes(typename); ok {
+		return objectPropertyFields(joinSegment(key, parsers.WildcardSegment), valueType, objects, visiting)
+	}
+
+	object, ok := objects[typename]
+	if !ok || visiting[typename] {
+		return nil
+	}
+	visiting[typename] = true
+	defer delete(visiting, typename)
+
+	fieldSpecs := []spec.FieldSpec{}
+	for _, property := range object.Properties {
+		propertyKey := joinSegment(key, property.Name)
+
+		if len(property.Checks) > 0 {
+			fieldSpecs = append(fieldSpecs, spec.FieldSpec{
+				Field:            propertyKey,
+				Type:             property.Type,
+				Optional:         property.Optional,
+				Default:          property.Default,
+				DefaultValue:     property.DefaultValue,
+				Notes:            property.Notes,
+				Strict:           property.Strict,
+				Nullable:         property.Nullable,
+				Severity:         property.Severity,
+				Checks:           property.Checks,
+				SpecFile:         object.SpecFile,
+				FieldLocation:    property.NameLocation,
+				TypeLocation:     property.TypeLocation,
+				OptionalLocation: property.OptionalLocation,
+				DefaultLocation:  property.DefaultLocation,
+				NotesLocation:    property.NotesLocation,
+				StrictLocation:   property.StrictLocation,
+				NullableLocation: property.NullableLocation,
+				SeverityLocation: property.SeverityLocation,
+			})
+		}
+
+		fieldSpecs = append(fieldSpecs, objectPropertyFields(propertyKey, property.Type, objects, visiting)...)
+	}
+
+	return fieldSpecs
+}
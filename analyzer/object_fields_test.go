@@ -0,0 +1,70 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestAddObjectPropertyFields tests that the properties with checks of the
+// custom objects used by fields are added as fields, also in lists, maps and
+// nested objects, and that recursive objects end.
+func TestAddObjectPropertyFields(t *testing.T) {
+	check := []spec.CheckWithLocation{{Check: "gte(1)"}}
+	objects := []spec.ObjectDef{
+		{
+			Name: "api_info",
+			Properties: []spec.ObjectPropertyDef{
+				{Name: "endpoint", Type: "string"},
+				{Name: "timeout", Type: "int", Checks: check},
+				{Name: "auth", Type: "auth_info", Optional: true},
+			},
+		},
+		{
+			Name: "auth_info",
+			Properties: []spec.ObjectPropertyDef{
+				{Name: "retries", Type: "int", Default: "3", DefaultValue: 3, Checks: check},
+			},
+		},
+		{
+			Name: "tree",
+			Properties: []spec.ObjectPropertyDef{
+				{Name: "depth", Type: "int", Checks: check},
+				{Name: "children", Type: "list<tree>"},
+			},
+		},
+	}
+
+	fieldSpecs := []spec.FieldSpec{
+		{Field: &parsers.NodeKey{Segments: []string{"apis"}}, Type: "list<api_info>"},
+		{Field: &parsers.NodeKey{Segments: []string{"root"}}, Type: "tree"},
+		{Field: &parsers.NodeKey{Segments: []string{"services"}}, Type: "map<string,api_info>"},
+	}
+
+	expected := []string{
+		"apis",
+		"root",
+		"services",
+		"apis[*].timeout",
+		"apis[*].auth.retries",
+		"root.depth",
+		"services[*].timeout",
+		"services[*].auth.retries",
+	}
+
+	result := addObjectPropertyFields(fieldSpecs, objects)
+	keys := make([]string, 0, len(result))
+	for _, fspec := range result {
+		keys = append(keys, fspec.Field.String())
+	}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected: %v\nGot: %v", expected, keys)
+	}
+
+	// Derived fields keep the metadata of the property
+	if result[4].DefaultValue != 3 || !reflect.DeepEqual(result[4].Checks, check) {
+		t.Errorf("Expected default and checks of property, got: %#v", result[4])
+	}
+}
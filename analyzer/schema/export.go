@@ -0,0 +1,376 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/analyzer/types"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// schemaDialect is the version of JSON Schema of the exported schemas.
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Checks that JSON Schema can express. Arguments of checks are written
+// without spaces in specifications, and strings aren't unescaped in checks.
+var (
+	numberCheckRegexp = regexp.MustCompile(`^(gte|gt|lte|lt|eq)\((-?[0-9]+(?:\.[0-9]+)?)\)$`)
+	rangeCheckRegexp  = regexp.MustCompile(`^range\((-?[0-9]+(?:\.[0-9]+)?),(-?[0-9]+(?:\.[0-9]+)?)\)$`)
+	stringCheckRegexp = regexp.MustCompile(`^(eq|regex)\("((?:[^"\\]|\\.)*)"\)$`)
+	lengthCheckRegexp = regexp.MustCompile(`^len\(\)\.(gte|gt|lte|lt|eq)\(([0-9]+)\)$`)
+)
+
+// numberKeywords are the keywords of JSON Schema for each comparison of numbers.
+var numberKeywords = map[string][]string{
+	"gte": {"minimum"},
+	"gt":  {"exclusiveMinimum"},
+	"lte": {"maximum"},
+	"lt":  {"exclusiveMaximum"},
+	"eq":  {"const"},
+}
+
+type exporter struct {
+	objects map[string]bool // Names of the custom objects, exported as definitions
+	issues  []Issue
+}
+
+// Export returns the JSON Schema of the config files described by the
+// specification, with its custom objects as definitions in $defs. The
+// checks that JSON Schema can't express, and the parts of the specification
+// it can't describe, are returned as issues.
+func Export(s *spec.Specification) ([]byte, []Issue) {
+	ex := &exporter{
+		objects: make(map[string]bool),
+		issues:  []Issue{},
+	}
+	for _, object := range s.Objects {
+		ex.objects[object.Name] = true
+	}
+
+	// Specifications this one depends on are not exported
+	if s.Extends != "" {
+		ex.addIssue(s.ExtendsLocation, "fields of the extended specification %s are not exported", s.Extends)
+	}
+	aliases := make([]string, 0, len(s.Imports))
+	for alias := range s.Imports {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		ex.addIssue(s.ImportsLocation[alias], "fields of the imported specification %s are not exported", alias)
+	}
+
+	root := newJSONObject()
+	root.set("$schema", schemaDialect)
+	root.set("type", "object")
+	root.object("properties")
+
+	for _, fspec := range s.Fields {
+		fieldSchema, ok := ex.fieldSchema(root, fspec)
+		if ok {
+			ex.exportField(fieldSchema, fspec.Field.String(), fspec)
+		}
+	}
+
+	if s.Strict {
+		root.set("additionalProperties", false)
+	}
+
+	// Custom objects
+	if len(s.Objects) > 0 {
+		definitions := root.object("$defs")
+		for _, object := range s.Objects {
+			definition := definitions.object(object.Name)
+			definition.set("type", "object")
+			properties := definition.object("properties")
+			for _, property := range object.Properties {
+				ex.exportField(properties.object(property.Name), object.Name+"."+property.Name, spec.FieldSpec{
+					Type:         property.Type,
+					Default:      property.Default,
+					DefaultValue: property.DefaultValue,
+					Notes:        property.Notes,
+					Strict:       property.Strict,
+					Nullable:     property.Nullable,
+					Checks:       property.Checks,
+					TypeLocation: property.TypeLocation,
+				})
+				if !property.Optional {
+					addRequired(definition, property.Name)
+				}
+			}
+		}
+	}
+
+	content, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		ex.addIssue(parsers.TokenLocation{}, "failed to encode schema: %s", err.Error())
+		return nil, ex.issues
+	}
+
+	return append(content, '\n'), ex.issues
+}
+
+// fieldSchema returns the schema of a field in the schema of the config file,
+// with the schemas of the objects and lists it is in. Fields that are not
+// optional are required in the object they are in.
+func (ex *exporter) fieldSchema(root *jsonObject, fspec spec.FieldSpec) (*jsonObject, bool) {
+	for _, segment := range fspec.Field.Segments {
+		if segment != parsers.WildcardSegment && strings.HasPrefix(segment, "[") {
+			ex.addIssue(fspec.FieldLocation, "field %s of a single list element is not supported", fspec.Field.String())
+			return nil, false
+		}
+	}
+
+	current := root
+	for i, segment := range fspec.Field.Segments {
+		if segment == parsers.WildcardSegment {
+			if _, ok := current.get("type"); !ok {
+				current.set("type", "array")
+			}
+			current = current.object("items")
+			continue
+		}
+
+		if _, ok := current.get("type"); !ok {
+			current.set("type", "object")
+		}
+		properties := current.object("properties")
+		if i == len(fspec.Field.Segments)-1 && !fspec.Optional {
+			addRequired(current, segment)
+		}
+		current = properties.object(segment)
+	}
+
+	return current, true
+}
+
+// exportField adds the type, metadata and checks of a field to its schema.
+func (ex *exporter) exportField(schema *jsonObject, name string, fspec spec.FieldSpec) {
+	ex.exportType(schema, name, fspec.Type, fspec.TypeLocation)
+	if fspec.Nullable {
+		allowNull(schema)
+	}
+	if fspec.Notes != "" {
+		schema.set("description", fspec.Notes)
+	}
+	if fspec.DefaultValue != nil {
+		schema.set("default", fspec.DefaultValue)
+	}
+	if fspec.Strict {
+		schema.set("additionalProperties", false)
+	}
+
+	for _, check := range fspec.Checks {
+		if !exportCheck(schema, fspec.Type, check.Check) {
+			ex.addIssue(check.Location, "check %s of %s can't be expressed in JSON Schema", check.Check, name)
+		}
+	}
+}
+
+// exportType adds the keywords describing the values of a type to a schema.
+func (ex *exporter) exportType(schema *jsonObject, name string, typename string, location parsers.TokenLocation) {
+	if alternatives := types.UnionTypes(typename); len(alternatives) > 1 {
+		anyOf := make([]interface{}, 0, len(alternatives))
+		for _, alternative := range alternatives {
+			alternativeSchema := newJSONObject()
+			ex.exportType(alternativeSchema, name, alternative, location)
+			anyOf = append(anyOf, alternativeSchema)
+		}
+		schema.set("anyOf", anyOf)
+		return
+	}
+
+	if values, ok := types.EnumValues(typename); ok {
+		schema.set("enum", values)
+		return
+	}
+
+	if strings.HasPrefix(typename, "list<") && strings.HasSuffix(typename, ">") {
+		schema.set("type", "array")
+		ex.exportType(schema.object("items"), name+parsers.WildcardSegment, typename[5:len(typename)-1], location)
+		return
+	}
+
+	if _, valueType, ok := types.MapTypes(typename); ok {
+		schema.set("type", "object")
+		ex.exportType(schema.object("additionalProperties"), name+".*", strings.TrimSpace(valueType), location)
+		return
+	}
+
+	if tag, objectTypes, ok := types.TaggedVariants(typename); ok {
+		anyOf := make([]interface{}, 0, len(objectTypes))
+		for _, objectType := range objectTypes {
+			variantSchema := newJSONObject()
+			ex.exportType(variantSchema, name, objectType, location)
+			anyOf = append(anyOf, variantSchema)
+		}
+		schema.set("anyOf", anyOf)
+		ex.addIssue(location, "the variant of %s is not picked by its tag %s in JSON Schema", name, tag)
+		return
+	}
+
+	if ex.objects[typename] {
+		schema.set("$ref", "#/$defs/"+typename)
+		return
+	}
+
+	switch typename {
+	case "bool":
+		schema.set("type", "boolean")
+	case "int":
+		schema.set("type", "integer")
+	case "float":
+		schema.set("type", "number")
+	case "string", "file":
+		schema.set("type", "string")
+	case "null":
+		schema.set("type", "null")
+	case "object":
+		schema.set("type", "object")
+	case "host":
+		schema.set("type", "string")
+		schema.set("format", "hostname")
+	case "host_port":
+		schema.set("type", "string")
+		schema.set("pattern", ":[0-9]+$")
+	case "port":
+		schema.set("type", "integer")
+		schema.set("minimum", 1)
+		schema.set("maximum", 65535)
+	default:
+		ex.addIssue(location, "type %s of %s is not supported", typename, name)
+	}
+}
+
+// exportCheck adds the keywords for a check on values of the type to a
+// schema, and returns whether JSON Schema can express the check.
+func exportCheck(schema *jsonObject, typename string, check string) bool {
+	switch {
+	case typename == "int" || typename == "float" || typename == "port":
+		if match := numberCheckRegexp.FindStringSubmatch(check); match != nil {
+			for _, keyword := range numberKeywords[match[1]] {
+				schema.set(keyword, parseNumber(match[2]))
+			}
+			return true
+		}
+		if match := rangeCheckRegexp.FindStringSubmatch(check); match != nil {
+			schema.set("minimum", parseNumber(match[1]))
+			schema.set("maximum", parseNumber(match[2]))
+			return true
+		}
+
+	case typename == "string" || typename == "file" || typename == "host" || typename == "host_port":
+		// Hosts are converted to strings to be compared
+		if typename == "host" || typename == "host_port" {
+			if !strings.HasPrefix(check, "toString().") {
+				return false
+			}
+			check = strings.TrimPrefix(check, "toString().")
+		}
+		if match := stringCheckRegexp.FindStringSubmatch(check); match != nil {
+			if match[1] == "eq" {
+				schema.set("const", match[2])
+			} else {
+				schema.set("pattern", match[2])
+			}
+			return true
+		}
+
+	case strings.HasPrefix(typename, "list<"):
+		return exportLengthCheck(schema, check, "minItems", "maxItems")
+
+	case strings.HasPrefix(typename, "map<"):
+		return exportLengthCheck(schema, check, "minProperties", "maxProperties")
+	}
+
+	return false
+}
+
+// exportLengthCheck adds the keywords for a check on the length of a list or
+// a map to a schema, and returns whether the check is on the length.
+func exportLengthCheck(schema *jsonObject, check string, minKeyword string, maxKeyword string) bool {
+	match := lengthCheckRegexp.FindStringSubmatch(check)
+	if match == nil {
+		return false
+	}
+
+	length, _ := strconv.Atoi(match[2])
+	switch match[1] {
+	case "gte":
+		schema.set(minKeyword, length)
+	case "gt":
+		schema.set(minKeyword, length+1)
+	case "lte":
+		schema.set(maxKeyword, length)
+	case "lt":
+		if length == 0 {
+			return false
+		}
+		schema.set(maxKeyword, length-1)
+	case "eq":
+		schema.set(minKeyword, length)
+		schema.set(maxKeyword, length)
+	}
+
+	return true
+}
+
+// allowNull makes a schema accept null values.
+func allowNull(schema *jsonObject) {
+	if typename, ok := schema.get("type"); ok {
+		if typename, ok := typename.(string); ok {
+			schema.set("type", []string{typename, "null"})
+			return
+		}
+	}
+
+	if values, ok := schema.get("enum"); ok {
+		schema.set("enum", append(values.([]interface{}), nil))
+		return
+	}
+
+	if anyOf, ok := schema.get("anyOf"); ok {
+		schema.set("anyOf", append(anyOf.([]interface{}), map[string]string{"type": "null"}))
+		return
+	}
+
+	if ref, ok := schema.get("$ref"); ok {
+		schema.remove("$ref")
+		reference := newJSONObject()
+		reference.set("$ref", ref)
+		schema.set("anyOf", []interface{}{reference, map[string]string{"type": "null"}})
+	}
+}
+
+// addRequired adds a property to the required properties of an object schema.
+func addRequired(schema *jsonObject, name string) {
+	required, _ := schema.get("required")
+	names, _ := required.([]string)
+	for _, requiredName := range names {
+		if requiredName == name {
+			return
+		}
+	}
+	schema.set("required", append(names, name))
+}
+
+// parseNumber returns the int or float written in a check.
+func parseNumber(text string) interface{} {
+	if value, err := strconv.Atoi(text); err == nil {
+		return value
+	}
+	value, _ := strconv.ParseFloat(text, 64)
+	return value
+}
+
+func (ex *exporter) addIssue(location parsers.TokenLocation, format string, args ...interface{}) {
+	ex.issues = append(ex.issues, Issue{
+		Message:  fmt.Sprintf(format, args...),
+		Location: location,
+	})
+}
@@ -0,0 +1,169 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestExport tests that fields are exported as properties of nested objects
+// and lists, with required, types, metadata and custom objects as
+// definitions, that checks are exported as constraints, and that what
+// JSON Schema can't express is reported.
+func TestExport(t *testing.T) {
+	key := func(segments ...string) *parsers.NodeKey {
+		return &parsers.NodeKey{Segments: segments}
+	}
+
+	specification := &spec.Specification{
+		File:    "./config.json",
+		Imports: map[string]string{"db": "./db.cms"},
+		Strict:  true,
+		Fields: []spec.FieldSpec{
+			{Field: key("server"), Type: "object"},
+			{Field: key("server", "host"), Type: "host", Default: "localhost", DefaultValue: "localhost", Notes: "Host name"},
+			{Field: key("server", "port"), Type: "port", Optional: true, Checks: []spec.CheckWithLocation{{Check: "gte(1024)"}}},
+			{Field: key("mode"), Type: `enum("fast","safe")`, Nullable: true},
+			{Field: key("ratio"), Type: "float", Optional: true, Checks: []spec.CheckWithLocation{{Check: "range(0.0,1.0)"}}},
+			{Field: key("name"), Type: "string", Optional: true, Checks: []spec.CheckWithLocation{
+				{Check: `regex("^[a-z]+$")`},
+				{Check: `regex("^a")||regex("^b")`},
+			}},
+			{Field: key("users"), Type: "list<user>", Checks: []spec.CheckWithLocation{{Check: "len().gte(1)"}}},
+			{Field: key("tags"), Type: "list<string>", Optional: true},
+			{Field: key("tags", "[*]"), Type: "string", Checks: []spec.CheckWithLocation{{Check: `regex("^#")`}}},
+			{Field: key("labels"), Type: "map<string,int>", Optional: true},
+			{Field: key("id"), Type: "int|string", Optional: true},
+			{Field: key("items", "[0]", "name"), Type: "string"},
+		},
+		Objects: []spec.ObjectDef{
+			{
+				Name: "user",
+				Properties: []spec.ObjectPropertyDef{
+					{Name: "name", Type: "string"},
+					{Name: "age", Type: "int", Optional: true, Nullable: true, Checks: []spec.CheckWithLocation{{Check: "gte(0)"}}},
+				},
+			},
+		},
+	}
+
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "server": {
+      "type": "object",
+      "properties": {
+        "host": {
+          "type": "string",
+          "format": "hostname",
+          "description": "Host name",
+          "default": "localhost"
+        },
+        "port": {
+          "type": "integer",
+          "minimum": 1024,
+          "maximum": 65535
+        }
+      },
+      "required": [
+        "host"
+      ]
+    },
+    "mode": {
+      "enum": [
+        "fast",
+        "safe",
+        null
+      ]
+    },
+    "ratio": {
+      "type": "number",
+      "minimum": 0,
+      "maximum": 1
+    },
+    "name": {
+      "type": "string",
+      "pattern": "^[a-z]+$"
+    },
+    "users": {
+      "type": "array",
+      "items": {
+        "$ref": "#/$defs/user"
+      },
+      "minItems": 1
+    },
+    "tags": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "pattern": "^#"
+      }
+    },
+    "labels": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "integer"
+      }
+    },
+    "id": {
+      "anyOf": [
+        {
+          "type": "integer"
+        },
+        {
+          "type": "string"
+        }
+      ]
+    }
+  },
+  "required": [
+    "server",
+    "mode",
+    "users"
+  ],
+  "additionalProperties": false,
+  "$defs": {
+    "user": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "age": {
+          "type": [
+            "integer",
+            "null"
+          ],
+          "minimum": 0
+        }
+      },
+      "required": [
+        "name"
+      ]
+    }
+  }
+}
+`
+
+	expectedIssues := []string{
+		"fields of the imported specification db are not exported",
+		`check regex("^a")||regex("^b") of name can't be expressed in JSON Schema`,
+		"field items[0].name of a single list element is not supported",
+	}
+
+	result, issues := Export(specification)
+	if string(result) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+
+	if len(issues) != len(expectedIssues) {
+		t.Fatalf("Expected issues %v, got %v", expectedIssues, issues)
+	}
+	for i, issue := range issues {
+		if issue.Message != expectedIssues[i] {
+			t.Errorf("Expected issue %q, got %q", expectedIssues[i], issue.Message)
+		}
+	}
+}
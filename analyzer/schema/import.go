@@ -0,0 +1,669 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/analyzer/types"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// importedKeywords are the keywords of JSON Schema that are mapped to
+// specifications, or that only document the schema.
+var importedKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true, "examples": true,
+	"definitions": true, "$defs": true, "$ref": true, "type": true, "properties": true, "required": true,
+	"additionalProperties": true, "items": true, "enum": true, "const": true, "default": true, "format": true,
+	"anyOf": true, "oneOf": true, "minimum": true, "maximum": true, "exclusiveMinimum": true,
+	"exclusiveMaximum": true, "minLength": true, "maxLength": true, "pattern": true, "minItems": true,
+	"maxItems": true, "minProperties": true, "maxProperties": true, "readOnly": true, "writeOnly": true,
+	"deprecated": true,
+}
+
+// hostFormats are the string formats of JSON Schema that are host names.
+var hostFormats = map[string]bool{"hostname": true, "ipv4": true}
+
+// maxRegexRepeat is the largest repetition count allowed in regular expressions.
+const maxRegexRepeat = 1000
+
+var objectNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// importedSchema is what a schema maps to in a specification.
+type importedSchema struct {
+	typename     string
+	nullable     bool
+	checks       []spec.CheckWithLocation
+	defaultText  string
+	defaultValue interface{}
+	notes        string
+	strict       bool
+
+	properties *parsers.Node   // Properties of inline objects, added as nested fields
+	required   map[string]bool // Names of the required properties
+	elements   *importedSchema // Schema of the elements of lists
+}
+
+type importer struct {
+	root        *parsers.Node
+	objects     []spec.ObjectDef
+	objectNames map[string]string // Names of the custom objects made from definitions, by reference
+	resolving   map[string]bool   // References being resolved, to stop at cycles
+	issues      []Issue
+}
+
+// Import returns the specification for the config file described by the
+// JSON Schema, which must describe an object. Properties are mapped to fields,
+// definitions of objects to custom objects, and the constraints that CMCL can
+// express to checks. The rest of the schema is returned as issues.
+func Import(file string, root *parsers.Node) (*spec.Specification, []Issue) {
+	im := &importer{
+		root:        root,
+		objects:     []spec.ObjectDef{},
+		objectNames: make(map[string]string),
+		resolving:   make(map[string]bool),
+		issues:      []Issue{},
+	}
+
+	s := &spec.Specification{
+		File:                 file,
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields:               []spec.FieldSpec{},
+	}
+
+	// The top level of the config file must be an object
+	imported := im.importSchema("", root)
+	if imported.typename != "object" {
+		im.addIssue(root, "the top level of the schema must be an object, found %s", imported.typename)
+		return s, im.issues
+	}
+
+	s.Strict = imported.strict
+	s.Fields = im.nestedFields([]spec.FieldSpec{}, &parsers.NodeKey{Segments: []string{}}, imported)
+	s.Objects = im.objects
+
+	return s, im.issues
+}
+
+// addFields adds the field for the schema at key, followed by its nested fields.
+func (im *importer) addFields(fields []spec.FieldSpec, key *parsers.NodeKey, schema *parsers.Node, optional bool) []spec.FieldSpec {
+	imported := im.importSchema(key.String(), schema)
+	fields = append(fields, imported.fieldSpec(key, optional))
+	return im.nestedFields(fields, key, imported)
+}
+
+// nestedFields adds the fields for the properties of an inline object, and
+// for the elements of a list when they have checks or nested fields of their own.
+func (im *importer) nestedFields(fields []spec.FieldSpec, key *parsers.NodeKey, imported *importedSchema) []spec.FieldSpec {
+	if imported.properties != nil {
+		values := imported.properties.Value.(map[string]*parsers.Node)
+		for _, name := range imported.properties.Keys() {
+			fields = im.addFields(fields, joinSegment(key, name), values[name], !imported.required[name])
+		}
+	}
+
+	if imported.elements != nil {
+		elementsKey := joinSegment(key, parsers.WildcardSegment)
+		if len(imported.elements.checks) > 0 {
+			fields = append(fields, imported.elements.fieldSpec(elementsKey, false))
+		}
+		fields = im.nestedFields(fields, elementsKey, imported.elements)
+	}
+
+	return fields
+}
+
+// importSchema maps the schema of the field with the given name.
+func (im *importer) importSchema(name string, schema *parsers.Node) *importedSchema {
+	imported := &importedSchema{
+		typename: "string",
+		checks:   []spec.CheckWithLocation{},
+		required: make(map[string]bool),
+	}
+
+	if schema == nil || schema.Type != parsers.Object {
+		im.addIssue(schema, "the schema of %s must be an object", describe(name))
+		return imported
+	}
+
+	// Report the keywords that aren't mapped
+	for _, keyword := range schema.Keys() {
+		if !importedKeywords[keyword] {
+			im.addIssue(property(schema, keyword), "keyword %s of %s is not supported", keyword, describe(name))
+		}
+	}
+
+	// Get the type of the values
+	if ref := property(schema, "$ref"); ref != nil {
+		im.importReference(name, ref, imported)
+	} else if enum := property(schema, "enum"); enum != nil {
+		im.importEnum(name, enum, imported)
+	} else if constant := property(schema, "const"); constant != nil {
+		im.importEnum(name, &parsers.Node{Type: parsers.Array, Value: []*parsers.Node{constant}, NameLocation: constant.NameLocation}, imported)
+	} else if alternatives := anyOf(schema); alternatives != nil {
+		im.importAlternatives(name, alternatives, imported)
+	} else if typeNode := property(schema, "type"); typeNode != nil {
+		im.importTypes(name, schema, typeNode, imported)
+	} else if property(schema, "properties") != nil || property(schema, "additionalProperties") != nil {
+		im.importType(name, schema, "object", imported)
+	} else if property(schema, "items") != nil {
+		im.importType(name, schema, "array", imported)
+	} else {
+		im.addIssue(schema, "%s has no type, a type for any value is not supported", describe(name))
+	}
+
+	// Constraints are checked on the values of their type
+	imported.checks = append(imported.checks, im.importConstraints(name, schema, imported.typename)...)
+
+	// Annotations
+	if defaultNode := property(schema, "default"); defaultNode != nil {
+		switch defaultNode.Type {
+		case parsers.String, parsers.Int, parsers.Float, parsers.Bool:
+			imported.defaultValue = defaultNode.Value
+			imported.defaultText = fmt.Sprint(defaultNode.Value)
+		default:
+			im.addIssue(defaultNode, "default of %s must be a string, a number or a boolean, found %s", describe(name), defaultNode.Type)
+		}
+	}
+	if description := property(schema, "description"); description != nil && description.Type == parsers.String {
+		imported.notes = description.Value.(string)
+	} else if title := property(schema, "title"); title != nil && title.Type == parsers.String {
+		imported.notes = title.Value.(string)
+	}
+
+	return imported
+}
+
+// importReference maps a reference to a definition. Definitions of objects
+// with properties are mapped to custom objects, and the rest are inlined.
+func (im *importer) importReference(name string, ref *parsers.Node, imported *importedSchema) {
+	path, _ := ref.Value.(string)
+	definitionName, definition := im.definition(path)
+	if definition == nil {
+		im.addIssue(ref, "reference %s of %s is not a definition of the schema", path, describe(name))
+		imported.typename = "object"
+		return
+	}
+
+	if property(definition, "properties") != nil {
+		imported.typename = im.importObject(path, definitionName, definition)
+		return
+	}
+
+	// Definitions that aren't objects are mapped in place of the reference
+	if im.resolving[path] {
+		im.addIssue(ref, "reference %s of %s is recursive", path, describe(name))
+		return
+	}
+	im.resolving[path] = true
+	defer delete(im.resolving, path)
+
+	*imported = *im.importSchema(name, definition)
+}
+
+// definition returns the name and schema of the definition at the path of
+// a reference, in the definitions or $defs of the root schema.
+func (im *importer) definition(path string) (string, *parsers.Node) {
+	for _, prefix := range []string{"#/definitions/", "#/$defs/"} {
+		if strings.HasPrefix(path, prefix) {
+			definitionName := path[len(prefix):]
+			return definitionName, property(property(im.root, prefix[2:len(prefix)-1]), definitionName)
+		}
+	}
+
+	return "", nil
+}
+
+// importObject returns the name of the custom object for the definition,
+// added to the custom objects the first time it is referenced.
+func (im *importer) importObject(path string, definitionName string, definition *parsers.Node) string {
+	if objectName, ok := im.objectNames[path]; ok {
+		return objectName
+	}
+
+	// Object names must be identifiers, and can't hide the types of the values
+	objectName := objectNameRegexp.ReplaceAllString(definitionName, "_")
+	for _, t := range types.GetTypes() {
+		if objectName == t {
+			objectName += "_object"
+		}
+	}
+	im.objectNames[path] = objectName
+
+	// Add the object before its properties, which can reference it
+	index := len(im.objects)
+	im.objects = append(im.objects, spec.ObjectDef{
+		Name:       objectName,
+		Properties: []spec.ObjectPropertyDef{},
+	})
+
+	imported := im.importSchema("definition "+definitionName, definition)
+	if imported.strict {
+		im.addIssue(property(definition, "additionalProperties"), "additionalProperties of definition %s is not supported on custom objects", definitionName)
+	}
+
+	values := imported.properties.Value.(map[string]*parsers.Node)
+	for _, propertyName := range imported.properties.Keys() {
+		propertyImported := im.importSchema(objectName+"."+propertyName, values[propertyName])
+		if propertyImported.properties != nil || (propertyImported.elements != nil && propertyImported.elements.properties != nil) {
+			im.addIssue(values[propertyName], "properties of %s.%s are not mapped, objects in definitions must be references to other definitions", objectName, propertyName)
+		}
+
+		im.objects[index].Properties = append(im.objects[index].Properties, spec.ObjectPropertyDef{
+			Name:         propertyName,
+			Type:         propertyImported.typename,
+			Optional:     !imported.required[propertyName],
+			Default:      propertyImported.defaultText,
+			DefaultValue: propertyImported.defaultValue,
+			Notes:        propertyImported.notes,
+			Strict:       propertyImported.strict,
+			Nullable:     propertyImported.nullable,
+			Checks:       propertyImported.checks,
+		})
+	}
+
+	return objectName
+}
+
+// importEnum maps the values of an enum to an enum type.
+func (im *importer) importEnum(name string, enum *parsers.Node, imported *importedSchema) {
+	values, ok := enum.Value.([]*parsers.Node)
+	if !ok {
+		im.addIssue(enum, "enum of %s must be a list", describe(name))
+		return
+	}
+
+	items := []string{}
+	for _, value := range values {
+		switch value.Type {
+		case parsers.Null:
+			imported.nullable = true
+		case parsers.String:
+			items = append(items, strconv.Quote(value.Value.(string)))
+		case parsers.Int, parsers.Bool:
+			items = append(items, fmt.Sprint(value.Value))
+		case parsers.Float:
+			items = append(items, formatFloat(value.Value.(float64)))
+		default:
+			im.addIssue(value, "values of enum of %s must be strings, numbers or booleans, found %s", describe(name), value.Type)
+		}
+	}
+
+	if len(items) == 0 {
+		imported.typename = "null"
+		return
+	}
+	imported.typename = "enum(" + strings.Join(items, ", ") + ")"
+}
+
+// importAlternatives maps the alternatives of anyOf or oneOf to a union type.
+// Checks only apply to the type of the field, so those of the alternatives are lost.
+func (im *importer) importAlternatives(name string, alternatives *parsers.Node, imported *importedSchema) {
+	typenames := []string{}
+	for _, alternative := range alternatives.Value.([]*parsers.Node) {
+		alternativeImported := im.importSchema(name, alternative)
+		if len(alternativeImported.checks) > 0 || alternativeImported.properties != nil {
+			im.addIssue(alternative, "constraints and properties of the alternatives of %s are not mapped", describe(name))
+		}
+
+		imported.nullable = imported.nullable || alternativeImported.nullable
+		if alternativeImported.typename != "null" && !containsString(typenames, alternativeImported.typename) {
+			typenames = append(typenames, alternativeImported.typename)
+		}
+	}
+
+	if len(typenames) == 0 {
+		imported.typename = "null"
+		return
+	}
+	imported.typename = strings.Join(typenames, " | ")
+}
+
+// importTypes maps the type keyword, a type name or a list of them.
+func (im *importer) importTypes(name string, schema *parsers.Node, typeNode *parsers.Node, imported *importedSchema) {
+	jsonTypes := []string{}
+	switch typeNode.Type {
+	case parsers.String:
+		jsonTypes = append(jsonTypes, typeNode.Value.(string))
+	case parsers.Array:
+		for _, element := range typeNode.Value.([]*parsers.Node) {
+			if jsonType, ok := element.Value.(string); ok {
+				jsonTypes = append(jsonTypes, jsonType)
+			}
+		}
+	}
+
+	typenames := []string{}
+	for _, jsonType := range jsonTypes {
+		if jsonType == "null" {
+			imported.nullable = true
+			continue
+		}
+
+		im.importType(name, schema, jsonType, imported)
+		typenames = append(typenames, imported.typename)
+	}
+
+	switch len(typenames) {
+	case 0:
+		imported.typename = "null"
+	case 1:
+		imported.typename = typenames[0]
+	default:
+		imported.typename = strings.Join(typenames, " | ")
+	}
+}
+
+// importType maps a JSON type, except null, to the type of the values.
+func (im *importer) importType(name string, schema *parsers.Node, jsonType string, imported *importedSchema) {
+	switch jsonType {
+	case "string":
+		imported.typename = "string"
+		if format := property(schema, "format"); format != nil {
+			if formatName, _ := format.Value.(string); hostFormats[formatName] {
+				imported.typename = "host"
+			} else {
+				im.addIssue(format, "format %s of %s is not supported", formatName, describe(name))
+			}
+		}
+
+	case "integer":
+		imported.typename = "int"
+
+	case "number":
+		imported.typename = "float"
+
+	case "boolean":
+		imported.typename = "bool"
+
+	case "array":
+		imported.typename = "list<string>"
+		items := property(schema, "items")
+		if items == nil || items.Type != parsers.Object {
+			im.addIssue(schema, "elements of %s must have a single schema in items", describe(name))
+			return
+		}
+		imported.elements = im.importSchema(name+parsers.WildcardSegment, items)
+		imported.typename = "list<" + imported.elements.typename + ">"
+
+	case "object":
+		imported.typename = "object"
+		properties := property(schema, "properties")
+		additionalProperties := property(schema, "additionalProperties")
+		if additionalProperties != nil && additionalProperties.Type == parsers.Bool {
+			imported.strict = !additionalProperties.Value.(bool)
+		}
+
+		if properties != nil {
+			imported.properties = properties
+			if required := property(schema, "required"); required != nil && required.Type == parsers.Array {
+				for _, requiredName := range required.Value.([]*parsers.Node) {
+					if requiredName, ok := requiredName.Value.(string); ok {
+						imported.required[requiredName] = true
+					}
+				}
+			}
+			if additionalProperties != nil && additionalProperties.Type == parsers.Object {
+				im.addIssue(additionalProperties, "additionalProperties of %s is not supported on objects with properties", describe(name))
+			}
+			return
+		}
+
+		// Objects with dynamic keys are maps
+		if additionalProperties != nil && additionalProperties.Type == parsers.Object {
+			values := im.importSchema(name+".*", additionalProperties)
+			if len(values.checks) > 0 || values.properties != nil {
+				im.addIssue(additionalProperties, "constraints and properties of the values of %s are not mapped", describe(name))
+			}
+			imported.typename = "map<string, " + values.typename + ">"
+		}
+
+	default:
+		im.addIssue(property(schema, "type"), "type %s of %s is not supported", jsonType, describe(name))
+	}
+}
+
+// importConstraints maps the constraints of the schema to checks on the
+// values of the type. Constraints on other types are returned as issues.
+func (im *importer) importConstraints(name string, schema *parsers.Node, typename string) []spec.CheckWithLocation {
+	// Hosts are checked as strings
+	category, prefix := typename, ""
+	switch {
+	case typename == "int" || typename == "float":
+		category = "number"
+	case typename == "host":
+		category, prefix = "string", "toString()."
+	case strings.HasPrefix(typename, "list<"):
+		category = "list"
+	case strings.HasPrefix(typename, "map<"):
+		category = "map"
+	}
+
+	checks := []spec.CheckWithLocation{}
+	addCheck := func(keywordNode *parsers.Node, check string) {
+		checks = append(checks, spec.CheckWithLocation{Check: prefix + check, Location: keywordNode.NameLocation})
+	}
+
+	var minLength, maxLength *parsers.Node
+	for _, keyword := range schema.Keys() {
+		keywordNode := property(schema, keyword)
+
+		// Keywords that aren't constraints, or constraints on the type of the values
+		method, keywordCategory := constraintMethod(keyword, schema)
+		if keywordCategory == "" {
+			continue
+		} else if keywordCategory != category {
+			im.addIssue(keywordNode, "%s of %s is not supported on type %s", keyword, describe(name), typename)
+			continue
+		}
+
+		switch keyword {
+		case "minLength":
+			minLength = keywordNode
+		case "maxLength":
+			maxLength = keywordNode
+		case "pattern":
+			pattern, ok := regexArgument(keywordNode.Value)
+			if !ok {
+				im.addIssue(keywordNode, "pattern of %s can't be written as a CMCL string", describe(name))
+				continue
+			}
+			addCheck(keywordNode, fmt.Sprintf("regex(%s)", pattern))
+		case "minItems", "maxItems", "minProperties", "maxProperties":
+			count, ok := keywordNode.Value.(int)
+			if !ok {
+				im.addIssue(keywordNode, "%s of %s must be an integer", keyword, describe(name))
+				continue
+			}
+			addCheck(keywordNode, fmt.Sprintf("len().%s(%d)", method, count))
+		default:
+			// Draft 4 exclusive bounds are booleans, used by minimum and maximum
+			if keywordNode.Type == parsers.Bool {
+				continue
+			}
+			bound, ok := numberArgument(keywordNode, typename)
+			if !ok {
+				im.addIssue(keywordNode, "%s of %s must be a number of type %s", keyword, describe(name), typename)
+				continue
+			}
+			addCheck(keywordNode, fmt.Sprintf("%s(%s)", method, bound))
+		}
+	}
+
+	// String lengths are checked with a pattern
+	if minLength != nil || maxLength != nil {
+		minCount, maxCount := 0, ""
+		location := minLength
+		if minLength != nil {
+			minCount, _ = minLength.Value.(int)
+		} else {
+			location = maxLength
+		}
+		if maxLength != nil {
+			maxValue, _ := maxLength.Value.(int)
+			maxCount = strconv.Itoa(maxValue)
+			if maxValue > maxRegexRepeat {
+				im.addIssue(maxLength, "maxLength of %s can't be larger than %d", describe(name), maxRegexRepeat)
+				maxCount = ""
+			}
+		}
+		if minCount > maxRegexRepeat {
+			im.addIssue(minLength, "minLength of %s can't be larger than %d", describe(name), maxRegexRepeat)
+			minCount = 0
+		}
+		if minCount > 0 || maxCount != "" {
+			addCheck(location, fmt.Sprintf("regex(\"^(?s).{%d,%s}$\")", minCount, maxCount))
+		}
+	}
+
+	return checks
+}
+
+// constraintMethod returns the CMCL method checking a constraint keyword,
+// and the category of types it applies to, empty if it isn't a constraint.
+func constraintMethod(keyword string, schema *parsers.Node) (string, string) {
+	exclusive := func(keyword string) bool {
+		node := property(schema, keyword)
+		return node != nil && node.Type == parsers.Bool && node.Value.(bool)
+	}
+
+	switch keyword {
+	case "minimum":
+		if exclusive("exclusiveMinimum") {
+			return "gt", "number"
+		}
+		return "gte", "number"
+	case "maximum":
+		if exclusive("exclusiveMaximum") {
+			return "lt", "number"
+		}
+		return "lte", "number"
+	case "exclusiveMinimum":
+		return "gt", "number"
+	case "exclusiveMaximum":
+		return "lt", "number"
+	case "minLength", "maxLength", "pattern":
+		return "regex", "string"
+	case "minItems":
+		return "gte", "list"
+	case "maxItems":
+		return "lte", "list"
+	case "minProperties":
+		return "gte", "map"
+	case "maxProperties":
+		return "lte", "map"
+	}
+
+	return "", ""
+}
+
+// fieldSpec returns the specification of the field at key.
+func (imported *importedSchema) fieldSpec(key *parsers.NodeKey, optional bool) spec.FieldSpec {
+	return spec.FieldSpec{
+		Field:        key,
+		Type:         imported.typename,
+		Optional:     optional,
+		Default:      imported.defaultText,
+		DefaultValue: imported.defaultValue,
+		Notes:        imported.notes,
+		Strict:       imported.strict,
+		Nullable:     imported.nullable,
+		Checks:       imported.checks,
+	}
+}
+
+func (im *importer) addIssue(node *parsers.Node, format string, args ...interface{}) {
+	location := parsers.TokenLocation{}
+	if node != nil {
+		location = node.NameLocation
+		if location == (parsers.TokenLocation{}) {
+			location = node.ValueLocation
+		}
+	}
+
+	im.issues = append(im.issues, Issue{
+		Message:  fmt.Sprintf(format, args...),
+		Location: location,
+	})
+}
+
+// anyOf returns the alternatives of anyOf or oneOf, or nil.
+func anyOf(schema *parsers.Node) *parsers.Node {
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		if alternatives := property(schema, keyword); alternatives != nil && alternatives.Type == parsers.Array {
+			return alternatives
+		}
+	}
+	return nil
+}
+
+// numberArgument returns a bound as an argument of a check on values of
+// the type, and false if ints can't be checked with it.
+func numberArgument(node *parsers.Node, typename string) (string, bool) {
+	switch value := node.Value.(type) {
+	case int:
+		if typename == "float" {
+			return formatFloat(float64(value)), true
+		}
+		return strconv.Itoa(value), true
+	case float64:
+		if typename == "float" {
+			return formatFloat(value), true
+		}
+		if value == float64(int(value)) {
+			return strconv.Itoa(int(value)), true
+		}
+	}
+
+	return "", false
+}
+
+// regexArgument returns a pattern as a CMCL string. Strings aren't unescaped
+// in checks, so quotes are escaped for the regular expression.
+func regexArgument(value interface{}) (string, bool) {
+	pattern, ok := value.(string)
+	if !ok || strings.ContainsAny(pattern, "\r\n") {
+		return "", false
+	}
+
+	return "\"" + strings.ReplaceAll(pattern, "\"", "\\\"") + "\"", true
+}
+
+// formatFloat returns a float with a decimal part, so it isn't taken for an int.
+func formatFloat(value float64) string {
+	float := strconv.FormatFloat(value, 'f', -1, 64)
+	if !strings.Contains(float, ".") {
+		float += ".0"
+	}
+	return float
+}
+
+// describe returns how a field is named in issues.
+func describe(name string) string {
+	if name == "" {
+		return "the top level"
+	} else if strings.HasPrefix(name, "definition ") {
+		return name
+	}
+	return "field " + name
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// joinSegment returns a new key with the segment added to key.
+func joinSegment(key *parsers.NodeKey, segment string) *parsers.NodeKey {
+	segments := make([]string, 0, len(key.Segments)+1)
+	segments = append(segments, key.Segments...)
+	return &parsers.NodeKey{Segments: append(segments, segment)}
+}
@@ -0,0 +1,177 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// parseJSON returns the node of a JSON document, with every token on a new
+// line so that properties keep their order in the document.
+func parseJSON(t *testing.T, text string) *parsers.Node {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	line := 0
+	nextToken := func() (json.Token, parsers.TokenLocation) {
+		token, err := decoder.Token()
+		if err != nil {
+			t.Fatalf("Invalid JSON: %s", err.Error())
+		}
+		line++
+		return token, parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: line, Column: 0},
+			End:   parsers.CharLocation{Line: line, Column: 1},
+		}
+	}
+
+	var parseValue func() *parsers.Node
+	parseValue = func() *parsers.Node {
+		token, location := nextToken()
+		node := &parsers.Node{ValueLocation: location}
+		switch token := token.(type) {
+		case json.Delim:
+			if token == '{' {
+				values := map[string]*parsers.Node{}
+				for decoder.More() {
+					name, nameLocation := nextToken()
+					value := parseValue()
+					value.NameLocation = nameLocation
+					values[name.(string)] = value
+				}
+				node.Type, node.Value = parsers.Object, values
+			} else {
+				values := []*parsers.Node{}
+				for decoder.More() {
+					values = append(values, parseValue())
+				}
+				node.Type, node.Value = parsers.Array, values
+			}
+			nextToken()
+		case string:
+			node.Type, node.Value = parsers.String, token
+		case json.Number:
+			if value, err := token.Int64(); err == nil {
+				node.Type, node.Value = parsers.Int, int(value)
+			} else {
+				value, _ := token.Float64()
+				node.Type, node.Value = parsers.Float, value
+			}
+		case bool:
+			node.Type, node.Value = parsers.Bool, token
+		case nil:
+			node.Type = parsers.Null
+		}
+		return node
+	}
+
+	return parseValue()
+}
+
+// TestImport tests that properties are imported as fields in schema order,
+// with required, enums, unions, lists, maps and definitions of objects,
+// that constraints are imported as checks, and that unsupported keywords
+// are reported.
+func TestImport(t *testing.T) {
+	root := parseJSON(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["server", "mode"],
+		"properties": {
+			"server": {
+				"type": "object",
+				"required": ["host"],
+				"properties": {
+					"host": {"type": "string", "format": "hostname", "default": "localhost"},
+					"port": {"type": "integer", "minimum": 1024, "exclusiveMaximum": 65536}
+				}
+			},
+			"mode": {"enum": ["fast", "safe", null], "description": "Processing mode"},
+			"name": {"type": "string", "minLength": 1, "maxLength": 64, "pattern": "^[a-z\"]+$"},
+			"ratio": {"type": ["number", "null"], "maximum": 1},
+			"users": {"type": "array", "items": {"$ref": "#/$defs/user"}, "minItems": 1},
+			"tags": {"type": "array", "items": {"type": "string", "pattern": "^#"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}, "maxProperties": 10},
+			"id": {"anyOf": [{"type": "integer"}, {"type": "string"}]},
+			"email": {"type": "string", "format": "email"},
+			"retries": {"type": "integer", "multipleOf": 2, "minLength": 1}
+		},
+		"$defs": {
+			"user": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"age": {"type": "integer", "minimum": 0}
+				}
+			}
+		}
+	}`)
+
+	expected := `config: "./config.json"
+
+spec strict {
+    server <object> {
+        host <type: host, default: "localhost">
+        port <int> optional ( gte(1024); lt(65536); )
+    }
+    mode <type: enum("fast", "safe"), notes: "Processing mode", nullable: true>
+    name <string> optional ( regex("^[a-z\"]+$"); regex("^(?s).{1,64}$"); )
+    ratio <type: float, optional: true, nullable: true> ( lte(1.0); )
+    users <list<user>> optional ( len().gte(1); )
+    tags <list<string>> optional
+    tags[*] <string> ( regex("^#"); )
+    labels <map<string, string>> optional ( len().lte(10); )
+    id <int | string> optional
+    email <string> optional
+    retries <int> optional
+}
+
+objects {
+    user {
+        name <string>
+        age <int> optional ( gte(0); )
+    }
+}
+`
+
+	expectedIssues := []string{
+		"format email of field email is not supported",
+		"keyword multipleOf of field retries is not supported",
+		"minLength of field retries is not supported on type int",
+	}
+
+	s, issues := Import("./config.json", root)
+	if result := spec.WriteSpecification(s); result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+
+	if len(issues) != len(expectedIssues) {
+		t.Fatalf("Expected issues %v, got %v", expectedIssues, issues)
+	}
+	for i, issue := range issues {
+		if issue.Message != expectedIssues[i] {
+			t.Errorf("Expected issue %q, got %q", expectedIssues[i], issue.Message)
+		}
+		if issue.Location == (parsers.TokenLocation{}) {
+			t.Errorf("Expected a location for issue %q", issue.Message)
+		}
+	}
+}
+
+// TestImportNotObject tests that schemas whose top level is not an object are reported.
+func TestImportNotObject(t *testing.T) {
+	root := parseJSON(t, `{"type": "array", "items": {"type": "string"}}`)
+
+	s, issues := Import("./config.json", root)
+	if len(s.Fields) != 0 {
+		t.Errorf("Expected no fields, got %v", s.Fields)
+	}
+	if len(issues) != 1 || issues[0].Message != "the top level of the schema must be an object, found list<string>" {
+		t.Errorf("Expected an issue for the top level, got %v", issues)
+	}
+}
@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// Issue is a part of a JSON Schema or of a specification that
+// can't be mapped to the other, with its location in the file.
+type Issue struct {
+	Message  string                `json:"message"`
+	Location parsers.TokenLocation `json:"location"`
+}
+
+// jsonObject is a JSON object that keeps its keys in the order they were set.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newJSONObject() *jsonObject {
+	return &jsonObject{
+		keys:   []string{},
+		values: make(map[string]interface{}),
+	}
+}
+
+// set sets the value of a key, which keeps its position if it was already set.
+func (o *jsonObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *jsonObject) get(key string) (interface{}, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+func (o *jsonObject) remove(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// object returns the object at key, set to an empty object if not set yet.
+func (o *jsonObject) object(key string) *jsonObject {
+	if value, ok := o.values[key].(*jsonObject); ok {
+		return value
+	}
+
+	value := newJSONObject()
+	o.set(key, value)
+	return value
+}
+
+// MarshalJSON encodes the object with its keys in order.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(encodedKey)
+		buf.WriteString(":")
+		buf.Write(encodedValue)
+	}
+	buf.WriteString("}")
+
+	return buf.Bytes(), nil
+}
+
+// property returns the value of a property of an object node, or nil.
+func property(node *parsers.Node, name string) *parsers.Node {
+	if node == nil || node.Type != parsers.Object {
+		return nil
+	}
+
+	return node.Value.(map[string]*parsers.Node)[name]
+}
@@ -0,0 +1,257 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// SetSpecFile records specFile as the file where the fields, checks, custom
+// objects and predicates of the specification were defined, if not recorded yet.
+func (s *Specification) SetSpecFile(specFile string) {
+	for i := range s.Fields {
+		if s.Fields[i].SpecFile == "" {
+			s.Fields[i].SpecFile = specFile
+		}
+		setChecksSpecFile(s.Fields[i].Checks, specFile)
+	}
+
+	for i := range s.Objects {
+		if s.Objects[i].SpecFile == "" {
+			s.Objects[i].SpecFile = specFile
+		}
+		for j := range s.Objects[i].Properties {
+			setChecksSpecFile(s.Objects[i].Properties[j].Checks, specFile)
+		}
+	}
+
+	for i := range s.Predicates {
+		if s.Predicates[i].SpecFile == "" {
+			s.Predicates[i].SpecFile = specFile
+		}
+	}
+}
+
+func setChecksSpecFile(checks []CheckWithLocation, specFile string) {
+	for i := range checks {
+		if checks[i].SpecFile == "" {
+			checks[i].SpecFile = specFile
+		}
+	}
+}
+
+// Extend returns the specification resulting from derived extending base.
+// The fields of derived with the key of a base field override it, and the
+// rest are added after the base fields. Custom objects and predicates of
+// derived replace the base ones with the same name. Imports of both are kept,
+// and the result is strict if either is. Errors are located in derived.
+func Extend(base *Specification, derived *Specification) (*Specification, []SpecParserError) {
+	errs := []SpecParserError{}
+
+	// Start from the fields of the base, with their own checks lists
+	result := *derived
+	result.Fields = make([]FieldSpec, 0, len(base.Fields)+len(derived.Fields))
+	fieldIndexes := make(map[string]int)
+	for _, fspec := range base.Fields {
+		fspec.Checks = append([]CheckWithLocation{}, fspec.Checks...)
+		fieldIndexes[fspec.Field.String()] = len(result.Fields)
+		result.Fields = append(result.Fields, fspec)
+	}
+
+	for _, fspec := range derived.Fields {
+		index, ok := fieldIndexes[fspec.Field.String()]
+		if ok {
+			errs = append(errs, overrideField(&result.Fields[index], fspec)...)
+			continue
+		}
+
+		// New fields need a type, and have no checks to remove
+		if fspec.Type == "" {
+			errs = append(errs, SpecParserError{
+				ErrorMessage: fmt.Sprintf("missing type metadata for field %s, which is not in the extended specification", fspec.Field.String()),
+				Location:     fspec.FieldLocation,
+			})
+			continue
+		}
+		if fspec.RemoveChecks != nil {
+			errs = append(errs, SpecParserError{
+				ErrorMessage: fmt.Sprintf("field %s has no inherited checks to remove, it is not in the extended specification", fspec.Field.String()),
+				Location:     fspec.RemoveLocation,
+			})
+			continue
+		}
+
+		result.Fields = append(result.Fields, fspec)
+	}
+
+	// Objects with the same name are replaced
+	result.Objects = nil
+	objectIndexes := make(map[string]int)
+	for _, object := range base.Objects {
+		objectIndexes[object.Name] = len(result.Objects)
+		result.Objects = append(result.Objects, object)
+	}
+	for _, object := range derived.Objects {
+		if index, ok := objectIndexes[object.Name]; ok {
+			result.Objects[index] = object
+			continue
+		}
+		result.Objects = append(result.Objects, object)
+	}
+
+	// Predicates with the same name are replaced
+	result.Predicates = nil
+	predicateIndexes := make(map[string]int)
+	for _, predicate := range base.Predicates {
+		predicateIndexes[predicate.Name] = len(result.Predicates)
+		result.Predicates = append(result.Predicates, predicate)
+	}
+	for _, predicate := range derived.Predicates {
+		if index, ok := predicateIndexes[predicate.Name]; ok {
+			result.Predicates[index] = predicate
+			continue
+		}
+		result.Predicates = append(result.Predicates, predicate)
+	}
+
+	// Imports of the base are kept, located where the base is extended
+	result.Imports = make(map[string]string)
+	result.ImportsAliasLocation = make(map[string]parsers.TokenLocation)
+	result.ImportsLocation = make(map[string]parsers.TokenLocation)
+	for alias, importPath := range base.Imports {
+		result.Imports[alias] = importPath
+		result.ImportsAliasLocation[alias] = derived.ExtendsLocation
+		result.ImportsLocation[alias] = derived.ExtendsLocation
+	}
+	for alias, importPath := range derived.Imports {
+		if inherited, ok := base.Imports[alias]; ok && inherited != importPath {
+			errs = append(errs, SpecParserError{
+				ErrorMessage: fmt.Sprintf("import alias %s is already used for %s in the extended specification", alias, inherited),
+				Location:     derived.ImportsAliasLocation[alias],
+			})
+			continue
+		}
+		result.Imports[alias] = importPath
+		result.ImportsAliasLocation[alias] = derived.ImportsAliasLocation[alias]
+		result.ImportsLocation[alias] = derived.ImportsLocation[alias]
+	}
+
+	// A strict base keeps the result strict
+	if base.Strict && !derived.Strict {
+		result.Strict = true
+		result.StrictLocation = derived.ExtendsLocation
+	}
+
+	return &result, errs
+}
+
+// overrideField applies the metadata and checks of override to the inherited
+// field. Metadata not given in override keeps its inherited value. Named
+// checks replace the inherited checks with the same name, other checks are added.
+func overrideField(inherited *FieldSpec, override FieldSpec) []SpecParserError {
+	errs := []SpecParserError{}
+
+	// Inherited metadata is located where the field is overridden,
+	// since its locations are in the file of the extended specification
+	inheritLocation := func(location *parsers.TokenLocation) {
+		if isSet(*location) {
+			*location = override.FieldLocation
+		}
+	}
+
+	inherited.Field = override.Field
+	inherited.FieldLocation = override.FieldLocation
+	inherited.SpecFile = override.SpecFile
+
+	if override.Type != "" {
+		inherited.Type = override.Type
+		inherited.TypeLocation = override.TypeLocation
+	} else {
+		inheritLocation(&inherited.TypeLocation)
+	}
+
+	if isSet(override.OptionalLocation) {
+		inherited.Optional = override.Optional
+		inherited.OptionalLocation = override.OptionalLocation
+	} else {
+		inheritLocation(&inherited.OptionalLocation)
+	}
+
+	if isSet(override.DefaultLocation) {
+		inherited.Default = override.Default
+		inherited.DefaultValue = override.DefaultValue
+		inherited.DefaultLocation = override.DefaultLocation
+	} else {
+		inheritLocation(&inherited.DefaultLocation)
+	}
+
+	if isSet(override.NotesLocation) {
+		inherited.Notes = override.Notes
+		inherited.NotesLocation = override.NotesLocation
+	} else {
+		inheritLocation(&inherited.NotesLocation)
+	}
+
+	if isSet(override.StrictLocation) {
+		inherited.Strict = override.Strict
+		inherited.StrictLocation = override.StrictLocation
+	} else {
+		inheritLocation(&inherited.StrictLocation)
+	}
+
+	if isSet(override.NullableLocation) {
+		inherited.Nullable = override.Nullable
+		inherited.NullableLocation = override.NullableLocation
+	} else {
+		inheritLocation(&inherited.NullableLocation)
+	}
+
+	if isSet(override.SeverityLocation) {
+		inherited.Severity = override.Severity
+		inherited.SeverityLocation = override.SeverityLocation
+	} else {
+		inheritLocation(&inherited.SeverityLocation)
+	}
+
+	// Remove inherited checks by name
+	for _, name := range override.RemoveChecks {
+		index := findCheck(inherited.Checks, name)
+		if index < 0 {
+			errs = append(errs, SpecParserError{
+				ErrorMessage: fmt.Sprintf("no inherited check named %s to remove in field %s", name, override.Field.String()),
+				Location:     override.RemoveLocation,
+			})
+			continue
+		}
+		inherited.Checks = append(inherited.Checks[:index], inherited.Checks[index+1:]...)
+	}
+	inherited.RemoveChecks = override.RemoveChecks
+	inherited.RemoveLocation = override.RemoveLocation
+
+	// Replace inherited checks with the same name, add the rest
+	for _, check := range override.Checks {
+		if index := findCheck(inherited.Checks, check.Name); check.Name != "" && index >= 0 {
+			inherited.Checks[index] = check
+			continue
+		}
+		inherited.Checks = append(inherited.Checks, check)
+	}
+
+	return errs
+}
+
+// findCheck returns the index of the check with the given name, or -1.
+func findCheck(checks []CheckWithLocation, name string) int {
+	for i, check := range checks {
+		if check.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isSet returns whether a location was set, metadata given in the spec always
+// has a location, which can't be at the start of the file.
+func isSet(location parsers.TokenLocation) bool {
+	return location != parsers.TokenLocation{}
+}
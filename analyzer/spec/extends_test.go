@@ -0,0 +1,224 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestExtend tests that fields of a specification override the inherited
+// fields, replacing and removing checks by name and changing the severity
+// of the inherited checks, and that errors are reported for overrides that
+// can't be applied.
+func TestExtend(t *testing.T) {
+	location := func(line int) parsers.TokenLocation {
+		return parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: line, Column: 4},
+			End:   parsers.CharLocation{Line: line, Column: 8},
+		}
+	}
+
+	base := &Specification{
+		Fields: []FieldSpec{
+			{
+				Field:        &parsers.NodeKey{Segments: []string{"port"}},
+				Type:         "int",
+				TypeLocation: location(1),
+				Checks: []CheckWithLocation{
+					{Check: "gte(1)", Name: "positive", SpecFile: "base.cms"},
+					{Check: "gte(1024)", Name: "privileged", SpecFile: "base.cms"},
+					{Check: "lt(65536)", SpecFile: "base.cms"},
+				},
+				SpecFile: "base.cms",
+			},
+			{
+				Field:    &parsers.NodeKey{Segments: []string{"host"}},
+				Type:     "string",
+				Checks:   []CheckWithLocation{},
+				SpecFile: "base.cms",
+			},
+		},
+		Objects: []ObjectDef{{Name: "api_info", SpecFile: "base.cms"}, {Name: "auth_info", SpecFile: "base.cms"}},
+	}
+
+	derived := &Specification{
+		File: "./config.json",
+		Fields: []FieldSpec{
+			{
+				Field:            &parsers.NodeKey{Segments: []string{"port"}},
+				FieldLocation:    location(5),
+				Default:          "8080",
+				DefaultValue:     8080,
+				DefaultLocation:  location(6),
+				Severity:         "warning",
+				SeverityLocation: location(7),
+				RemoveChecks:     []string{"privileged"},
+				Checks: []CheckWithLocation{
+					{Check: "gte(2)", Name: "positive", SpecFile: "derived.cms"},
+					{Check: "lt(10000)", SpecFile: "derived.cms"},
+				},
+				SpecFile: "derived.cms",
+			},
+			{
+				Field:    &parsers.NodeKey{Segments: []string{"timeout"}},
+				Type:     "int",
+				Checks:   []CheckWithLocation{},
+				SpecFile: "derived.cms",
+			},
+		},
+		Objects: []ObjectDef{{Name: "auth_info", SpecFile: "derived.cms"}},
+	}
+
+	expectedFields := []FieldSpec{
+		{
+			Field:            &parsers.NodeKey{Segments: []string{"port"}},
+			FieldLocation:    location(5),
+			Type:             "int",
+			TypeLocation:     location(5),
+			Default:          "8080",
+			DefaultValue:     8080,
+			DefaultLocation:  location(6),
+			Severity:         "warning",
+			SeverityLocation: location(7),
+			RemoveChecks:     []string{"privileged"},
+			Checks: []CheckWithLocation{
+				{Check: "gte(2)", Name: "positive", SpecFile: "derived.cms"},
+				{Check: "lt(65536)", SpecFile: "base.cms"},
+				{Check: "lt(10000)", SpecFile: "derived.cms"},
+			},
+			SpecFile: "derived.cms",
+		},
+		base.Fields[1],
+		derived.Fields[1],
+	}
+	expectedObjects := []ObjectDef{{Name: "api_info", SpecFile: "base.cms"}, {Name: "auth_info", SpecFile: "derived.cms"}}
+
+	result, errs := Extend(base, derived)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if result.File != derived.File {
+		t.Errorf("Expected file %s, got %s", derived.File, result.File)
+	}
+	if !reflect.DeepEqual(result.Fields, expectedFields) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedFields, result.Fields)
+	}
+	if !reflect.DeepEqual(result.Objects, expectedObjects) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedObjects, result.Objects)
+	}
+
+	// The base is left as it was
+	if len(base.Fields[0].Checks) != 3 {
+		t.Errorf("Expected the checks of the base to be kept, got: %#v", base.Fields[0].Checks)
+	}
+
+	// Overrides that can't be applied
+	invalid := &Specification{
+		Fields: []FieldSpec{
+			{Field: &parsers.NodeKey{Segments: []string{"port"}}, RemoveChecks: []string{"missing"}, RemoveLocation: location(2)},
+			{Field: &parsers.NodeKey{Segments: []string{"timeout"}}, FieldLocation: location(3)},
+		},
+	}
+	expectedErrors := []SpecParserError{
+		{
+			ErrorMessage: "no inherited check named missing to remove in field port",
+			Location:     location(2),
+		},
+		{
+			ErrorMessage: "missing type metadata for field timeout, which is not in the extended specification",
+			Location:     location(3),
+		},
+	}
+
+	_, errs = Extend(base, invalid)
+	if !reflect.DeepEqual(errs, expectedErrors) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedErrors, errs)
+	}
+}
+
+// TestExtendImports tests that the imports of the base are kept, and that
+// an alias used for another specification in the base is reported.
+func TestExtendImports(t *testing.T) {
+	location := func(line int) parsers.TokenLocation {
+		return parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: line, Column: 4},
+			End:   parsers.CharLocation{Line: line, Column: 8},
+		}
+	}
+
+	base := &Specification{
+		Imports:              map[string]string{"db": "./db.cms", "auth": "./auth.cms"},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{"db": location(1), "auth": location(2)},
+		ImportsLocation:      map[string]parsers.TokenLocation{"db": location(1), "auth": location(2)},
+	}
+
+	derived := &Specification{
+		ExtendsLocation:      location(0),
+		Imports:              map[string]string{"db": "./db.cms", "cache": "./cache.cms"},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{"db": location(3), "cache": location(4)},
+		ImportsLocation:      map[string]parsers.TokenLocation{"db": location(3), "cache": location(4)},
+	}
+
+	expectedImports := map[string]string{"db": "./db.cms", "auth": "./auth.cms", "cache": "./cache.cms"}
+	expectedLocations := map[string]parsers.TokenLocation{"db": location(3), "auth": location(0), "cache": location(4)}
+
+	result, errs := Extend(base, derived)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result.Imports, expectedImports) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedImports, result.Imports)
+	}
+	if !reflect.DeepEqual(result.ImportsAliasLocation, expectedLocations) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedLocations, result.ImportsAliasLocation)
+	}
+	if !reflect.DeepEqual(result.ImportsLocation, expectedLocations) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedLocations, result.ImportsLocation)
+	}
+
+	// The same alias for another specification
+	conflicting := &Specification{
+		Imports:              map[string]string{"db": "./other_db.cms"},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{"db": location(5)},
+		ImportsLocation:      map[string]parsers.TokenLocation{"db": location(5)},
+	}
+	expectedErrors := []SpecParserError{
+		{
+			ErrorMessage: "import alias db is already used for ./db.cms in the extended specification",
+			Location:     location(5),
+		},
+	}
+
+	_, errs = Extend(base, conflicting)
+	if !reflect.DeepEqual(errs, expectedErrors) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedErrors, errs)
+	}
+}
+
+// TestExtendStrict tests that the result is strict if either specification is.
+func TestExtendStrict(t *testing.T) {
+	tests := []struct {
+		baseStrict    bool
+		derivedStrict bool
+		expected      bool
+	}{
+		{baseStrict: false, derivedStrict: false, expected: false},
+		{baseStrict: true, derivedStrict: false, expected: true},
+		{baseStrict: false, derivedStrict: true, expected: true},
+		{baseStrict: true, derivedStrict: true, expected: true},
+	}
+
+	for _, test := range tests {
+		base := &Specification{Strict: test.baseStrict}
+		derived := &Specification{Strict: test.derivedStrict}
+
+		result, errs := Extend(base, derived)
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		}
+		if result.Strict != test.expected {
+			t.Errorf("Expected strict %v for base strict %v and derived strict %v, got %v", test.expected, test.baseStrict, test.derivedStrict, result.Strict)
+		}
+	}
+}
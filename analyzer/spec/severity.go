@@ -0,0 +1,40 @@
+package spec
+
+// Severities of failing checks. Checks and fields without a severity are errors.
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Severities lists the severities from the least to the most severe.
+var Severities = []string{SeverityInfo, SeverityWarning, SeverityError}
+
+// SeverityLevel returns the rank of a severity in Severities, where more
+// severe is higher, or -1 if it isn't a severity. An empty severity is an error.
+func SeverityLevel(severity string) int {
+	if severity == "" {
+		severity = SeverityError
+	}
+
+	for level, s := range Severities {
+		if s == severity {
+			return level
+		}
+	}
+
+	return -1
+}
+
+// CheckSeverity returns the severity of a check of the field, which is the
+// severity of the check if it has one, else the severity of the field.
+func (fspec FieldSpec) CheckSeverity(checkNum int) string {
+	if checkNum >= 0 && checkNum < len(fspec.Checks) && fspec.Checks[checkNum].Severity != "" {
+		return fspec.Checks[checkNum].Severity
+	}
+	if fspec.Severity != "" {
+		return fspec.Severity
+	}
+
+	return SeverityError
+}
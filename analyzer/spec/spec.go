@@ -5,49 +5,90 @@ import "github.com/ConfigMate/configmate/parsers"
 type Specification struct {
 	File       string            `json:"file"`        // File this specification is for
 	FileFormat string            `json:"file_format"` // Format of the file
+	Extends    string            `json:"extends"`     // Specification this specification extends
 	Imports    map[string]string `json:"imports"`     // Imported rulebooks with their aliases
 	Fields     []FieldSpec       `json:"fields"`      // Node that holds the specification of the file
 	Objects    []ObjectDef       `json:"objects"`     // List of object definitions
+	Predicates []PredicateDef    `json:"predicates"`  // List of named predicates
+	Strict     bool              `json:"strict"`      // Whether keys not declared in the spec are reported
 
 	FileLocation         parsers.TokenLocation            `json:"file_location"`          // Location of the file specification
 	FileFormatLocation   parsers.TokenLocation            `json:"file_format_location"`   // Location of the file format
+	ExtendsLocation      parsers.TokenLocation            `json:"extends_location"`       // Location of the extended specification
 	ImportsAliasLocation map[string]parsers.TokenLocation `json:"imports_alias_location"` // Location of the imports alias
 	ImportsLocation      map[string]parsers.TokenLocation `json:"imports_location"`       // Location of the imports field
+	StrictLocation       parsers.TokenLocation            `json:"strict_location"`        // Location of the strict keyword
 }
 
 type FieldSpec struct {
-	Field    *parsers.NodeKey    `json:"field"`    // Field to check
-	Type     string              `json:"type"`     // Type of the field
-	Optional bool                `json:"optional"` // Whether the field is optional
-	Default  string              `json:"default"`  // Default value of the field
-	Notes    string              `json:"notes"`    // Notes about the rule
-	Checks   []CheckWithLocation `json:"checks"`   // List of checks to perform
+	Field        *parsers.NodeKey    `json:"field"`         // Field to check
+	Type         string              `json:"type"`          // Type of the field
+	Optional     bool                `json:"optional"`      // Whether the field is optional
+	Default      string              `json:"default"`       // Default value of the field
+	DefaultValue interface{}         `json:"default_value"` // Typed default value (string, int, float64 or bool)
+	Notes        string              `json:"notes"`         // Notes about the rule
+	Strict       bool                `json:"strict"`        // Whether keys inside the field not declared in the spec are reported
+	Nullable     bool                `json:"nullable"`      // Whether the field can be null
+	Severity     string              `json:"severity"`      // Severity of the checks that fail, error if empty
+	Checks       []CheckWithLocation `json:"checks"`        // List of checks to perform
+	RemoveChecks []string            `json:"remove_checks"` // Names of the inherited checks to remove
+	SpecFile     string              `json:"spec_file"`     // Specification file where the field was defined
 
 	FieldLocation    parsers.TokenLocation `json:"field_location"`    // Location of the field
 	TypeLocation     parsers.TokenLocation `json:"type_location"`     // Location of the type
 	OptionalLocation parsers.TokenLocation `json:"optional_location"` // Location of the optional field
 	DefaultLocation  parsers.TokenLocation `json:"default_location"`  // Location of the default field
 	NotesLocation    parsers.TokenLocation `json:"notes_location"`    // Location of the notes field
+	StrictLocation   parsers.TokenLocation `json:"strict_location"`   // Location of the strict field
+	NullableLocation parsers.TokenLocation `json:"nullable_location"` // Location of the nullable field
+	SeverityLocation parsers.TokenLocation `json:"severity_location"` // Location of the severity field
+	RemoveLocation   parsers.TokenLocation `json:"remove_location"`   // Location of the remove field
 }
 
 type CheckWithLocation struct {
-	Check    string                `json:"check"`    // Name of the check
-	Location parsers.TokenLocation `json:"location"` // Location of the check
+	Check    string                `json:"check"`     // Name of the check
+	Name     string                `json:"name"`      // Name given to the check, empty if unnamed
+	Severity string                `json:"severity"`  // Severity of the check if it fails, the severity of the field if empty
+	SpecFile string                `json:"spec_file"` // Specification file where the check was defined
+	Location parsers.TokenLocation `json:"location"`  // Location of the check
 }
 
 type ObjectDef struct {
 	Name       string              `json:"name"`       // Name of the object
 	Properties []ObjectPropertyDef `json:"properties"` // List of properties of the object
+	SpecFile   string              `json:"spec_file"`  // Specification file where the object was defined
 
 	NameLocation parsers.TokenLocation `json:"name_location"` // Location of the name
 }
 
 type ObjectPropertyDef struct {
-	Name     string `json:"name"`     // Name of the property
-	Type     string `json:"type"`     // Type of the property
-	Optional bool   `json:"optional"` // Whether the property is optional
+	Name         string              `json:"name"`          // Name of the property
+	Type         string              `json:"type"`          // Type of the property
+	Optional     bool                `json:"optional"`      // Whether the property is optional
+	Default      string              `json:"default"`       // Default value of the property
+	DefaultValue interface{}         `json:"default_value"` // Typed default value (string, int, float64 or bool)
+	Notes        string              `json:"notes"`         // Notes about the property
+	Strict       bool                `json:"strict"`        // Whether keys inside the property not declared in the spec are reported
+	Nullable     bool                `json:"nullable"`      // Whether the property can be null
+	Severity     string              `json:"severity"`      // Severity of the checks that fail, error if empty
+	Checks       []CheckWithLocation `json:"checks"`        // List of checks to perform wherever the object is used
 
 	NameLocation     parsers.TokenLocation `json:"name_location"`     // Location of the name
 	TypeLocation     parsers.TokenLocation `json:"type_location"`     // Location of the type
 	OptionalLocation parsers.TokenLocation `json:"optional_location"` // Location of the optional field
+	DefaultLocation  parsers.TokenLocation `json:"default_location"`  // Location of the default field
+	NotesLocation    parsers.TokenLocation `json:"notes_location"`    // Location of the notes field
+	StrictLocation   parsers.TokenLocation `json:"strict_location"`   // Location of the strict field
+	NullableLocation parsers.TokenLocation `json:"nullable_location"` // Location of the nullable field
+	SeverityLocation parsers.TokenLocation `json:"severity_location"` // Location of the severity field
+}
+
+type PredicateDef struct {
+	Name     string   `json:"name"`      // Name of the predicate
+	Params   []string `json:"params"`    // Names of the parameters
+	Check    string   `json:"check"`     // Check the predicate stands for
+	SpecFile string   `json:"spec_file"` // Specification file where the predicate was defined
+
+	NameLocation  parsers.TokenLocation `json:"name_location"`  // Location of the name
+	CheckLocation parsers.TokenLocation `json:"check_location"` // Location of the check
 }
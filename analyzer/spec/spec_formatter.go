@@ -0,0 +1,481 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ConfigMate/configmate/parsers/gen/parser_cmsl"
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// maxLineWidth is the width of the lines over which imports and checks
+// are written one per line.
+const maxLineWidth = 100
+
+// metadataOrder is the order of the items of long metadata expressions.
+var metadataOrder = []string{"type", "optional", "default", "notes", "strict", "nullable", "severity", "remove"}
+
+// declarationContext is a production declaring a field or an object
+// property, with its metadata and checks.
+type declarationContext interface {
+	metadataContext
+	LPAREN() antlr.TerminalNode
+	RPAREN() antlr.TerminalNode
+	SEMICOLON(i int) antlr.TerminalNode
+}
+
+type specFormatter struct {
+	sb         strings.Builder
+	comments   []antlr.Token // Comments not written yet, in order
+	lastLine   int           // Line in the source of the last token written
+	blockStart bool          // Whether nothing was written yet in the current block
+	blankLine  bool          // Whether a blank line must be written before the next line
+}
+
+// FormatSpecification returns the specification in its canonical format.
+// Blocks are indented with four spaces, metadata items are written in a fixed
+// order, names and strings are quoted only as needed, and checks are written
+// on the line of their field unless it gets too long. Comments and blank
+// lines between declarations are kept.
+func FormatSpecification(content []byte) ([]byte, []SpecParserError) {
+	// Create error listener
+	errorListener := &cmslErrorListener{}
+
+	// Create lexer
+	input := antlr.NewInputStream(string(content))
+	lexer := parser_cmsl.NewCMSLLexer(input)
+
+	// Add error listener
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorListener)
+
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	parser := parser_cmsl.NewCMSLParser(stream)
+
+	// Add error listener
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(errorListener)
+
+	tree := parser.Cmsl()
+
+	// Check for errors
+	if len(errorListener.errors) > 0 {
+		return nil, errorListener.errors
+	}
+
+	// Comments are the only tokens in the hidden channel
+	f := &specFormatter{
+		comments:   make([]antlr.Token, 0),
+		blockStart: true,
+	}
+	for _, token := range stream.GetAllTokens() {
+		if token.GetChannel() == antlr.TokenHiddenChannel {
+			f.comments = append(f.comments, token)
+		}
+	}
+
+	f.formatSpecification(tree.Specification())
+
+	// Comments at the end of the file
+	f.writeComments(tree.EOF().GetSymbol(), 0)
+
+	return []byte(f.sb.String()), nil
+}
+
+func (f *specFormatter) formatSpecification(ctx parser_cmsl.ISpecificationContext) {
+	// Config declaration
+	config := ctx.ConfigDeclaration()
+	configLine := "config: " + formatString(config.SHORT_STRING().GetText())
+	if config.IDENTIFIER() != nil {
+		configLine += " " + config.IDENTIFIER().GetText()
+	}
+	f.writeLine(0, config.GetStart(), config.GetStop(), configLine)
+
+	if extends := ctx.ExtendsStatement(); extends != nil {
+		f.writeLine(0, extends.GetStart(), extends.GetStop(), "extends "+formatString(extends.SHORT_STRING().GetText()))
+	}
+
+	if imports := ctx.ImportStatement(); imports != nil {
+		f.formatImports(imports)
+	}
+
+	// Specification body
+	body := ctx.SpecificationBody()
+	bodyLine := "spec {"
+	if body.STRICT_METAD_KW() != nil {
+		bodyLine = "spec strict {"
+	}
+	f.blankLine = true
+	f.writeLine(0, body.GetStart(), body.LBRACE().GetSymbol(), bodyLine)
+	f.blockStart = true
+	for _, item := range body.AllSpecificationItem() {
+		f.formatSpecificationItem(item, 1)
+	}
+	f.closeBlock(0, body.RBRACE().GetSymbol(), body.RBRACE().GetSymbol(), "}")
+
+	// Custom object types
+	if objects := ctx.ObjectDefinitions(); objects != nil {
+		f.blankLine = true
+		f.writeLine(0, objects.GetStart(), objects.LBRACE().GetSymbol(), "objects {")
+		f.blockStart = true
+		for _, object := range objects.AllObjectDefinition() {
+			f.writeLine(1, object.GetStart(), object.LBRACE().GetSymbol(), object.IDENTIFIER().GetText()+" {")
+			f.blockStart = true
+			for _, property := range object.AllObjectPropertyDefinition() {
+				f.formatDeclaration(property, 2, formatName(property.SimpleName()), nil)
+			}
+			f.closeBlock(1, object.RBRACE().GetSymbol(), object.RBRACE().GetSymbol(), "}")
+		}
+		f.closeBlock(0, objects.RBRACE().GetSymbol(), objects.RBRACE().GetSymbol(), "}")
+	}
+
+	// Named predicates
+	if predicates := ctx.PredicateDefinitions(); predicates != nil {
+		f.blankLine = true
+		f.writeLine(0, predicates.GetStart(), predicates.LBRACE().GetSymbol(), "checks {")
+		f.blockStart = true
+		for _, predicate := range predicates.AllPredicateDefinition() {
+			// The first identifier is the name, the rest are the parameters
+			identifiers := predicate.AllIDENTIFIER()
+			params := make([]string, 0, len(identifiers)-1)
+			for _, param := range identifiers[1:] {
+				params = append(params, param.GetText())
+			}
+
+			line := fmt.Sprintf("%s(%s) = %s;", identifiers[0].GetText(), strings.Join(params, ", "), formatTokens(predicate.Check()))
+			f.writeLine(1, predicate.GetStart(), predicate.GetStop(), line)
+		}
+		f.closeBlock(0, predicates.RBRACE().GetSymbol(), predicates.RBRACE().GetSymbol(), "}")
+	}
+}
+
+// formatImports writes the imports on a single line, or one per line if
+// they don't fit or have comments.
+func (f *specFormatter) formatImports(ctx parser_cmsl.IImportStatementContext) {
+	items := make([]string, 0, len(ctx.AllImportItem()))
+	for _, item := range ctx.AllImportItem() {
+		items = append(items, item.IDENTIFIER().GetText()+": "+formatString(item.SHORT_STRING().GetText()))
+	}
+
+	line := "import (" + strings.Join(items, ", ") + ")"
+	if len(line) <= maxLineWidth && !f.hasComments(ctx.GetStart(), ctx.GetStop()) {
+		f.writeLine(0, ctx.GetStart(), ctx.GetStop(), line)
+		return
+	}
+
+	f.writeLine(0, ctx.GetStart(), ctx.LPAREN().GetSymbol(), "import (")
+	for i, item := range ctx.AllImportItem() {
+		if i < len(items)-1 {
+			f.writeLine(1, item.GetStart(), ctx.COMMA(i).GetSymbol(), items[i]+",")
+		} else {
+			f.writeLine(1, item.GetStart(), item.GetStop(), items[i])
+		}
+	}
+	f.closeBlock(0, ctx.RPAREN().GetSymbol(), ctx.RPAREN().GetSymbol(), ")")
+}
+
+// formatSpecificationItem writes a field declaration, followed by the block
+// of its nested fields. Empty blocks are removed.
+func (f *specFormatter) formatSpecificationItem(ctx parser_cmsl.ISpecificationItemContext, depth int) {
+	children := ctx.AllSpecificationItem()
+	if len(children) == 0 {
+		f.formatDeclaration(ctx, depth, formatName(ctx.FieldName()), nil)
+		return
+	}
+
+	f.formatDeclaration(ctx, depth, formatName(ctx.FieldName()), ctx.LBRACE().GetSymbol())
+	f.blockStart = true
+	for _, child := range children {
+		f.formatSpecificationItem(child, depth+1)
+	}
+	f.closeBlock(depth, ctx.RBRACE().GetSymbol(), ctx.RBRACE().GetSymbol(), "}")
+}
+
+// formatDeclaration writes the name, metadata and checks of a declaration,
+// ending with the brace opening its block if lbrace is not nil. Checks are
+// written one per line if they don't fit in the line or have comments.
+func (f *specFormatter) formatDeclaration(ctx declarationContext, depth int, name string, lbrace antlr.Token) {
+	header := name + formatMetadata(ctx)
+	suffix := ""
+	stop := ctx.GetStop()
+	if ctx.ShortMetadataExpression() != nil {
+		stop = ctx.ShortMetadataExpression().GetStop()
+	} else if ctx.LongMetadataExpression() != nil {
+		stop = ctx.LongMetadataExpression().GetStop()
+	}
+	if lbrace != nil {
+		suffix, stop = " {", lbrace
+	}
+
+	checks := ctx.AllNamedCheck()
+	if len(checks) == 0 {
+		f.writeLine(depth, ctx.GetStart(), stop, header+suffix)
+		return
+	}
+
+	formattedChecks := make([]string, 0, len(checks))
+	for _, namedCheck := range checks {
+		formattedCheck := formatTokens(namedCheck.Check())
+		if namedCheck.CheckSeverity() != nil {
+			formattedCheck += " <severity: " + namedCheck.CheckSeverity().IDENTIFIER().GetText() + ">"
+		}
+		formattedCheck += ";"
+		if namedCheck.IDENTIFIER() != nil {
+			formattedCheck = namedCheck.IDENTIFIER().GetText() + ": " + formattedCheck
+		}
+		formattedChecks = append(formattedChecks, formattedCheck)
+	}
+
+	// Checks on the line of the declaration
+	lparen, rparen := ctx.LPAREN().GetSymbol(), ctx.RPAREN().GetSymbol()
+	line := header + " ( " + strings.Join(formattedChecks, " ") + " )" + suffix
+	if len(indentation)*depth+len(line) <= maxLineWidth && !f.hasComments(lparen, rparen) {
+		if lbrace == nil {
+			stop = rparen
+		}
+		f.writeLine(depth, ctx.GetStart(), stop, line)
+		return
+	}
+
+	// One check per line
+	f.writeLine(depth, ctx.GetStart(), lparen, header+" (")
+	for i, namedCheck := range checks {
+		f.writeLine(depth+1, namedCheck.GetStart(), ctx.SEMICOLON(i).GetSymbol(), formattedChecks[i])
+	}
+	if lbrace == nil {
+		lbrace = rparen
+	}
+	f.closeBlock(depth, rparen, lbrace, ")"+suffix)
+}
+
+// formatMetadata returns the metadata of a declaration. Long metadata with
+// only the type, and optional when true, is written in short form.
+func formatMetadata(ctx metadataContext) string {
+	if shortMetadata := ctx.ShortMetadataExpression(); shortMetadata != nil {
+		metadata := " <" + formatTokens(shortMetadata.TypeExpr()) + ">"
+		if shortMetadata.OPTIONAL_METAD_KW() != nil {
+			metadata += " optional"
+		}
+		return metadata
+	}
+
+	items := make([]parser_cmsl.IMetadataItemContext, len(ctx.LongMetadataExpression().AllMetadataItem()))
+	copy(items, ctx.LongMetadataExpression().AllMetadataItem())
+	sort.SliceStable(items, func(i, j int) bool {
+		return metadataRank(items[i]) < metadataRank(items[j])
+	})
+
+	if typeItem, ok := items[0].(*parser_cmsl.TypeMetadataContext); ok {
+		if len(items) == 1 {
+			return " <" + formatTokens(typeItem.TypeExpr()) + ">"
+		}
+		if optionalItem, ok := items[1].(*parser_cmsl.OptionalMetadataContext); ok && len(items) == 2 && optionalItem.BOOL().GetText() == "true" {
+			return " <" + formatTokens(typeItem.TypeExpr()) + "> optional"
+		}
+	}
+
+	formattedItems := make([]string, 0, len(items))
+	for _, item := range items {
+		formattedItems = append(formattedItems, formatMetadataItem(item))
+	}
+
+	return " <" + strings.Join(formattedItems, ", ") + ">"
+}
+
+// formatMetadataItem returns an item of a long metadata expression.
+func formatMetadataItem(ctx parser_cmsl.IMetadataItemContext) string {
+	switch item := ctx.(type) {
+	case *parser_cmsl.TypeMetadataContext:
+		return "type: " + formatTokens(item.TypeExpr())
+	case *parser_cmsl.OptionalMetadataContext:
+		return "optional: " + item.BOOL().GetText()
+	case *parser_cmsl.DefaultMetadataContext:
+		if _, ok := item.Primitive().(*parser_cmsl.StringContext); ok {
+			return "default: " + formatString(item.Primitive().GetText())
+		}
+		return "default: " + item.Primitive().GetText()
+	case *parser_cmsl.NotesMetadataContext:
+		return "notes: " + formatStringExpr(item.StringExpr())
+	case *parser_cmsl.StrictMetadataContext:
+		return "strict: " + item.BOOL().GetText()
+	case *parser_cmsl.NullableMetadataContext:
+		return "nullable: " + item.BOOL().GetText()
+	case *parser_cmsl.SeverityMetadataContext:
+		return "severity: " + item.IDENTIFIER().GetText()
+	case *parser_cmsl.RemoveMetadataContext:
+		checkNames := make([]string, 0, len(item.AllIDENTIFIER()))
+		for _, checkName := range item.AllIDENTIFIER() {
+			checkNames = append(checkNames, checkName.GetText())
+		}
+		return "remove: [" + strings.Join(checkNames, ", ") + "]"
+	}
+
+	return ctx.GetText()
+}
+
+// metadataRank returns the position of a metadata item in metadataOrder.
+func metadataRank(ctx parser_cmsl.IMetadataItemContext) int {
+	// The first token of an item is its keyword
+	for i, keyword := range metadataOrder {
+		if ctx.GetStart().GetText() == keyword {
+			return i
+		}
+	}
+
+	return len(metadataOrder)
+}
+
+// formatName returns a field name with its names quoted only when needed.
+func formatName(tree antlr.Tree) string {
+	switch node := tree.(type) {
+	case parser_cmsl.ISimpleNameContext:
+		if node.LITERAL_STRING() != nil {
+			return writeSegment(removeSingleQuotesInKeys(node.GetText()))
+		}
+		return node.GetText()
+	case antlr.TerminalNode:
+		return node.GetText()
+	}
+
+	var sb strings.Builder
+	for _, child := range tree.GetChildren() {
+		sb.WriteString(formatName(child))
+	}
+
+	return sb.String()
+}
+
+// formatString returns a string of the metadata, where consecutive spaces
+// are not significant, with single spaces and no spaces around the text.
+func formatString(text string) string {
+	return "\"" + removeStrQuotesAndCleanSpaces(text) + "\""
+}
+
+// formatStringExpr returns a string expression, written as a short string
+// unless it needs the quotes of a long string.
+func formatStringExpr(ctx parser_cmsl.IStringExprContext) string {
+	if ctx.SHORT_STRING() != nil {
+		return formatString(ctx.SHORT_STRING().GetText())
+	}
+
+	text := removeStrQuotesAndCleanSpaces(ctx.GetText())
+	if strings.ContainsAny(text, "\"\\") {
+		return ctx.GetText()
+	}
+
+	return "\"" + text + "\""
+}
+
+// formatTokens returns the tokens of a type or a check separated by the
+// canonical spaces.
+func formatTokens(tree antlr.Tree) string {
+	tokens := make([]antlr.Token, 0)
+	collectTokens(tree, &tokens)
+
+	var sb strings.Builder
+	for i, token := range tokens {
+		if i > 0 && spaceBetween(tokens[i-1].GetTokenType(), token.GetTokenType()) {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(token.GetText())
+	}
+
+	return sb.String()
+}
+
+// collectTokens adds the tokens of the tree to tokens, in order.
+func collectTokens(tree antlr.Tree, tokens *[]antlr.Token) {
+	if terminal, ok := tree.(antlr.TerminalNode); ok {
+		*tokens = append(*tokens, terminal.GetSymbol())
+		return
+	}
+
+	for _, child := range tree.GetChildren() {
+		collectTokens(child, tokens)
+	}
+}
+
+// spaceBetween returns whether a space separates two tokens: after commas and
+// colons, around pipes and operators, and around the keywords and braces of
+// conditions and loops.
+func spaceBetween(previous int, next int) bool {
+	switch previous {
+	case parser_cmsl.CMSLParserCOMMA, parser_cmsl.CMSLParserCOLON, parser_cmsl.CMSLParserPIPE,
+		parser_cmsl.CMSLParserAND_SYM, parser_cmsl.CMSLParserOR_SYM, parser_cmsl.CMSLParserCOALESCE_SYM,
+		parser_cmsl.CMSLParserIF_SYM, parser_cmsl.CMSLParserELSEIF_SYM, parser_cmsl.CMSLParserELSE_SYM,
+		parser_cmsl.CMSLParserFOREACH_SYM, parser_cmsl.CMSLParserLBRACE:
+		return true
+	}
+
+	switch next {
+	case parser_cmsl.CMSLParserPIPE, parser_cmsl.CMSLParserAND_SYM, parser_cmsl.CMSLParserOR_SYM,
+		parser_cmsl.CMSLParserCOALESCE_SYM, parser_cmsl.CMSLParserELSEIF_SYM, parser_cmsl.CMSLParserELSE_SYM,
+		parser_cmsl.CMSLParserLBRACE, parser_cmsl.CMSLParserRBRACE:
+		return true
+	}
+
+	return false
+}
+
+// writeLine writes a line with the tokens from start to stop, formatted as
+// text. The comments before stop are written on their own lines before it,
+// and a comment right after stop on the same line is kept at its end.
+func (f *specFormatter) writeLine(depth int, start antlr.Token, stop antlr.Token, text string) {
+	f.writeComments(stop, depth)
+	f.startLine(start, depth)
+	f.sb.WriteString(text)
+
+	// Whitespace is skipped, so a comment after stop is the next token
+	if len(f.comments) > 0 && f.comments[0].GetTokenIndex() == stop.GetTokenIndex()+1 && f.comments[0].GetLine() == stop.GetLine() {
+		f.sb.WriteString(" " + strings.TrimRight(f.comments[0].GetText(), " \t"))
+		f.comments = f.comments[1:]
+	}
+
+	f.sb.WriteString("\n")
+	f.lastLine = stop.GetLine()
+}
+
+// closeBlock writes the line closing a block, from start to stop, after the
+// comments left in the block.
+func (f *specFormatter) closeBlock(depth int, start antlr.Token, stop antlr.Token, text string) {
+	f.writeComments(start, depth+1)
+
+	// Blank lines at the end of blocks are removed
+	f.blockStart = true
+	f.writeLine(depth, start, stop, text)
+}
+
+// writeComments writes the comments before the token, each on its own line.
+func (f *specFormatter) writeComments(before antlr.Token, depth int) {
+	for len(f.comments) > 0 && f.comments[0].GetTokenIndex() < before.GetTokenIndex() {
+		comment := f.comments[0]
+		f.comments = f.comments[1:]
+
+		f.startLine(comment, depth)
+		f.sb.WriteString(strings.TrimRight(comment.GetText(), " \t") + "\n")
+		f.lastLine = comment.GetLine()
+	}
+}
+
+// startLine indents a new line for the token, after a blank line if one
+// was requested or the token was after a blank line in the source.
+func (f *specFormatter) startLine(token antlr.Token, depth int) {
+	if f.blankLine || (!f.blockStart && token.GetLine() > f.lastLine+1) {
+		f.sb.WriteString("\n")
+	}
+	f.blankLine, f.blockStart = false, false
+
+	f.sb.WriteString(strings.Repeat(indentation, depth))
+}
+
+// hasComments returns whether there are comments between two tokens.
+func (f *specFormatter) hasComments(start antlr.Token, stop antlr.Token) bool {
+	for _, comment := range f.comments {
+		if comment.GetTokenIndex() > start.GetTokenIndex() && comment.GetTokenIndex() < stop.GetTokenIndex() {
+			return true
+		}
+	}
+
+	return false
+}
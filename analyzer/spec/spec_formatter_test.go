@@ -0,0 +1,84 @@
+package spec
+
+import (
+	"os"
+	"testing"
+)
+
+// TestFormatSpecification tests that the formatter normalizes indentation,
+// metadata, checks and quoting, keeps comments and blank lines, and that
+// formatted specifications don't change when formatted again.
+func TestFormatSpecification(t *testing.T) {
+	unformattedCMS, err := os.ReadFile("./test_specs/unformatted.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expected := `// Specification of the server config
+config: "./config.json" json
+import (db: "./db.cms", auth: "./auth.cms")
+
+spec strict {
+    // The server
+    server <object> {
+        host <type: host, default: "localhost"> // Host name
+        port <port> optional ( gte(1024); lte(65535); )
+
+        timeout <int> (
+            name_of_a_long_check: gte(1) && lte(300);
+            if (eq(1)) { gte(0) } else { lte(2) };
+            foreach (x: items) { x.gte(1) };
+        )
+    }
+    items <type: list<int>, severity: warning>
+    'log.file' <type: file, notes: "Path of the log file"> (
+        exists(); // must exist
+    )
+}
+
+objects {
+    user {
+        name <string>
+        age <int> optional ( gte(0) <severity: info>; )
+    }
+}
+
+checks {
+    within(min, max) = gte(min) && lte(max);
+}
+// end
+`
+
+	formatted, errs := FormatSpecification(unformattedCMS)
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	if string(formatted) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, formatted)
+	}
+
+	// Formatting is idempotent
+	reformatted, errs := FormatSpecification(formatted)
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	if string(reformatted) != string(formatted) {
+		t.Errorf("Expected no changes, got:\n%s", reformatted)
+	}
+}
+
+// TestFormatSpecificationSyntaxErrors tests that specifications with syntax errors are not formatted
+func TestFormatSpecificationSyntaxErrors(t *testing.T) {
+	cmsWithParserErrors, err := os.ReadFile("./test_specs/with_parser_errors.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	formatted, errs := FormatSpecification(cmsWithParserErrors)
+	if len(errs) == 0 {
+		t.Errorf("Expecting errors, no errors where returned instead")
+	}
+	if formatted != nil {
+		t.Errorf("Expected no formatted specification, got:\n%s", formatted)
+	}
+}
@@ -0,0 +1,66 @@
+package spec
+
+import (
+	"github.com/ConfigMate/configmate/parsers"
+	"github.com/ConfigMate/configmate/parsers/gen/parser_cmsl"
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// LocateInCheck returns the location in a specification of the characters
+// of a check from offset start to offset end. The text of a check is the
+// text of its tokens, without the whitespace and comments between them,
+// so the offsets are mapped to the tokens of the check in the source of
+// the specification. The location of the whole check is returned if the
+// offsets are not in the check.
+func LocateInCheck(source []byte, checkLocation parsers.TokenLocation, start int, end int) parsers.TokenLocation {
+	// Create lexer
+	input := antlr.NewInputStream(string(source))
+	lexer := parser_cmsl.NewCMSLLexer(input)
+	lexer.RemoveErrorListeners()
+
+	// Find the characters of the check in its tokens
+	offset := 0
+	var startLocation, lastLocation *parsers.CharLocation
+	for _, token := range lexer.GetAllTokens() {
+		tokenStart := parsers.CharLocation{Line: token.GetLine() - 1, Column: token.GetColumn()}
+		if token.GetChannel() != antlr.TokenDefaultChannel || charBefore(tokenStart, checkLocation.Start) {
+			continue
+		}
+		if !charBefore(tokenStart, checkLocation.End) {
+			break
+		}
+
+		// Tokens of strings can span several lines
+		location := tokenStart
+		for _, c := range token.GetText() {
+			if offset == start {
+				startLocation = &parsers.CharLocation{Line: location.Line, Column: location.Column}
+			}
+			if offset == end-1 {
+				lastLocation = &parsers.CharLocation{Line: location.Line, Column: location.Column}
+			}
+			offset++
+
+			if c == '\n' {
+				location.Line++
+				location.Column = 0
+			} else {
+				location.Column++
+			}
+		}
+	}
+
+	if startLocation == nil || lastLocation == nil {
+		return checkLocation
+	}
+
+	return parsers.TokenLocation{
+		Start: *startLocation,
+		End:   parsers.CharLocation{Line: lastLocation.Line, Column: lastLocation.Column + 1},
+	}
+}
+
+// charBefore returns whether the character at a is before the one at b.
+func charBefore(a parsers.CharLocation, b parsers.CharLocation) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
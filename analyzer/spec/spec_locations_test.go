@@ -0,0 +1,68 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestLocateInCheck tests that offsets in the text of a check are located
+// in the source of the specification, skipping the whitespace and comments
+// between the tokens of the check.
+func TestLocateInCheck(t *testing.T) {
+	source := []byte(`config: "./config.json"
+
+spec {
+    port <int> (
+        range(1,
+            // upper bound
+            65535);
+    )
+}
+`)
+	// Location of the check range(1,65535)
+	checkLocation := parsers.TokenLocation{
+		Start: parsers.CharLocation{Line: 4, Column: 8},
+		End:   parsers.CharLocation{Line: 6, Column: 18},
+	}
+
+	tests := []struct {
+		start    int
+		end      int
+		expected parsers.TokenLocation
+	}{
+		{
+			start: 0,
+			end:   5,
+			expected: parsers.TokenLocation{
+				Start: parsers.CharLocation{Line: 4, Column: 8},
+				End:   parsers.CharLocation{Line: 4, Column: 13},
+			},
+		},
+		{
+			start: 8,
+			end:   13,
+			expected: parsers.TokenLocation{
+				Start: parsers.CharLocation{Line: 6, Column: 12},
+				End:   parsers.CharLocation{Line: 6, Column: 17},
+			},
+		},
+		{
+			start:    0,
+			end:      14,
+			expected: checkLocation,
+		},
+		{
+			start:    10,
+			end:      20,
+			expected: checkLocation,
+		},
+	}
+
+	for _, test := range tests {
+		result := LocateInCheck(source, checkLocation, test.start, test.end)
+		if result != test.expected {
+			t.Errorf("LocateInCheck(%d, %d) = %v, want %v", test.start, test.end, result, test.expected)
+		}
+	}
+}
@@ -120,6 +120,13 @@ func (p *specParserImpl) EnterConfigDeclaration(ctx *parser_cmsl.ConfigDeclarati
 		},
 	}
 
+	// Format is optional, it will be detected from the file if missing
+	if ctx.IDENTIFIER() == nil {
+		p.spec.FileFormat = ""
+		p.spec.FileFormatLocation = p.spec.FileLocation
+		return
+	}
+
 	// Set values of fileFormat and fileFormatLocation in spec
 	p.spec.FileFormat = ctx.IDENTIFIER().GetText()
 	p.spec.FileFormatLocation = parsers.TokenLocation{
@@ -134,6 +141,42 @@ func (p *specParserImpl) EnterConfigDeclaration(ctx *parser_cmsl.ConfigDeclarati
 	}
 }
 
+// EnterExtendsStatement is called when production extendsStatement is entered.
+func (p *specParserImpl) EnterExtendsStatement(ctx *parser_cmsl.ExtendsStatementContext) {
+	// Set values of extends and extendsLocation in spec
+	p.spec.Extends = removeStrQuotesAndCleanSpaces(ctx.SHORT_STRING().GetText())
+	p.spec.ExtendsLocation = parsers.TokenLocation{
+		Start: parsers.CharLocation{
+			Line:   ctx.SHORT_STRING().GetSymbol().GetLine() - 1,
+			Column: ctx.SHORT_STRING().GetSymbol().GetColumn(),
+		},
+		End: parsers.CharLocation{
+			Line:   ctx.SHORT_STRING().GetSymbol().GetLine() - 1,
+			Column: ctx.SHORT_STRING().GetSymbol().GetColumn() + len(ctx.SHORT_STRING().GetText()),
+		},
+	}
+}
+
+// EnterSpecificationBody is called when production specificationBody is entered.
+func (p *specParserImpl) EnterSpecificationBody(ctx *parser_cmsl.SpecificationBodyContext) {
+	// Strict keyword is optional
+	if ctx.STRICT_METAD_KW() == nil {
+		return
+	}
+
+	p.spec.Strict = true
+	p.spec.StrictLocation = parsers.TokenLocation{
+		Start: parsers.CharLocation{
+			Line:   ctx.STRICT_METAD_KW().GetSymbol().GetLine() - 1,
+			Column: ctx.STRICT_METAD_KW().GetSymbol().GetColumn(),
+		},
+		End: parsers.CharLocation{
+			Line:   ctx.STRICT_METAD_KW().GetSymbol().GetLine() - 1,
+			Column: ctx.STRICT_METAD_KW().GetSymbol().GetColumn() + len(ctx.STRICT_METAD_KW().GetText()),
+		},
+	}
+}
+
 // EnterImportStatement is called when production importStatement is entered.
 func (p *specParserImpl) EnterImportItem(ctx *parser_cmsl.ImportItemContext) {
 	// Add import to spec
@@ -190,10 +233,35 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 		Checks: make([]CheckWithLocation, 0),
 	}
 
+	// Add metadata and checks to field, fields of specs extending
+	// another one can override inherited fields
+	p.parseMetadataAndChecks(ctx, fieldKey.String(), &fieldSpecification, p.spec.Extends != "")
+
+	p.spec.Fields = append(p.spec.Fields, fieldSpecification)
+}
+
+// metadataContext is a production with metadata and checks, like
+// specification items and object property definitions.
+type metadataContext interface {
+	antlr.ParserRuleContext
+	ShortMetadataExpression() parser_cmsl.IShortMetadataExpressionContext
+	LongMetadataExpression() parser_cmsl.ILongMetadataExpressionContext
+	AllNamedCheck() []parser_cmsl.INamedCheckContext
+}
+
+// parseMetadataAndChecks adds the metadata and checks of ctx to the field
+// specification. Name is the name of the field used in error messages. If
+// overriding, the field can override an inherited field, so the type can be
+// omitted and inherited checks can be removed.
+func (p *specParserImpl) parseMetadataAndChecks(ctx metadataContext, name string, fieldSpecification *FieldSpec, overriding bool) {
 	foundType := false
 	foundDefault := false
 	foundOptional := false
 	foundNotes := false
+	foundStrict := false
+	foundNullable := false
+	foundSeverity := false
+	foundRemove := false
 
 	if ctx.ShortMetadataExpression() != nil {
 		foundType = true
@@ -233,7 +301,7 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 				// Check if type has already been found
 				if foundType {
 					p.errs = append(p.errs, SpecParserError{
-						ErrorMessage: fmt.Sprintf("duplicate type metadata for field %s", fieldKey.String()),
+						ErrorMessage: fmt.Sprintf("duplicate type metadata for field %s", name),
 						Location: parsers.TokenLocation{
 							Start: parsers.CharLocation{
 								Line:   item.GetStart().GetLine() - 1,
@@ -265,7 +333,7 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 				// Check if optional has already been found
 				if foundOptional {
 					p.errs = append(p.errs, SpecParserError{
-						ErrorMessage: fmt.Sprintf("duplicate optional metadata for field %s", fieldKey.String()),
+						ErrorMessage: fmt.Sprintf("duplicate optional metadata for field %s", name),
 						Location: parsers.TokenLocation{
 							Start: parsers.CharLocation{
 								Line:   item.GetStart().GetLine() - 1,
@@ -302,7 +370,7 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 				// Check if default has already been found
 				if foundDefault {
 					p.errs = append(p.errs, SpecParserError{
-						ErrorMessage: fmt.Sprintf("duplicate default metadata for field %s", fieldKey.String()),
+						ErrorMessage: fmt.Sprintf("duplicate default metadata for field %s", name),
 						Location: parsers.TokenLocation{
 							Start: parsers.CharLocation{
 								Line:   item.GetStart().GetLine() - 1,
@@ -320,6 +388,7 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 
 				// Add default to field
 				fieldSpecification.Default = removeStrQuotesAndCleanSpaces(item.Primitive().GetText())
+				fieldSpecification.DefaultValue = parsePrimitiveValue(item.Primitive())
 				fieldSpecification.DefaultLocation = parsers.TokenLocation{
 					Start: parsers.CharLocation{
 						Line:   item.Primitive().GetStart().GetLine() - 1,
@@ -334,7 +403,7 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 				// Check if notes has already been found
 				if foundNotes {
 					p.errs = append(p.errs, SpecParserError{
-						ErrorMessage: fmt.Sprintf("duplicate notes metadata for field %s", fieldKey.String()),
+						ErrorMessage: fmt.Sprintf("duplicate notes metadata for field %s", name),
 						Location: parsers.TokenLocation{
 							Start: parsers.CharLocation{
 								Line:   item.GetStart().GetLine() - 1,
@@ -362,6 +431,148 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 						Column: item.StringExpr().GetStop().GetColumn() + len(item.StringExpr().GetStop().GetText()),
 					},
 				}
+			case *parser_cmsl.StrictMetadataContext:
+				// Check if strict has already been found
+				if foundStrict {
+					p.errs = append(p.errs, SpecParserError{
+						ErrorMessage: fmt.Sprintf("duplicate strict metadata for field %s", name),
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{
+								Line:   item.GetStart().GetLine() - 1,
+								Column: item.GetStart().GetColumn(),
+							},
+							End: parsers.CharLocation{
+								Line:   item.GetStop().GetLine() - 1,
+								Column: item.GetStop().GetColumn() + len(item.GetStop().GetText()),
+							},
+						},
+					})
+					continue
+				}
+				foundStrict = true
+
+				// Add strict to field
+				strict, err := strconv.ParseBool(item.BOOL().GetText())
+				if err != nil {
+					panic(fmt.Sprintf("strict must be a bool, found: %s; this error should have been cought in a previous stage", item.BOOL().GetText()))
+				}
+
+				fieldSpecification.Strict = strict
+				fieldSpecification.StrictLocation = parsers.TokenLocation{
+					Start: parsers.CharLocation{
+						Line:   item.BOOL().GetSymbol().GetLine() - 1,
+						Column: item.BOOL().GetSymbol().GetColumn(),
+					},
+					End: parsers.CharLocation{
+						Line:   item.BOOL().GetSymbol().GetLine() - 1,
+						Column: item.BOOL().GetSymbol().GetColumn() + len(item.BOOL().GetSymbol().GetText()),
+					},
+				}
+
+			case *parser_cmsl.NullableMetadataContext:
+				// Check if nullable has already been found
+				if foundNullable {
+					p.errs = append(p.errs, SpecParserError{
+						ErrorMessage: fmt.Sprintf("duplicate nullable metadata for field %s", name),
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{
+								Line:   item.GetStart().GetLine() - 1,
+								Column: item.GetStart().GetColumn(),
+							},
+							End: parsers.CharLocation{
+								Line:   item.GetStop().GetLine() - 1,
+								Column: item.GetStop().GetColumn() + len(item.GetStop().GetText()),
+							},
+						},
+					})
+					continue
+				}
+				foundNullable = true
+
+				// Add nullable to field
+				nullable, err := strconv.ParseBool(item.BOOL().GetText())
+				if err != nil {
+					panic(fmt.Sprintf("nullable must be a bool, found: %s; this error should have been cought in a previous stage", item.BOOL().GetText()))
+				}
+
+				fieldSpecification.Nullable = nullable
+				fieldSpecification.NullableLocation = parsers.TokenLocation{
+					Start: parsers.CharLocation{
+						Line:   item.BOOL().GetSymbol().GetLine() - 1,
+						Column: item.BOOL().GetSymbol().GetColumn(),
+					},
+					End: parsers.CharLocation{
+						Line:   item.BOOL().GetSymbol().GetLine() - 1,
+						Column: item.BOOL().GetSymbol().GetColumn() + len(item.BOOL().GetSymbol().GetText()),
+					},
+				}
+
+			case *parser_cmsl.SeverityMetadataContext:
+				// Check if severity has already been found
+				if foundSeverity {
+					p.errs = append(p.errs, SpecParserError{
+						ErrorMessage: fmt.Sprintf("duplicate severity metadata for field %s", name),
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{
+								Line:   item.GetStart().GetLine() - 1,
+								Column: item.GetStart().GetColumn(),
+							},
+							End: parsers.CharLocation{
+								Line:   item.GetStop().GetLine() - 1,
+								Column: item.GetStop().GetColumn() + len(item.GetStop().GetText()),
+							},
+						},
+					})
+					continue
+				}
+				foundSeverity = true
+
+				// Add severity to field
+				severity, location, ok := p.parseSeverity(item.IDENTIFIER(), name)
+				if !ok {
+					continue
+				}
+
+				fieldSpecification.Severity = severity
+				fieldSpecification.SeverityLocation = location
+
+			case *parser_cmsl.RemoveMetadataContext:
+				location := parsers.TokenLocation{
+					Start: parsers.CharLocation{
+						Line:   item.GetStart().GetLine() - 1,
+						Column: item.GetStart().GetColumn(),
+					},
+					End: parsers.CharLocation{
+						Line:   item.GetStop().GetLine() - 1,
+						Column: item.GetStop().GetColumn() + len(item.GetStop().GetText()),
+					},
+				}
+
+				// Only inherited checks can be removed
+				if !overriding {
+					p.errs = append(p.errs, SpecParserError{
+						ErrorMessage: fmt.Sprintf("remove metadata for field %s is only allowed in fields of specifications that extend another", name),
+						Location:     location,
+					})
+					continue
+				}
+
+				// Check if remove has already been found
+				if foundRemove {
+					p.errs = append(p.errs, SpecParserError{
+						ErrorMessage: fmt.Sprintf("duplicate remove metadata for field %s", name),
+						Location:     location,
+					})
+					continue
+				}
+				foundRemove = true
+
+				// Add names of the checks to remove to field
+				fieldSpecification.RemoveChecks = make([]string, 0, len(item.AllIDENTIFIER()))
+				for _, checkName := range item.AllIDENTIFIER() {
+					fieldSpecification.RemoveChecks = append(fieldSpecification.RemoveChecks, checkName.GetText())
+				}
+				fieldSpecification.RemoveLocation = location
 
 			default:
 				panic(fmt.Sprintf("unknown metadata item: %s; this error should have been cought in a previous stage", item.GetText()))
@@ -371,9 +582,10 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 		panic(fmt.Sprintf("unknown metadata expression: %s; this error should have been cought in a previous stage", ctx.GetText()))
 	}
 
-	if !foundType {
+	// Overriding fields inherit the type if it's omitted
+	if !foundType && !overriding {
 		p.errs = append(p.errs, SpecParserError{
-			ErrorMessage: fmt.Sprintf("missing type metadata for field %s", fieldKey.String()),
+			ErrorMessage: fmt.Sprintf("missing type metadata for field %s", name),
 			Location: parsers.TokenLocation{
 				Start: parsers.CharLocation{
 					Line:   ctx.GetStart().GetLine() - 1,
@@ -388,10 +600,44 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 	}
 
 	// For each check statement
-	for _, check := range ctx.AllCheck() {
+	checkNames := make(map[string]bool)
+	for _, namedCheck := range ctx.AllNamedCheck() {
+		check := namedCheck.Check()
+
+		// Names are optional, but must be unique in the field
+		checkName := ""
+		if namedCheck.IDENTIFIER() != nil {
+			checkName = namedCheck.IDENTIFIER().GetText()
+			if checkNames[checkName] {
+				p.errs = append(p.errs, SpecParserError{
+					ErrorMessage: fmt.Sprintf("duplicate check name %s for field %s", checkName, name),
+					Location: parsers.TokenLocation{
+						Start: parsers.CharLocation{
+							Line:   namedCheck.IDENTIFIER().GetSymbol().GetLine() - 1,
+							Column: namedCheck.IDENTIFIER().GetSymbol().GetColumn(),
+						},
+						End: parsers.CharLocation{
+							Line:   namedCheck.IDENTIFIER().GetSymbol().GetLine() - 1,
+							Column: namedCheck.IDENTIFIER().GetSymbol().GetColumn() + len(checkName),
+						},
+					},
+				})
+				continue
+			}
+			checkNames[checkName] = true
+		}
+
+		// Checks without a severity have the severity of the field
+		checkSeverity := ""
+		if namedCheck.CheckSeverity() != nil {
+			checkSeverity, _, _ = p.parseSeverity(namedCheck.CheckSeverity().IDENTIFIER(), name)
+		}
+
 		// Add check to field
 		fieldSpecification.Checks = append(fieldSpecification.Checks, CheckWithLocation{
-			Check: check.GetText(),
+			Check:    check.GetText(),
+			Name:     checkName,
+			Severity: checkSeverity,
 			Location: parsers.TokenLocation{
 				Start: parsers.CharLocation{
 					Line:   check.GetStart().GetLine() - 1,
@@ -404,8 +650,33 @@ func (p *specParserImpl) EnterSpecificationItem(ctx *parser_cmsl.SpecificationIt
 			},
 		})
 	}
+}
 
-	p.spec.Fields = append(p.spec.Fields, fieldSpecification)
+// parseSeverity returns the severity named by identifier and its location. If
+// it isn't a severity, an error is reported and ok is false. Name is the name
+// of the field used in error messages.
+func (p *specParserImpl) parseSeverity(identifier antlr.TerminalNode, name string) (severity string, location parsers.TokenLocation, ok bool) {
+	location = parsers.TokenLocation{
+		Start: parsers.CharLocation{
+			Line:   identifier.GetSymbol().GetLine() - 1,
+			Column: identifier.GetSymbol().GetColumn(),
+		},
+		End: parsers.CharLocation{
+			Line:   identifier.GetSymbol().GetLine() - 1,
+			Column: identifier.GetSymbol().GetColumn() + len(identifier.GetText()),
+		},
+	}
+
+	severity = identifier.GetText()
+	if SeverityLevel(severity) < 0 {
+		p.errs = append(p.errs, SpecParserError{
+			ErrorMessage: fmt.Sprintf("invalid severity %s for field %s, expected one of: %s", severity, name, strings.Join(Severities, ", ")),
+			Location:     location,
+		})
+		return "", location, false
+	}
+
+	return severity, location, true
 }
 
 // ExitObjectField is called when production objectField is exited.
@@ -450,45 +721,105 @@ func (p *specParserImpl) EnterObjectDefinition(ctx *parser_cmsl.ObjectDefinition
 			},
 		}
 
-		// Get property type
-		objectPropertyDefinition.Type = propertyDef.ShortMetadataExpression().TypeExpr().GetText()
-		objectPropertyDefinition.TypeLocation = parsers.TokenLocation{
+		// Get property metadata and checks, which are the same as for fields
+		propertySpecification := FieldSpec{Checks: make([]CheckWithLocation, 0)}
+		p.parseMetadataAndChecks(propertyDef, objectDefinition.Name+"."+objectPropertyDefinition.Name, &propertySpecification, false)
+
+		objectPropertyDefinition.Type = propertySpecification.Type
+		objectPropertyDefinition.Optional = propertySpecification.Optional
+		objectPropertyDefinition.Default = propertySpecification.Default
+		objectPropertyDefinition.DefaultValue = propertySpecification.DefaultValue
+		objectPropertyDefinition.Notes = propertySpecification.Notes
+		objectPropertyDefinition.Strict = propertySpecification.Strict
+		objectPropertyDefinition.Nullable = propertySpecification.Nullable
+		objectPropertyDefinition.Severity = propertySpecification.Severity
+		objectPropertyDefinition.Checks = propertySpecification.Checks
+		objectPropertyDefinition.TypeLocation = propertySpecification.TypeLocation
+		objectPropertyDefinition.OptionalLocation = propertySpecification.OptionalLocation
+		objectPropertyDefinition.DefaultLocation = propertySpecification.DefaultLocation
+		objectPropertyDefinition.NotesLocation = propertySpecification.NotesLocation
+		objectPropertyDefinition.StrictLocation = propertySpecification.StrictLocation
+		objectPropertyDefinition.NullableLocation = propertySpecification.NullableLocation
+		objectPropertyDefinition.SeverityLocation = propertySpecification.SeverityLocation
+
+		// Add property to object definition
+		objectDefinition.Properties = append(objectDefinition.Properties, objectPropertyDefinition)
+	}
+
+	// Add object definition to spec
+	p.spec.Objects = append(p.spec.Objects, objectDefinition)
+}
+
+// EnterPredicateDefinition is called when production predicateDefinition is entered.
+func (p *specParserImpl) EnterPredicateDefinition(ctx *parser_cmsl.PredicateDefinitionContext) {
+	// The first identifier is the name, the rest are the parameters
+	identifiers := ctx.AllIDENTIFIER()
+	predicateDefinition := PredicateDef{
+		Name:   identifiers[0].GetText(),
+		Params: make([]string, 0, len(identifiers)-1),
+		Check:  ctx.Check().GetText(),
+		NameLocation: parsers.TokenLocation{
 			Start: parsers.CharLocation{
-				Line:   propertyDef.ShortMetadataExpression().TypeExpr().GetStart().GetLine() - 1,
-				Column: propertyDef.ShortMetadataExpression().TypeExpr().GetStart().GetColumn(),
+				Line:   identifiers[0].GetSymbol().GetLine() - 1,
+				Column: identifiers[0].GetSymbol().GetColumn(),
 			},
 			End: parsers.CharLocation{
-				Line:   propertyDef.ShortMetadataExpression().TypeExpr().GetStop().GetLine() - 1,
-				Column: propertyDef.ShortMetadataExpression().TypeExpr().GetStop().GetColumn() + len(propertyDef.ShortMetadataExpression().TypeExpr().GetStop().GetText()),
+				Line:   identifiers[0].GetSymbol().GetLine() - 1,
+				Column: identifiers[0].GetSymbol().GetColumn() + len(identifiers[0].GetText()),
 			},
+		},
+		CheckLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{
+				Line:   ctx.Check().GetStart().GetLine() - 1,
+				Column: ctx.Check().GetStart().GetColumn(),
+			},
+			End: parsers.CharLocation{
+				Line:   ctx.Check().GetStop().GetLine() - 1,
+				Column: ctx.Check().GetStop().GetColumn() + len(ctx.Check().GetStop().GetText()),
+			},
+		},
+	}
+
+	// Check that the predicate hasn't already been defined
+	for _, defined := range p.spec.Predicates {
+		if defined.Name == predicateDefinition.Name {
+			p.errs = append(p.errs, SpecParserError{
+				ErrorMessage: fmt.Sprintf("duplicate definition of predicate %s", predicateDefinition.Name),
+				Location:     predicateDefinition.NameLocation,
+			})
+			return
 		}
+	}
 
-		// Get property optional
-		if propertyDef.ShortMetadataExpression().OPTIONAL_METAD_KW() != nil {
-			objectPropertyDefinition.Optional = true
-			objectPropertyDefinition.OptionalLocation = parsers.TokenLocation{
-				Start: parsers.CharLocation{
-					Line:   propertyDef.ShortMetadataExpression().OPTIONAL_METAD_KW().GetSymbol().GetLine() - 1,
-					Column: propertyDef.ShortMetadataExpression().OPTIONAL_METAD_KW().GetSymbol().GetColumn(),
-				},
-				End: parsers.CharLocation{
-					Line:   propertyDef.ShortMetadataExpression().OPTIONAL_METAD_KW().GetSymbol().GetLine() - 1,
-					Column: propertyDef.ShortMetadataExpression().OPTIONAL_METAD_KW().GetSymbol().GetColumn() + len(propertyDef.ShortMetadataExpression().OPTIONAL_METAD_KW().GetSymbol().GetText()),
+	// Parameters must be unique, and 'this' is the value the predicate is applied to
+	for _, param := range identifiers[1:] {
+		paramName := param.GetText()
+		if paramName == "this" || containsString(predicateDefinition.Params, paramName) {
+			p.errs = append(p.errs, SpecParserError{
+				ErrorMessage: fmt.Sprintf("invalid parameter %s of predicate %s, parameters must be unique and can't be 'this'", paramName, predicateDefinition.Name),
+				Location: parsers.TokenLocation{
+					Start: parsers.CharLocation{
+						Line:   param.GetSymbol().GetLine() - 1,
+						Column: param.GetSymbol().GetColumn(),
+					},
+					End: parsers.CharLocation{
+						Line:   param.GetSymbol().GetLine() - 1,
+						Column: param.GetSymbol().GetColumn() + len(paramName),
+					},
 				},
-			}
+			})
+			return
 		}
-
-		// Add property to object definition
-		objectDefinition.Properties = append(objectDefinition.Properties, objectPropertyDefinition)
+		predicateDefinition.Params = append(predicateDefinition.Params, paramName)
 	}
 
-	// Add object definition to spec
-	p.spec.Objects = append(p.spec.Objects, objectDefinition)
+	// Add predicate definition to spec
+	p.spec.Predicates = append(p.spec.Predicates, predicateDefinition)
 }
 
 func parseFieldName(ctx parser_cmsl.IFieldNameContext) *parsers.NodeKey {
 	if ctx.SimpleName() != nil {
-		return &parsers.NodeKey{Segments: []string{removeSingleQuotesInKeys(ctx.SimpleName().GetText())}}
+		return &parsers.NodeKey{Segments: parseNameSegments(ctx.GetChildren())}
 	} else if ctx.DottedName() != nil {
 		return parseDottedName(ctx.DottedName())
 	}
@@ -497,14 +828,49 @@ func parseFieldName(ctx parser_cmsl.IFieldNameContext) *parsers.NodeKey {
 }
 
 func parseDottedName(ctx parser_cmsl.IDottedNameContext) *parsers.NodeKey {
+	return &parsers.NodeKey{Segments: parseNameSegments(ctx.GetChildren())}
+}
+
+// parseNameSegments returns the segments of a field name in order,
+// names without quotes and indexes as '[n]' or '[*]'.
+func parseNameSegments(children []antlr.Tree) []string {
 	segments := make([]string, 0)
 
 	// For each segment
-	for _, segment := range ctx.AllSimpleName() {
-		segments = append(segments, removeSingleQuotesInKeys(segment.GetText()))
+	for _, child := range children {
+		switch segment := child.(type) {
+		case parser_cmsl.ISimpleNameContext:
+			segments = append(segments, removeSingleQuotesInKeys(segment.GetText()))
+		case parser_cmsl.IFieldIndexContext:
+			if segment.STAR() != nil {
+				segments = append(segments, parsers.WildcardSegment)
+			} else {
+				index, _ := strconv.Atoi(segment.INT().GetText())
+				segments = append(segments, parsers.IndexSegment(index))
+			}
+		}
 	}
 
-	return &parsers.NodeKey{Segments: segments}
+	return segments
+}
+
+// parsePrimitiveValue returns the value of a primitive with the
+// type it was written as: string, int, float64 or bool.
+func parsePrimitiveValue(ctx parser_cmsl.IPrimitiveContext) interface{} {
+	switch primitive := ctx.(type) {
+	case *parser_cmsl.StringContext:
+		return removeStrQuotesAndCleanSpaces(primitive.GetText())
+	case *parser_cmsl.IntContext:
+		value, _ := strconv.Atoi(primitive.GetText())
+		return value
+	case *parser_cmsl.FloatContext:
+		value, _ := strconv.ParseFloat(primitive.GetText(), 64)
+		return value
+	case *parser_cmsl.BooleanContext:
+		return primitive.GetText() == "true"
+	}
+
+	panic(fmt.Sprintf("unknown primitive: %s; this error should have been cought in a previous stage", ctx.GetText()))
 }
 
 func removeSingleQuotesInKeys(str string) string {
@@ -533,3 +899,12 @@ func removeStrQuotesAndCleanSpaces(str string) string {
 
 	return str
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -59,7 +59,8 @@ func TestParseSimple(t *testing.T) {
 					Start: parsers.CharLocation{Line: 5, Column: 18},
 					End:   parsers.CharLocation{Line: 5, Column: 24},
 				},
-				Default: "localhost",
+				Default:      "localhost",
+				DefaultValue: "localhost",
 				DefaultLocation: parsers.TokenLocation{
 					Start: parsers.CharLocation{Line: 6, Column: 21},
 					End:   parsers.CharLocation{Line: 6, Column: 32},
@@ -90,7 +91,8 @@ func TestParseSimple(t *testing.T) {
 					Start: parsers.CharLocation{Line: 11, Column: 18},
 					End:   parsers.CharLocation{Line: 11, Column: 21},
 				},
-				Default: "80",
+				Default:      "80",
+				DefaultValue: 80,
 				DefaultLocation: parsers.TokenLocation{
 					Start: parsers.CharLocation{Line: 12, Column: 21},
 					End:   parsers.CharLocation{Line: 12, Column: 23},
@@ -121,7 +123,8 @@ func TestParseSimple(t *testing.T) {
 					Start: parsers.CharLocation{Line: 20, Column: 18},
 					End:   parsers.CharLocation{Line: 20, Column: 22},
 				},
-				Default: "false",
+				Default:      "false",
+				DefaultValue: false,
 				DefaultLocation: parsers.TokenLocation{
 					Start: parsers.CharLocation{Line: 21, Column: 21},
 					End:   parsers.CharLocation{Line: 21, Column: 26},
@@ -205,6 +208,7 @@ func TestParseSimple(t *testing.T) {
 							Start: parsers.CharLocation{Line: 37, Column: 17},
 							End:   parsers.CharLocation{Line: 37, Column: 23},
 						},
+						Checks: []CheckWithLocation{},
 					},
 					{
 						Name: "timeout",
@@ -222,6 +226,7 @@ func TestParseSimple(t *testing.T) {
 							Start: parsers.CharLocation{Line: 38, Column: 21},
 							End:   parsers.CharLocation{Line: 38, Column: 29},
 						},
+						Checks: []CheckWithLocation{},
 					},
 					{
 						Name: "method",
@@ -234,6 +239,7 @@ func TestParseSimple(t *testing.T) {
 							Start: parsers.CharLocation{Line: 39, Column: 15},
 							End:   parsers.CharLocation{Line: 39, Column: 21},
 						},
+						Checks: []CheckWithLocation{},
 					},
 				},
 			},
@@ -305,6 +311,879 @@ func TestParseWithImports(t *testing.T) {
 	}
 }
 
+// TestParseWithoutFormat tests the parser's ability to parse a config declaration without format
+func TestParseWithoutFormat(t *testing.T) {
+	withoutFormatCMS, err := os.ReadFile("./test_specs/without_format.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.yaml",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields:               []FieldSpec{},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withoutFormatCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseStrict tests the parser's ability to parse strict specifications and fields
+func TestParseStrict(t *testing.T) {
+	strictCMS, err := os.ReadFile("./test_specs/strict.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.json",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "json",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Strict: true,
+		StrictLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 2, Column: 5},
+			End:   parsers.CharLocation{Line: 2, Column: 11},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"server"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 10},
+				},
+				Type: "object",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 14},
+					End:   parsers.CharLocation{Line: 4, Column: 20},
+				},
+				Strict: false,
+				StrictLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 5, Column: 16},
+					End:   parsers.CharLocation{Line: 5, Column: 21},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"server", "port"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 8},
+					End:   parsers.CharLocation{Line: 7, Column: 12},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 14},
+					End:   parsers.CharLocation{Line: 7, Column: 17},
+				},
+				Checks: []CheckWithLocation{},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(strictCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithIndexes tests the parser's ability to parse field names with indexes and wildcards
+func TestParseWithIndexes(t *testing.T) {
+	withIndexesCMS, err := os.ReadFile("./test_specs/with_indexes.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.yaml",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "yaml",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"servers", "[*]"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 14},
+				},
+				Type: "object",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 16},
+					End:   parsers.CharLocation{Line: 3, Column: 22},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"servers", "[*]", "port"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 8},
+					End:   parsers.CharLocation{Line: 4, Column: 12},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 14},
+					End:   parsers.CharLocation{Line: 4, Column: 17},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"hosts", "[0]"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 6, Column: 4},
+					End:   parsers.CharLocation{Line: 6, Column: 12},
+				},
+				Type: "string",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 6, Column: 14},
+					End:   parsers.CharLocation{Line: 6, Column: 20},
+				},
+				Checks: []CheckWithLocation{},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withIndexesCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithUnions tests the parser's ability to parse enum, union and tagged union types
+func TestParseWithUnions(t *testing.T) {
+	withUnionsCMS, err := os.ReadFile("./test_specs/with_unions.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.yaml",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "yaml",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"level"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 9},
+				},
+				Type: "enum(\"debug\",\"info\",\"warn\")",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 11},
+					End:   parsers.CharLocation{Line: 3, Column: 40},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"timeout"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 4},
+					End:   parsers.CharLocation{Line: 4, Column: 11},
+				},
+				Type: "int|string",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 13},
+					End:   parsers.CharLocation{Line: 4, Column: 25},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"api"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 5, Column: 4},
+					End:   parsers.CharLocation{Line: 5, Column: 7},
+				},
+				Type: "tagged(kind,\"http\":http_api,\"grpc\":grpc_api)",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 5, Column: 9},
+					End:   parsers.CharLocation{Line: 5, Column: 57},
+				},
+				Checks: []CheckWithLocation{},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withUnionsCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithMaps tests the parser's ability to parse map types
+func TestParseWithMaps(t *testing.T) {
+	withMapsCMS, err := os.ReadFile("./test_specs/with_maps.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.toml",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "toml",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"upstreams"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 13},
+				},
+				Type: "map<string,host_port>",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 15},
+					End:   parsers.CharLocation{Line: 3, Column: 37},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "len().gte(1)",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 3, Column: 41},
+							End:   parsers.CharLocation{Line: 3, Column: 53},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withMapsCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithObjectChecks tests the parser's ability to parse metadata and checks of object properties
+func TestParseWithObjectChecks(t *testing.T) {
+	withObjectChecksCMS, err := os.ReadFile("./test_specs/with_object_checks.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.json",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "json",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"apis"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 8},
+				},
+				Type: "list<api_info>",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 10},
+					End:   parsers.CharLocation{Line: 3, Column: 24},
+				},
+				Checks: []CheckWithLocation{},
+			},
+		},
+		Objects: []ObjectDef{
+			{
+				Name: "api_info",
+				NameLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 4},
+					End:   parsers.CharLocation{Line: 7, Column: 12},
+				},
+				Properties: []ObjectPropertyDef{
+					{
+						Name: "endpoint",
+						NameLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 8, Column: 8},
+							End:   parsers.CharLocation{Line: 8, Column: 16},
+						},
+						Type: "string",
+						TypeLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 8, Column: 18},
+							End:   parsers.CharLocation{Line: 8, Column: 24},
+						},
+						Checks: []CheckWithLocation{
+							{
+								Check: "len().gt(1)",
+								Location: parsers.TokenLocation{
+									Start: parsers.CharLocation{Line: 8, Column: 28},
+									End:   parsers.CharLocation{Line: 8, Column: 39},
+								},
+							},
+						},
+					},
+					{
+						Name: "timeout",
+						NameLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 9, Column: 8},
+							End:   parsers.CharLocation{Line: 9, Column: 15},
+						},
+						Type: "int",
+						TypeLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 10, Column: 18},
+							End:   parsers.CharLocation{Line: 10, Column: 21},
+						},
+						Default:      "30",
+						DefaultValue: 30,
+						DefaultLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 11, Column: 21},
+							End:   parsers.CharLocation{Line: 11, Column: 23},
+						},
+						Checks: []CheckWithLocation{
+							{
+								Check: "gte(1)",
+								Location: parsers.TokenLocation{
+									Start: parsers.CharLocation{Line: 12, Column: 12},
+									End:   parsers.CharLocation{Line: 12, Column: 18},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withObjectChecksCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithExtends tests the parser's ability to parse specifications
+// that extend another one, with fields overriding inherited fields
+func TestParseWithExtends(t *testing.T) {
+	extendsCMS, err := os.ReadFile("./test_specs/extends.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.json",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "json",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Extends: "./base.cms",
+		ExtendsLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 1, Column: 8},
+			End:   parsers.CharLocation{Line: 1, Column: 20},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"server", "port"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 4},
+					End:   parsers.CharLocation{Line: 4, Column: 15},
+				},
+				Default:      "8080",
+				DefaultValue: 8080,
+				DefaultLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 5, Column: 17},
+					End:   parsers.CharLocation{Line: 5, Column: 21},
+				},
+				RemoveChecks: []string{"privileged"},
+				RemoveLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 6, Column: 8},
+					End:   parsers.CharLocation{Line: 6, Column: 28},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "lt(65536)",
+						Name:  "range",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 7, Column: 15},
+							End:   parsers.CharLocation{Line: 7, Column: 24},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(extendsCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithPredicates tests the parser's ability to parse predicate definitions
+func TestParseWithPredicates(t *testing.T) {
+	withPredicatesCMS, err := os.ReadFile("./test_specs/with_predicates.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.json",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "json",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"server", "port"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 15},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 17},
+					End:   parsers.CharLocation{Line: 3, Column: 20},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "within(1024,65535)",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 3, Column: 24},
+							End:   parsers.CharLocation{Line: 3, Column: 43},
+						},
+					},
+				},
+			},
+		},
+		Predicates: []PredicateDef{
+			{
+				Name:   "within",
+				Params: []string{"min", "max"},
+				Check:  "gte(min)&&lte(max)",
+				NameLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 4},
+					End:   parsers.CharLocation{Line: 7, Column: 10},
+				},
+				CheckLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 23},
+					End:   parsers.CharLocation{Line: 7, Column: 43},
+				},
+			},
+			{
+				Name:   "positive",
+				Params: []string{},
+				Check:  "gt(0)",
+				NameLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 8, Column: 4},
+					End:   parsers.CharLocation{Line: 8, Column: 12},
+				},
+				CheckLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 8, Column: 17},
+					End:   parsers.CharLocation{Line: 8, Column: 22},
+				},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withPredicatesCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+// TestParseWithSeverity tests the parser's ability to parse the severity
+// of fields, object properties and checks
+func TestParseWithSeverity(t *testing.T) {
+	withSeverityCMS, err := os.ReadFile("./test_specs/with_severity.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.json",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "json",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"port"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 8},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 16},
+					End:   parsers.CharLocation{Line: 3, Column: 19},
+				},
+				Severity: "warning",
+				SeverityLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 31},
+					End:   parsers.CharLocation{Line: 3, Column: 38},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "gte(1024)",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 3, Column: 42},
+							End:   parsers.CharLocation{Line: 3, Column: 51},
+						},
+					},
+					{
+						Check:    "range(1,65535)",
+						Severity: "error",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 3, Column: 53},
+							End:   parsers.CharLocation{Line: 3, Column: 68},
+						},
+					},
+				},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"name"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 4},
+					End:   parsers.CharLocation{Line: 4, Column: 8},
+				},
+				Type: "string",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 10},
+					End:   parsers.CharLocation{Line: 4, Column: 16},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check:    "len().gt(0)",
+						Severity: "info",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 4, Column: 20},
+							End:   parsers.CharLocation{Line: 4, Column: 31},
+						},
+					},
+				},
+			},
+		},
+		Objects: []ObjectDef{
+			{
+				Name: "limit",
+				NameLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 8, Column: 4},
+					End:   parsers.CharLocation{Line: 8, Column: 9},
+				},
+				Properties: []ObjectPropertyDef{
+					{
+						Name: "max",
+						NameLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 9, Column: 8},
+							End:   parsers.CharLocation{Line: 9, Column: 11},
+						},
+						Type: "int",
+						TypeLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 9, Column: 19},
+							End:   parsers.CharLocation{Line: 9, Column: 22},
+						},
+						Severity: "info",
+						SeverityLocation: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 9, Column: 34},
+							End:   parsers.CharLocation{Line: 9, Column: 38},
+						},
+						Checks: []CheckWithLocation{
+							{
+								Check: "gt(0)",
+								Location: parsers.TokenLocation{
+									Start: parsers.CharLocation{Line: 9, Column: 42},
+									End:   parsers.CharLocation{Line: 9, Column: 47},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withSeverityCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
+func TestParseWithKeywordNames(t *testing.T) {
+	withKeywordNamesCMS, err := os.ReadFile("./test_specs/with_keyword_names.cms")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	expectedSpec := &Specification{
+		File: "./some/file.json",
+		FileLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 8},
+			End:   parsers.CharLocation{Line: 0, Column: 26},
+		},
+		FileFormat: "json",
+		FileFormatLocation: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 0, Column: 27},
+			End:   parsers.CharLocation{Line: 0, Column: 31},
+		},
+		Imports:              map[string]string{},
+		ImportsAliasLocation: map[string]parsers.TokenLocation{},
+		ImportsLocation:      map[string]parsers.TokenLocation{},
+		Fields: []FieldSpec{
+			{
+				Field: &parsers.NodeKey{Segments: []string{"severity"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 4},
+					End:   parsers.CharLocation{Line: 3, Column: 12},
+				},
+				Type: "string",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 3, Column: 14},
+					End:   parsers.CharLocation{Line: 3, Column: 20},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "len().gt(0)",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 3, Column: 24},
+							End:   parsers.CharLocation{Line: 3, Column: 35},
+						},
+					},
+				},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"remove"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 4},
+					End:   parsers.CharLocation{Line: 4, Column: 10},
+				},
+				Type: "bool",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 4, Column: 12},
+					End:   parsers.CharLocation{Line: 4, Column: 16},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "severity.eq(\"high\")",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 4, Column: 20},
+							End:   parsers.CharLocation{Line: 4, Column: 39},
+						},
+					},
+				},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"enum"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 5, Column: 4},
+					End:   parsers.CharLocation{Line: 5, Column: 8},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 5, Column: 10},
+					End:   parsers.CharLocation{Line: 5, Column: 13},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"tagged"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 6, Column: 4},
+					End:   parsers.CharLocation{Line: 6, Column: 10},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 6, Column: 12},
+					End:   parsers.CharLocation{Line: 6, Column: 15},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"map"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 4},
+					End:   parsers.CharLocation{Line: 7, Column: 7},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 7, Column: 9},
+					End:   parsers.CharLocation{Line: 7, Column: 12},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"nullable"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 8, Column: 4},
+					End:   parsers.CharLocation{Line: 8, Column: 12},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 8, Column: 14},
+					End:   parsers.CharLocation{Line: 8, Column: 17},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"extends"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 9, Column: 4},
+					End:   parsers.CharLocation{Line: 9, Column: 11},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 9, Column: 13},
+					End:   parsers.CharLocation{Line: 9, Column: 16},
+				},
+				Checks: []CheckWithLocation{
+					{
+						Check: "map.gt(0)",
+						Location: parsers.TokenLocation{
+							Start: parsers.CharLocation{Line: 9, Column: 20},
+							End:   parsers.CharLocation{Line: 9, Column: 29},
+						},
+					},
+				},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"checks"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 10, Column: 4},
+					End:   parsers.CharLocation{Line: 10, Column: 10},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 10, Column: 12},
+					End:   parsers.CharLocation{Line: 10, Column: 15},
+				},
+				Checks: []CheckWithLocation{},
+			},
+			{
+				Field: &parsers.NodeKey{Segments: []string{"strict"}},
+				FieldLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 11, Column: 4},
+					End:   parsers.CharLocation{Line: 11, Column: 10},
+				},
+				Type: "int",
+				TypeLocation: parsers.TokenLocation{
+					Start: parsers.CharLocation{Line: 11, Column: 12},
+					End:   parsers.CharLocation{Line: 11, Column: 15},
+				},
+				Checks: []CheckWithLocation{},
+			},
+		},
+	}
+
+	parser := NewSpecParser()
+	result, errs := parser.Parse(withKeywordNamesCMS)
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	}
+	if !reflect.DeepEqual(result, expectedSpec) {
+		t.Errorf("Expected: %#v\nGot: %#v", expectedSpec, result)
+	}
+}
+
 // TestParserHighLevelErrors tests the parser's ability to report high level errors
 func TestParserHighLevelErrors(t *testing.T) {
 	cmsWithHighLevelErrors, err := os.ReadFile("./test_specs/with_highlevel_errors.cms")
@@ -348,6 +1227,20 @@ func TestParserHighLevelErrors(t *testing.T) {
 				End:   parsers.CharLocation{Line: 30, Column: 26},
 			},
 		},
+		{
+			ErrorMessage: "invalid severity fatal for field server.log_level, expected one of: info, warning, error",
+			Location: parsers.TokenLocation{
+				Start: parsers.CharLocation{Line: 36, Column: 22},
+				End:   parsers.CharLocation{Line: 36, Column: 27},
+			},
+		},
+		{
+			ErrorMessage: "invalid severity low for field server.log_level, expected one of: info, warning, error",
+			Location: parsers.TokenLocation{
+				Start: parsers.CharLocation{Line: 37, Column: 35},
+				End:   parsers.CharLocation{Line: 37, Column: 38},
+			},
+		},
 	}
 
 	parser := NewSpecParser()
@@ -0,0 +1,284 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cmslKeywords are the words that can't be written as unquoted field names.
+var cmslKeywords = map[string]bool{
+	"config": true, "import": true, "extends": true, "spec": true, "objects": true, "checks": true,
+	"type": true, "optional": true, "default": true, "notes": true, "strict": true, "nullable": true,
+	"severity": true, "remove": true, "list": true, "map": true, "enum": true, "tagged": true, "true": true, "false": true,
+	"if": true, "elseif": true, "else": true, "foreach": true,
+}
+
+var (
+	identifierRegexp = regexp.MustCompile(`^@?[a-zA-Z0-9_-]+$`)
+	numberRegexp     = regexp.MustCompile(`^[0-9]+$`)
+)
+
+const indentation = "    "
+
+// WriteSpecification returns the specification written in CMSL. Fields are
+// written in order, inside the block of the closest declared field their key
+// continues, or with their full key at the top level otherwise.
+func WriteSpecification(s *Specification) string {
+	var sb strings.Builder
+
+	// Config declaration
+	sb.WriteString(fmt.Sprintf("config: %s", strconv.Quote(s.File)))
+	if s.FileFormat != "" {
+		sb.WriteString(" " + s.FileFormat)
+	}
+	sb.WriteString("\n")
+
+	if s.Extends != "" {
+		sb.WriteString(fmt.Sprintf("extends %s\n", strconv.Quote(s.Extends)))
+	}
+
+	// Imports are sorted by alias
+	if len(s.Imports) > 0 {
+		aliases := make([]string, 0, len(s.Imports))
+		for alias := range s.Imports {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+
+		items := make([]string, 0, len(aliases))
+		for _, alias := range aliases {
+			items = append(items, fmt.Sprintf("%s: %s", alias, strconv.Quote(s.Imports[alias])))
+		}
+		sb.WriteString(fmt.Sprintf("import (%s)\n", strings.Join(items, ", ")))
+	}
+
+	// Specification body
+	sb.WriteString("\nspec ")
+	if s.Strict {
+		sb.WriteString("strict ")
+	}
+	sb.WriteString("{\n")
+	children := fieldChildren(s.Fields)
+	for _, index := range children[-1] {
+		writeField(&sb, s.Fields, children, index, -1, 1)
+	}
+	sb.WriteString("}\n")
+
+	// Custom object types
+	if len(s.Objects) > 0 {
+		sb.WriteString("\nobjects {\n")
+		for _, object := range s.Objects {
+			sb.WriteString(fmt.Sprintf("%s%s {\n", indentation, object.Name))
+			for _, property := range object.Properties {
+				sb.WriteString(strings.Repeat(indentation, 2))
+				sb.WriteString(writeSegment(property.Name))
+				sb.WriteString(writeMetadata(FieldSpec{
+					Type:         property.Type,
+					Optional:     property.Optional,
+					Default:      property.Default,
+					DefaultValue: property.DefaultValue,
+					Notes:        property.Notes,
+					Strict:       property.Strict,
+					Nullable:     property.Nullable,
+					Severity:     property.Severity,
+				}))
+				sb.WriteString(writeChecks(property.Checks))
+				sb.WriteString("\n")
+			}
+			sb.WriteString(indentation + "}\n")
+		}
+		sb.WriteString("}\n")
+	}
+
+	// Named predicates
+	if len(s.Predicates) > 0 {
+		sb.WriteString("\nchecks {\n")
+		for _, predicate := range s.Predicates {
+			sb.WriteString(fmt.Sprintf("%s%s(%s) = %s;\n", indentation, predicate.Name, strings.Join(predicate.Params, ", "), predicate.Check))
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// fieldChildren returns the indexes of the fields written inside the block of
+// each field, with the top level fields at -1. The parent of a field is the
+// field with the longest key its key continues with a name, since names
+// in blocks can't start with an index.
+func fieldChildren(fields []FieldSpec) map[int][]int {
+	children := make(map[int][]int)
+	for i, fspec := range fields {
+		parent := -1
+		for j, candidate := range fields {
+			candidateLen := len(candidate.Field.Segments)
+			if j == i || candidateLen >= len(fspec.Field.Segments) || isIndexSegment(fspec.Field.Segments[candidateLen]) {
+				continue
+			}
+			if parent >= 0 && candidateLen <= len(fields[parent].Field.Segments) {
+				continue
+			}
+			if hasPrefix(fspec.Field.Segments, candidate.Field.Segments) {
+				parent = j
+			}
+		}
+		children[parent] = append(children[parent], i)
+	}
+
+	return children
+}
+
+// writeField writes the field at index and its block, with its key
+// relative to the key of the parent field.
+func writeField(sb *strings.Builder, fields []FieldSpec, children map[int][]int, index int, parent int, depth int) {
+	fspec := fields[index]
+	segments := fspec.Field.Segments
+	if parent >= 0 {
+		segments = segments[len(fields[parent].Field.Segments):]
+	}
+
+	sb.WriteString(strings.Repeat(indentation, depth))
+	sb.WriteString(writeKey(segments))
+	sb.WriteString(writeMetadata(fspec))
+	sb.WriteString(writeChecks(fspec.Checks))
+
+	if len(children[index]) > 0 {
+		sb.WriteString(" {\n")
+		for _, child := range children[index] {
+			writeField(sb, fields, children, child, index, depth+1)
+		}
+		sb.WriteString(strings.Repeat(indentation, depth) + "}")
+	}
+	sb.WriteString("\n")
+}
+
+// writeMetadata returns the metadata of the field, in the short form if
+// it only has a type and whether it is optional.
+func writeMetadata(fspec FieldSpec) string {
+	if fspec.Type != "" && fspec.Default == "" && fspec.DefaultValue == nil && fspec.Notes == "" &&
+		!fspec.Strict && !fspec.Nullable && fspec.Severity == "" && fspec.RemoveChecks == nil {
+		if fspec.Optional {
+			return fmt.Sprintf(" <%s> optional", fspec.Type)
+		}
+		return fmt.Sprintf(" <%s>", fspec.Type)
+	}
+
+	items := []string{}
+	if fspec.Type != "" {
+		items = append(items, "type: "+fspec.Type)
+	}
+	if fspec.Optional {
+		items = append(items, "optional: true")
+	}
+	if fspec.DefaultValue != nil {
+		items = append(items, "default: "+writePrimitive(fspec.DefaultValue))
+	} else if fspec.Default != "" {
+		items = append(items, "default: "+strconv.Quote(fspec.Default))
+	}
+	if fspec.Notes != "" {
+		items = append(items, "notes: "+strconv.Quote(fspec.Notes))
+	}
+	if fspec.Strict {
+		items = append(items, "strict: true")
+	}
+	if fspec.Nullable {
+		items = append(items, "nullable: true")
+	}
+	if fspec.Severity != "" {
+		items = append(items, "severity: "+fspec.Severity)
+	}
+	if fspec.RemoveChecks != nil {
+		items = append(items, fmt.Sprintf("remove: [%s]", strings.Join(fspec.RemoveChecks, ", ")))
+	}
+
+	return fmt.Sprintf(" <%s>", strings.Join(items, ", "))
+}
+
+// writeChecks returns the list of checks, empty if there are none.
+func writeChecks(checks []CheckWithLocation) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(" (")
+	for _, check := range checks {
+		sb.WriteString(" ")
+		if check.Name != "" {
+			sb.WriteString(check.Name + ": ")
+		}
+		sb.WriteString(check.Check)
+		if check.Severity != "" {
+			sb.WriteString(fmt.Sprintf(" <severity: %s>", check.Severity))
+		}
+		sb.WriteString(";")
+	}
+	sb.WriteString(" )")
+
+	return sb.String()
+}
+
+// writePrimitive returns a default value as a CMSL primitive.
+func writePrimitive(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value)
+	case float64:
+		// Floats need a decimal part to be told apart from ints
+		float := strconv.FormatFloat(value, 'f', -1, 64)
+		if !strings.Contains(float, ".") {
+			float += ".0"
+		}
+		return float
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// writeKey returns the key given by the segments as a CMSL field name.
+func writeKey(segments []string) string {
+	result := ""
+	for _, segment := range segments {
+		if isIndexSegment(segment) {
+			result += segment
+			continue
+		}
+		if result != "" {
+			result += "."
+		}
+		result += writeSegment(segment)
+	}
+
+	return result
+}
+
+// writeSegment returns a segment of a key, quoted unless it is an identifier.
+func writeSegment(segment string) string {
+	if identifierRegexp.MatchString(segment) && !cmslKeywords[segment] && !numberRegexp.MatchString(segment) {
+		return segment
+	}
+
+	return fmt.Sprintf("'%s'", segment)
+}
+
+// isIndexSegment checks if a segment is an index or a wildcard segment.
+func isIndexSegment(segment string) bool {
+	return strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]")
+}
+
+// hasPrefix checks if the segments start with the prefix segments.
+func hasPrefix(segments []string, prefix []string) bool {
+	if len(prefix) > len(segments) {
+		return false
+	}
+	for i, segment := range prefix {
+		if segments[i] != segment {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestWriteSpecification tests that fields are written inside the block of
+// their parent field, that list elements and quoted names are written with
+// their full key, and that metadata, checks with their severity, objects and
+// predicates are written.
+func TestWriteSpecification(t *testing.T) {
+	key := func(segments ...string) *parsers.NodeKey {
+		return &parsers.NodeKey{Segments: segments}
+	}
+
+	specification := &Specification{
+		File:       "./config.json",
+		FileFormat: "json",
+		Imports:    map[string]string{"db": "./db.cms", "auth": "./auth.cms"},
+		Strict:     true,
+		Fields: []FieldSpec{
+			{Field: key("server"), Type: "object"},
+			{Field: key("server", "host"), Type: "host", Default: "localhost", DefaultValue: "localhost"},
+			{Field: key("server", "port"), Type: "port", Checks: []CheckWithLocation{{Check: "gte(1024)", Name: "unprivileged"}, {Check: "open()", Severity: "warning"}}},
+			{Field: key("items"), Type: "list<object>", Optional: true},
+			{Field: key("items", "[*]", "name"), Type: "string"},
+			{Field: key("timeout"), Type: "float", Default: "2", DefaultValue: 2.0, Notes: "Timeout in seconds"},
+			{Field: key("log", "file.name"), Type: "file", Nullable: true},
+			{Field: key("type"), Type: "string | int", Optional: true},
+		},
+		Objects: []ObjectDef{
+			{
+				Name: "user",
+				Properties: []ObjectPropertyDef{
+					{Name: "name", Type: "string"},
+					{Name: "age", Type: "int", Optional: true, Severity: "info", Checks: []CheckWithLocation{{Check: "gte(0)"}}},
+				},
+			},
+		},
+		Predicates: []PredicateDef{
+			{Name: "within", Params: []string{"min", "max"}, Check: "gte(min)&&lte(max)"},
+		},
+	}
+
+	expected := `config: "./config.json" json
+import (auth: "./auth.cms", db: "./db.cms")
+
+spec strict {
+    server <object> {
+        host <type: host, default: "localhost">
+        port <port> ( unprivileged: gte(1024); open() <severity: warning>; )
+    }
+    items <list<object>> optional
+    items[*].name <string>
+    timeout <type: float, default: 2.0, notes: "Timeout in seconds">
+    log.'file.name' <type: file, nullable: true>
+    'type' <string | int> optional
+}
+
+objects {
+    user {
+        name <string>
+        age <type: int, optional: true, severity: info> ( gte(0); )
+    }
+}
+
+checks {
+    within(min, max) = gte(min)&&lte(max);
+}
+`
+
+	if result := WriteSpecification(specification); result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
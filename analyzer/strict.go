@@ -0,0 +1,233 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/analyzer/types"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// undeclaredKeysFinder walks a config file looking for keys that are
+// not declared in the spec, either as fields or as properties of
+// custom objects. Keys are only reported inside strict fields, or in
+// the whole file if the spec is strict.
+type undeclaredKeysFinder struct {
+	fieldSpecs []spec.FieldSpec
+	objects    map[string]spec.ObjectDef
+	specFile   string
+	configFile string
+	specErrors []SpecError
+}
+
+// findUndeclaredKeys returns a SpecError for each key of the config
+// file that is not declared in the spec.
+func findUndeclaredKeys(
+	configFile *parsers.Node,
+	fieldSpecs []spec.FieldSpec,
+	objects []spec.ObjectDef,
+	strict bool,
+	specFilePath string,
+	configFilePath string) []SpecError {
+	finder := &undeclaredKeysFinder{
+		fieldSpecs: fieldSpecs,
+		objects:    make(map[string]spec.ObjectDef),
+		specFile:   specFilePath,
+		configFile: configFilePath,
+		specErrors: []SpecError{},
+	}
+	for _, object := range objects {
+		finder.objects[object.Name] = object
+	}
+
+	finder.walk(configFile, &parsers.NodeKey{Segments: []string{}}, "", strict)
+
+	return finder.specErrors
+}
+
+// walk checks the keys inside node, which is at key and was declared with typename.
+func (f *undeclaredKeysFinder) walk(node *parsers.Node, key *parsers.NodeKey, typename string, strict bool) {
+	switch node.Type {
+	case parsers.Object:
+		children := node.Value.(map[string]*parsers.Node)
+
+		// Tagged unions declare the properties of the object picked by the tag
+		typename = types.ResolveTypeName(typename, children)
+
+		// Sort names so errors are always in the same order
+		names := make([]string, 0, len(children))
+		for name := range children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			child := children[name]
+			childKey := joinSegment(key, name)
+
+			childType, childStrict, declared := f.declaration(childKey, name, typename)
+
+			// Entries are declared by the map, with the type of its values
+			if _, valueType, ok := types.MapTypes(typename); ok && !declared {
+				childType, declared = valueType, true
+			}
+
+			if !declared {
+				if strict {
+					f.reportUndeclaredKey(childKey, name, child, typename)
+				}
+				continue
+			}
+
+			f.walk(child, childKey, childType, strict || childStrict)
+		}
+	case parsers.Array:
+		// Elements are declared by the array, with the type of its elements
+		elementType := ""
+		if strings.HasPrefix(typename, "list<") && strings.HasSuffix(typename, ">") {
+			elementType = typename[5 : len(typename)-1]
+		}
+
+		for i, child := range node.Value.([]*parsers.Node) {
+			childKey := joinSegment(key, parsers.IndexSegment(i))
+
+			childType, childStrict, declared := f.declaration(childKey, "", "")
+			if !declared {
+				childType = elementType
+			}
+
+			f.walk(child, childKey, childType, strict || childStrict)
+		}
+	}
+}
+
+// declaration returns the type of the key, whether it is strict, and whether
+// it is declared, as a field in the spec or as a property of its parent type.
+func (f *undeclaredKeysFinder) declaration(key *parsers.NodeKey, name string, parentType string) (string, bool, bool) {
+	for _, fspec := range f.fieldSpecs {
+		if fspec.Field.Matches(key) {
+			return fspec.Type, fspec.Strict, true
+		}
+	}
+
+	if object, ok := f.objects[parentType]; ok {
+		for _, property := range object.Properties {
+			if property.Name == name {
+				return property.Type, property.Strict, true
+			}
+		}
+	}
+
+	return "", false, false
+}
+
+// reportUndeclaredKey adds a SpecError for the key, suggesting the
+// declared name closest to it.
+func (f *undeclaredKeysFinder) reportUndeclaredKey(key *parsers.NodeKey, name string, node *parsers.Node, parentType string) {
+	specError := SpecError{
+		AnalyzerMsg: fmt.Sprintf("Key %s in file %s is not declared in the specification", key.String(), f.configFile),
+		ErrorMsgs:   []string{},
+		TokenList: []TokenLocationWithFile{
+			{
+				File:     f.configFile,
+				Location: node.NameLocation,
+			},
+		},
+	}
+
+	if suggestion := closestName(name, f.declaredNames(key, parentType)); suggestion != "" {
+		specError.ErrorMsgs = append(specError.ErrorMsgs, fmt.Sprintf("did you mean '%s'?", suggestion))
+	}
+
+	f.specErrors = append(f.specErrors, specError)
+}
+
+// declaredNames returns the names declared next to the key, as fields
+// in the spec or as properties of its parent type.
+func (f *undeclaredKeysFinder) declaredNames(key *parsers.NodeKey, parentType string) []string {
+	parentKey := &parsers.NodeKey{Segments: key.Segments[:len(key.Segments)-1]}
+
+	names := []string{}
+	for _, fspec := range f.fieldSpecs {
+		segments := fspec.Field.Segments
+		if len(segments) != len(key.Segments) {
+			continue
+		}
+
+		fieldParentKey := &parsers.NodeKey{Segments: segments[:len(segments)-1]}
+		if fieldParentKey.Matches(parentKey) {
+			names = append(names, segments[len(segments)-1])
+		}
+	}
+
+	if object, ok := f.objects[parentType]; ok {
+		for _, property := range object.Properties {
+			names = append(names, property.Name)
+		}
+	}
+
+	return names
+}
+
+// closestName returns the candidate with the smallest edit distance to
+// name, or an empty string if no candidate is close enough to be a typo.
+func closestName(name string, candidates []string) string {
+	maxDistance := len(name) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	closest := ""
+	closestDistance := maxDistance + 1
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance < closestDistance {
+			closest = candidate
+			closestDistance = distance
+		}
+	}
+
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Distances from the previous prefix of a to every prefix of b
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j] + 1 // Deletion
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1 // Insertion
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost // Substitution
+			}
+		}
+		previous = current
+	}
+
+	return previous[len(rb)]
+}
+
+// joinSegment returns a new key with the segment added at the end.
+func joinSegment(key *parsers.NodeKey, segment string) *parsers.NodeKey {
+	segments := make([]string, 0, len(key.Segments)+1)
+	segments = append(segments, key.Segments...)
+	return &parsers.NodeKey{Segments: append(segments, segment)}
+}
@@ -0,0 +1,191 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// TestFindUndeclaredKeys tests that keys not declared in the spec are
+// reported only inside strict specs and fields, with a suggestion.
+func TestFindUndeclaredKeys(t *testing.T) {
+	// Test cases
+	type testCase struct {
+		fieldSpecs []spec.FieldSpec
+		strict     bool
+		expected   []string
+	}
+
+	server := spec.FieldSpec{Field: &parsers.NodeKey{Segments: []string{"server"}}, Type: "object"}
+	bindAddress := spec.FieldSpec{Field: &parsers.NodeKey{Segments: []string{"server", "bindAddress"}}, Type: "string"}
+	apis := spec.FieldSpec{Field: &parsers.NodeKey{Segments: []string{"apis"}}, Type: "list<api_info>"}
+	strictServer := server
+	strictServer.Strict = true
+	env := spec.FieldSpec{Field: &parsers.NodeKey{Segments: []string{"env"}}, Type: "object"}
+	envs := spec.FieldSpec{Field: &parsers.NodeKey{Segments: []string{"env", "[*]"}}, Type: "object"}
+	envHost := spec.FieldSpec{Field: &parsers.NodeKey{Segments: []string{"env", "[*]", "hostname"}}, Type: "string"}
+
+	testCases := []testCase{
+		{
+			fieldSpecs: []spec.FieldSpec{server, bindAddress, apis},
+			strict:     false,
+			expected:   []string{},
+		},
+		{
+			fieldSpecs: []spec.FieldSpec{strictServer, bindAddress, apis},
+			strict:     false,
+			expected:   []string{"server.bindAdress: did you mean 'bindAddress'?"},
+		},
+		{
+			fieldSpecs: []spec.FieldSpec{server, bindAddress, apis},
+			strict:     true,
+			expected: []string{
+				"apis[1].timout: did you mean 'timeout'?",
+				"env",
+				"server.bindAdress: did you mean 'bindAddress'?",
+				"version",
+			},
+		},
+		{
+			fieldSpecs: []spec.FieldSpec{server, bindAddress, apis, env, envs, envHost},
+			strict:     true,
+			expected: []string{
+				"apis[1].timout: did you mean 'timeout'?",
+				"env.dev.hostnme: did you mean 'hostname'?",
+				"server.bindAdress: did you mean 'bindAddress'?",
+				"version",
+			},
+		},
+	}
+
+	objects := []spec.ObjectDef{
+		{
+			Name: "api_info",
+			Properties: []spec.ObjectPropertyDef{
+				{Name: "endpoint", Type: "string"},
+				{Name: "timeout", Type: "int"},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		specErrors := findUndeclaredKeys(undeclaredKeysConfigFile(), test.fieldSpecs, objects, test.strict, "spec.cms", "config.json")
+
+		actual := []string{}
+		for _, specError := range specErrors {
+			key := specError.TokenList[0].Location.Start.Line
+			reported := undeclaredKeyNames[key]
+			for _, msg := range specError.ErrorMsgs {
+				reported += ": " + msg
+			}
+			actual = append(actual, reported)
+		}
+
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("findUndeclaredKeys(strict: %t) returned %v, expected %v", test.strict, actual, test.expected)
+		}
+	}
+}
+
+// TestClosestName tests the suggestions for undeclared keys.
+func TestClosestName(t *testing.T) {
+	// Test cases
+	type testCase struct {
+		name       string
+		candidates []string
+		expected   string
+	}
+
+	testCases := []testCase{
+		{name: "bindAdress", candidates: []string{"port", "bindAddress"}, expected: "bindAddress"},
+		{name: "PORT", candidates: []string{"port", "host"}, expected: "port"},
+		{name: "version", candidates: []string{"port", "host"}, expected: ""},
+		{name: "x", candidates: []string{}, expected: ""},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		if actual := closestName(test.name, test.candidates); actual != test.expected {
+			t.Errorf("closestName(%s, %v) returned '%s', expected '%s'", test.name, test.candidates, actual, test.expected)
+		}
+	}
+}
+
+// undeclaredKeyNames maps the line of each key in undeclaredKeysConfigFile to its name.
+var undeclaredKeyNames = map[int]string{
+	1:  "server.bindAdress",
+	2:  "server.bindAddress",
+	3:  "apis[0].endpoint",
+	4:  "apis[1].timout",
+	5:  "version",
+	6:  "env",
+	7:  "env.prod",
+	8:  "env.prod.hostname",
+	9:  "env.dev",
+	10: "env.dev.hostnme",
+}
+
+// undeclaredKeysConfigFile returns a config file with keys that are
+// not declared in the spec, each of them on its own line.
+func undeclaredKeysConfigFile() *parsers.Node {
+	nameOnLine := func(line int) parsers.TokenLocation {
+		return parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: line, Column: 0},
+			End:   parsers.CharLocation{Line: line, Column: 1},
+		}
+	}
+
+	return &parsers.Node{
+		Type: parsers.Object,
+		Value: map[string]*parsers.Node{
+			"server": {
+				Type: parsers.Object,
+				Value: map[string]*parsers.Node{
+					"bindAdress":  {Type: parsers.String, Value: "0.0.0.0", NameLocation: nameOnLine(1)},
+					"bindAddress": {Type: parsers.String, Value: "0.0.0.0", NameLocation: nameOnLine(2)},
+				},
+			},
+			"apis": {
+				Type: parsers.Array,
+				Value: []*parsers.Node{
+					{
+						Type: parsers.Object,
+						Value: map[string]*parsers.Node{
+							"endpoint": {Type: parsers.String, Value: "/users", NameLocation: nameOnLine(3)},
+						},
+					},
+					{
+						Type: parsers.Object,
+						Value: map[string]*parsers.Node{
+							"timout": {Type: parsers.Int, Value: 30, NameLocation: nameOnLine(4)},
+						},
+					},
+				},
+			},
+			"version": {Type: parsers.Int, Value: 2, NameLocation: nameOnLine(5)},
+			"env": {
+				Type: parsers.Object,
+				Value: map[string]*parsers.Node{
+					"prod": {
+						Type: parsers.Object,
+						Value: map[string]*parsers.Node{
+							"hostname": {Type: parsers.String, Value: "prod.example.com", NameLocation: nameOnLine(8)},
+						},
+						NameLocation: nameOnLine(7),
+					},
+					"dev": {
+						Type: parsers.Object,
+						Value: map[string]*parsers.Node{
+							"hostnme": {Type: parsers.String, Value: "localhost", NameLocation: nameOnLine(10)},
+						},
+						NameLocation: nameOnLine(9),
+					},
+				},
+				NameLocation: nameOnLine(6),
+			},
+		},
+	}
+}
@@ -33,6 +33,7 @@ func init() {
 			"float":     floatFactory,
 			"string":    stringFactory,
 			"object":    objectFactory,
+			"null":      nullFactory,
 			"host":      hostFactory,
 			"port":      portFactory,
 			"host_port": hostPortFactory,
@@ -43,12 +44,32 @@ func init() {
 }
 
 func (tf *typeFactory) makeType(typename string, value interface{}) (IType, error) {
+	if alternatives := splitTopLevel(typename, '|'); len(alternatives) > 1 {
+		return unionFactory(alternatives, value)
+	}
+
 	if strings.HasPrefix(typename, "list<") && strings.HasSuffix(typename, ">") {
 		return listFactory(typename[5:len(typename)-1], value)
 	}
 
+	if keyType, valueType, ok := MapTypes(typename); ok {
+		return mapFactory(keyType, valueType, value)
+	}
+
+	if strings.HasPrefix(typename, "enum(") && strings.HasSuffix(typename, ")") {
+		return enumFactory(typename[5:len(typename)-1], value)
+	}
+
+	if strings.HasPrefix(typename, "tagged(") && strings.HasSuffix(typename, ")") {
+		return taggedUnionFactory(typename[7:len(typename)-1], value)
+	}
+
 	if customDef, ok := tf.customObjTypes[typename]; ok {
-		return customObjectFactory(value.(map[string]*parsers.Node), customDef)
+		objValue, ok := value.(map[string]*parsers.Node)
+		if !ok {
+			return nil, fmt.Errorf("value is not an object")
+		}
+		return customObjectFactory(objValue, customDef)
 	}
 
 	if factory, ok := tf.factories[typename]; ok {
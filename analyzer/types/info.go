@@ -1,5 +1,82 @@
 package types
 
+import (
+	"fmt"
+	"strings"
+)
+
+// MethodParam is a parameter of a method.
+type MethodParam struct {
+	Name string `json:"name"` // Name of the parameter
+	Type string `json:"type"` // Type of the arguments it accepts
+}
+
+// MethodInfo describes a method of a type. The types of the elements of
+// a list, of the keys and values of a map, and of the properties of a
+// custom object are written elementtype, keytype, valuetype and fieldtype.
+type MethodInfo struct {
+	Type        string        `json:"type"`        // Type the method is called on, any if it can be called on values of every type
+	Name        string        `json:"name"`        // Name of the method
+	Params      []MethodParam `json:"params"`      // Parameters of the method
+	Variadic    bool          `json:"variadic"`    // Whether the last parameter takes any number of arguments
+	Result      string        `json:"result"`      // Type of the value returned
+	Description string        `json:"description"` // What the method does
+	Examples    []string      `json:"examples"`    // Checks calling the method
+}
+
+// TypeInfo describes a type and its methods.
+type TypeInfo struct {
+	Name    string       `json:"name"`
+	Methods []MethodInfo `json:"methods"`
+}
+
+// Signature returns the signature of the method, like
+// int.range(min int, max int) bool.
+func (m MethodInfo) Signature() string {
+	params := make([]string, 0, len(m.Params))
+	for i, param := range m.Params {
+		if m.Variadic && i == len(m.Params)-1 {
+			params = append(params, fmt.Sprintf("%s ...%s", param.Name, param.Type))
+		} else {
+			params = append(params, fmt.Sprintf("%s %s", param.Name, param.Type))
+		}
+	}
+
+	return fmt.Sprintf("%s.%s(%s) %s", m.Type, m.Name, strings.Join(params, ", "), m.Result)
+}
+
+// typeMethods is the registry of the methods of each type.
+var typeMethods = map[string][]MethodInfo{
+	"bool":          tBoolMethodsInfo,
+	"int":           tIntMethodsInfo,
+	"float":         tFloatMethodsInfo,
+	"string":        tStringMethodsInfo,
+	"list":          tListMethodsInfo,
+	"map":           tMapMethodsInfo,
+	"null":          tNullMethodsInfo,
+	"file":          tFileMethodsInfo,
+	"host":          tHostMethodsInfo,
+	"port":          tPortMethodsInfo,
+	"host_port":     tHostPortMethodsInfo,
+	"custom_object": tCustomObjectMethodsInfo,
+}
+
+func init() {
+	// Methods of null can be called on values of every type
+	for typename, methods := range typeMethods {
+		for i := range methods {
+			if typename == "null" {
+				methods[i].Type = "any"
+			} else {
+				methods[i].Type = typename
+			}
+			if methods[i].Params == nil {
+				methods[i].Params = []MethodParam{}
+			}
+		}
+	}
+}
+
 func GetTypes() []string {
 	return []string{
 		"bool",
@@ -7,6 +84,8 @@ func GetTypes() []string {
 		"float",
 		"string",
 		"list",
+		"map",
+		"null",
 		"file",
 		"host",
 		"port",
@@ -15,23 +94,32 @@ func GetTypes() []string {
 	}
 }
 
-func GetTypeInfo(typename string) map[string]string {
-	info := map[string]map[string]string{
-		"bool":          tBoolMethodsDescriptions,
-		"int":           tIntMethodsDescriptions,
-		"float":         tFloatMethodsDescriptions,
-		"string":        tStringMethodsDescriptions,
-		"list":          tListMethodsDescriptions,
-		"file":          tFileMethodsDescriptions,
-		"host":          tHostMethodsDescriptions,
-		"port":          tPortMethodsDescriptions,
-		"host_port":     tHostPortMethodsDescriptions,
-		"custom_object": tCustomObjectMethodsDescriptions,
+// GetMethods returns the methods of a type, or nil if the type
+// doesn't exist.
+func GetMethods(typename string) []MethodInfo {
+	return typeMethods[typename]
+}
+
+// GetTypesInfo returns every type with its methods.
+func GetTypesInfo() []TypeInfo {
+	typesInfo := make([]TypeInfo, 0, len(typeMethods))
+	for _, typename := range GetTypes() {
+		typesInfo = append(typesInfo, TypeInfo{
+			Name:    typename,
+			Methods: typeMethods[typename],
+		})
 	}
 
-	if _, ok := info[typename]; !ok {
-		return nil
+	return typesInfo
+}
+
+// findMethod returns the method of a type with the given name.
+func findMethod(typename string, method string) (MethodInfo, bool) {
+	for _, info := range typeMethods[typename] {
+		if info.Name == method {
+			return info, true
+		}
 	}
 
-	return info[typename]
+	return MethodInfo{}, false
 }
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MethodType returns the type of the result of calling a method on a
+// value of type typename, with arguments of the given types, or an error
+// if the call would fail whatever the value is. An empty type is a type
+// that can't be known before the value is read, like the alternative of
+// a union; it is accepted everywhere and has every method.
+func MethodType(typename string, method string, argTypes []string) (string, error) {
+	receiver, substitutions := signatureReceiver(typename)
+	if receiver == "" {
+		return "", nil
+	}
+
+	// isNull can be used on values of every type
+	if method == "isNull" {
+		receiver = "null"
+	}
+
+	info, ok := findMethod(receiver, method)
+	if !ok {
+		return "", fmt.Errorf("%s does not have a method %s", typename, method)
+	}
+
+	// Check that the correct number of arguments were passed, the last
+	// parameter of variadic methods takes the remaining arguments
+	params := info.Params
+	switch {
+	case info.Variadic && len(argTypes) < len(params)-1:
+		return "", fmt.Errorf("%s expects at least %d arguments, got %d", qualifiedName(info), len(params)-1, len(argTypes))
+	case !info.Variadic && len(argTypes) != len(params) && len(params) == 1:
+		return "", fmt.Errorf("%s expects 1 argument, got %d", qualifiedName(info), len(argTypes))
+	case !info.Variadic && len(argTypes) != len(params):
+		return "", fmt.Errorf("%s expects %d arguments, got %d", qualifiedName(info), len(params), len(argTypes))
+	}
+
+	// Check the types of the arguments
+	for i, argType := range argTypes {
+		param := params[len(params)-1]
+		if i < len(params) {
+			param = params[i]
+		}
+
+		paramType := substitutions.Replace(param.Type)
+		if !assignableType(argType, paramType) {
+			return "", fmt.Errorf("argument %d of %s must be %s, got %s", i+1, qualifiedName(info), paramType, argType)
+		}
+	}
+
+	return substitutions.Replace(info.Result), nil
+}
+
+// qualifiedName returns the name of a method with its type, like int.range.
+func qualifiedName(info MethodInfo) string {
+	if info.Type == "any" {
+		return info.Name
+	}
+	return info.Type + "." + info.Name
+}
+
+// signatureReceiver returns the type whose methods apply to values of
+// type typename, and the replacer of the types of their elements, keys,
+// values and properties. The receiver is empty when the type of the
+// values can't be known before they are read.
+func signatureReceiver(typename string) (string, *strings.Replacer) {
+	none := strings.NewReplacer()
+	if typename == "" || len(splitTopLevel(typename, '|')) > 1 {
+		return "", none
+	}
+
+	if strings.HasPrefix(typename, "list<") && strings.HasSuffix(typename, ">") {
+		return "list", strings.NewReplacer("elementtype", typename[5:len(typename)-1])
+	}
+
+	if keyType, valueType, ok := MapTypes(typename); ok {
+		return "map", strings.NewReplacer("keytype", keyType, "valuetype", valueType)
+	}
+
+	// Values of enums are values of the type of the enum value they are equal to
+	if values, ok := EnumValues(typename); ok {
+		enumType := ""
+		for i, value := range values {
+			var valueType string
+			switch value.(type) {
+			case string:
+				valueType = "string"
+			case int:
+				valueType = "int"
+			case float64:
+				valueType = "float"
+			default:
+				valueType = "bool"
+			}
+			if i > 0 && valueType != enumType {
+				return "", none
+			}
+			enumType = valueType
+		}
+		return enumType, none
+	}
+
+	// The type of a property depends on its name, which is a value
+	if _, ok := tf.customObjTypes[typename]; ok {
+		return "custom_object", strings.NewReplacer("fieldtype", "")
+	}
+
+	// Objects without a definition don't have methods
+	if _, ok := typeMethods[typename]; ok || typename == "object" {
+		return typename, none
+	}
+
+	// Tagged unions, and types that are not defined, are known once read
+	return "", none
+}
+
+// assignableType returns whether a value of type argType can be passed
+// as a parameter of type paramType.
+func assignableType(argType string, paramType string) bool {
+	if argType == "" || paramType == "" || argType == paramType {
+		return true
+	}
+
+	// Ports can be made from ints
+	return paramType == "port" && argType == "int"
+}
@@ -5,9 +5,20 @@ import (
 	"strconv"
 )
 
-var tBoolMethodsDescriptions map[string]string = map[string]string{
-	"eq":       "bool.eq(arg bool) bool : Checks that the value is equal to the argument",
-	"toString": "bool.toString() string : Converts the value to a string",
+var tBoolMethodsInfo = []MethodInfo{
+	{
+		Name:        "eq",
+		Params:      []MethodParam{{Name: "arg", Type: "bool"}},
+		Result:      "bool",
+		Description: "Checks that the value is equal to the argument",
+		Examples:    []string{"eq(true)"},
+	},
+	{
+		Name:        "toString",
+		Result:      "string",
+		Description: "Converts the value to a string",
+		Examples:    []string{"toString().eq(\"true\")"},
+	},
 }
 
 type tBool struct {
@@ -7,8 +7,14 @@ import (
 	"github.com/ConfigMate/configmate/parsers"
 )
 
-var tCustomObjectMethodsDescriptions map[string]string = map[string]string{
-	"get": "custom_object.get(field string) fieldtype : Gets the specified field",
+var tCustomObjectMethodsInfo = []MethodInfo{
+	{
+		Name:        "get",
+		Params:      []MethodParam{{Name: "field", Type: "string"}},
+		Result:      "fieldtype",
+		Description: "Gets the specified field",
+		Examples:    []string{"get(\"port\").gte(1024)"},
+	},
 }
 
 // Special error to indicate that an optional field is missing
@@ -30,9 +36,21 @@ func customObjectFactory(objValue map[string]*parsers.Node, definition spec.Obje
 
 	for _, prop := range definition.Properties {
 		if propNode, ok := objValue[prop.Name]; ok {
-			t, err := MakeType(prop.Type, propNode.Value)
+			// Null values of nullable properties don't need to match the type
+			propType := prop.Type
+			if propNode.Type == parsers.Null && prop.Nullable {
+				propType = "null"
+			}
+
+			t, err := MakeType(propType, propNode.Value)
+			if err != nil {
+				return nil, fmt.Errorf("property %s: %s", prop.Name, err.Error())
+			}
+			customObj.Fields[prop.Name] = t
+		} else if prop.DefaultValue != nil {
+			t, err := MakeType(prop.Type, prop.DefaultValue)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("default value of property %s: %s", prop.Name, err.Error())
 			}
 			customObj.Fields[prop.Name] = t
 		} else if prop.Optional {
@@ -9,15 +9,55 @@ import (
 	"syscall"
 )
 
-var tFileMethodsDescriptions map[string]string = map[string]string{
-	"exists":       "file.exists() bool : Checks that the file exists",
-	"isDir":        "file.isDir() bool : Checks that the file is a directory",
-	"parentExists": "file.parentExists() bool : Checks that the parent directory exists",
-	"size":         "file.size() int : Gets the size of the file in bytes",
-	"perms":        "file.perms() string : Gets the permissions of the file as a string (e.g. \"0644\")",
-	"user":         "file.user() string : Gets the user that owns the file",
-	"group":        "file.group() string : Gets the group that owns the file",
-	"toString":     "file.toString() string : Converts the value to a string",
+var tFileMethodsInfo = []MethodInfo{
+	{
+		Name:        "exists",
+		Result:      "bool",
+		Description: "Checks that the file exists",
+		Examples:    []string{"exists()"},
+	},
+	{
+		Name:        "isDir",
+		Result:      "bool",
+		Description: "Checks that the file is a directory",
+		Examples:    []string{"isDir()"},
+	},
+	{
+		Name:        "parentExists",
+		Result:      "bool",
+		Description: "Checks that the parent directory exists",
+		Examples:    []string{"parentExists()"},
+	},
+	{
+		Name:        "size",
+		Result:      "int",
+		Description: "Gets the size of the file in bytes",
+		Examples:    []string{"size().lt(1048576)"},
+	},
+	{
+		Name:        "perms",
+		Result:      "string",
+		Description: "Gets the permissions of the file as a string (e.g. \"0644\")",
+		Examples:    []string{"perms().eq(\"0600\")"},
+	},
+	{
+		Name:        "user",
+		Result:      "string",
+		Description: "Gets the user that owns the file",
+		Examples:    []string{"user().eq(\"root\")"},
+	},
+	{
+		Name:        "group",
+		Result:      "string",
+		Description: "Gets the group that owns the file",
+		Examples:    []string{"group().eq(\"www-data\")"},
+	},
+	{
+		Name:        "toString",
+		Result:      "string",
+		Description: "Converts the value to a string",
+		Examples:    []string{"toString().regex(\"[.]pem$\")"},
+	},
 }
 
 type tFile struct {
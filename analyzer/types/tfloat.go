@@ -4,15 +4,61 @@ import (
 	"fmt"
 )
 
-var tFloatMethodsDescriptions map[string]string = map[string]string{
-	"eq":       "float.eq(arg float) bool : Checks that the value is equal to the argument",
-	"gt":       "float.gt(arg float) bool : Checks that the value is greater than the argument",
-	"gte":      "float.gte(arg float) bool : Checks that the value is greater than or equal to the argument",
-	"lt":       "float.lt(arg float) bool : Checks that the value is less than the argument",
-	"lte":      "float.lte(arg float) bool : Checks that the value is less than or equal to the argument",
-	"range":    "float.range(min float, max float) bool : Checks that the value is within the range",
-	"toInt":    "float.toInt() int : Converts the value to an int",
-	"toString": "float.toString() string : Converts the value to a string",
+var tFloatMethodsInfo = []MethodInfo{
+	{
+		Name:        "eq",
+		Params:      []MethodParam{{Name: "arg", Type: "float"}},
+		Result:      "bool",
+		Description: "Checks that the value is equal to the argument",
+		Examples:    []string{"eq(0.5)"},
+	},
+	{
+		Name:        "gt",
+		Params:      []MethodParam{{Name: "arg", Type: "float"}},
+		Result:      "bool",
+		Description: "Checks that the value is greater than the argument",
+		Examples:    []string{"gt(0.0)"},
+	},
+	{
+		Name:        "gte",
+		Params:      []MethodParam{{Name: "arg", Type: "float"}},
+		Result:      "bool",
+		Description: "Checks that the value is greater than or equal to the argument",
+		Examples:    []string{"gte(0.1)"},
+	},
+	{
+		Name:        "lt",
+		Params:      []MethodParam{{Name: "arg", Type: "float"}},
+		Result:      "bool",
+		Description: "Checks that the value is less than the argument",
+		Examples:    []string{"lt(1.0)"},
+	},
+	{
+		Name:        "lte",
+		Params:      []MethodParam{{Name: "arg", Type: "float"}},
+		Result:      "bool",
+		Description: "Checks that the value is less than or equal to the argument",
+		Examples:    []string{"lte(0.9)"},
+	},
+	{
+		Name:        "range",
+		Params:      []MethodParam{{Name: "min", Type: "float"}, {Name: "max", Type: "float"}},
+		Result:      "bool",
+		Description: "Checks that the value is within the range",
+		Examples:    []string{"range(0.0, 1.0)"},
+	},
+	{
+		Name:        "toInt",
+		Result:      "int",
+		Description: "Converts the value to an int",
+		Examples:    []string{"toInt().eq(3)"},
+	},
+	{
+		Name:        "toString",
+		Result:      "string",
+		Description: "Converts the value to a string",
+		Examples:    []string{"toString().eq(\"0.5\")"},
+	},
 }
 
 type tFloat struct {
@@ -8,10 +8,26 @@ import (
 	probing "github.com/prometheus-community/pro-bing"
 )
 
-var tHostMethodsDescriptions map[string]string = map[string]string{
-	"reachable": "host.reachable() bool : Checks that the host is reachable",
-	"addPort":   "host.addPort(p port) host_port : Adds a port to the host to form a host_port type",
-	"toString":  "host.toString() string : Converts the value to a string",
+var tHostMethodsInfo = []MethodInfo{
+	{
+		Name:        "reachable",
+		Result:      "bool",
+		Description: "Checks that the host is reachable",
+		Examples:    []string{"reachable()"},
+	},
+	{
+		Name:        "addPort",
+		Params:      []MethodParam{{Name: "p", Type: "port"}},
+		Result:      "host_port",
+		Description: "Adds a port to the host to form a host_port type",
+		Examples:    []string{"addPort(server.port).live()", "addPort(443).live()"},
+	},
+	{
+		Name:        "toString",
+		Result:      "string",
+		Description: "Converts the value to a string",
+		Examples:    []string{"toString().eq(\"localhost\")"},
+	},
 }
 
 type tHost struct {
@@ -7,11 +7,31 @@ import (
 	"time"
 )
 
-var tHostPortMethodsDescriptions map[string]string = map[string]string{
-	"live":     "host_port.live() bool : Checks that the host:port is live",
-	"getHost":  "host_port.getHost() host : Gets the host",
-	"getPort":  "host_port.getPort() port : Gets the port",
-	"toString": "host_port.toString() string : Converts the value to a string",
+var tHostPortMethodsInfo = []MethodInfo{
+	{
+		Name:        "live",
+		Result:      "bool",
+		Description: "Checks that the host:port is live",
+		Examples:    []string{"live()"},
+	},
+	{
+		Name:        "getHost",
+		Result:      "host",
+		Description: "Gets the host",
+		Examples:    []string{"getHost().reachable()"},
+	},
+	{
+		Name:        "getPort",
+		Result:      "port",
+		Description: "Gets the port",
+		Examples:    []string{"getPort().toInt().eq(443)"},
+	},
+	{
+		Name:        "toString",
+		Result:      "string",
+		Description: "Converts the value to a string",
+		Examples:    []string{"toString().eq(\"localhost:8080\")"},
+	},
 }
 
 type tHostPort struct {
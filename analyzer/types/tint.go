@@ -5,15 +5,61 @@ import (
 	"strconv"
 )
 
-var tIntMethodsDescriptions map[string]string = map[string]string{
-	"eq":       "int.eq(arg int) bool : Checks that the value is equal to the argument",
-	"gt":       "int.gt(arg int) bool : Checks that the value is greater than the argument",
-	"gte":      "int.gte(arg int) bool : Checks that the value is greater than or equal to the argument",
-	"lt":       "int.lt(arg int) bool : Checks that the value is less than the argument",
-	"lte":      "int.lte(arg int) bool : Checks that the value is less than or equal to the argument",
-	"range":    "int.range(min int, max int) bool : Checks that the value is in the range [min, max]",
-	"toFloat":  "int.toFloat() float : Converts the value to a float",
-	"toString": "int.toString() string : Converts the value to a string",
+var tIntMethodsInfo = []MethodInfo{
+	{
+		Name:        "eq",
+		Params:      []MethodParam{{Name: "arg", Type: "int"}},
+		Result:      "bool",
+		Description: "Checks that the value is equal to the argument",
+		Examples:    []string{"eq(3)"},
+	},
+	{
+		Name:        "gt",
+		Params:      []MethodParam{{Name: "arg", Type: "int"}},
+		Result:      "bool",
+		Description: "Checks that the value is greater than the argument",
+		Examples:    []string{"gt(0)"},
+	},
+	{
+		Name:        "gte",
+		Params:      []MethodParam{{Name: "arg", Type: "int"}},
+		Result:      "bool",
+		Description: "Checks that the value is greater than or equal to the argument",
+		Examples:    []string{"gte(1024)"},
+	},
+	{
+		Name:        "lt",
+		Params:      []MethodParam{{Name: "arg", Type: "int"}},
+		Result:      "bool",
+		Description: "Checks that the value is less than the argument",
+		Examples:    []string{"lt(65536)"},
+	},
+	{
+		Name:        "lte",
+		Params:      []MethodParam{{Name: "arg", Type: "int"}},
+		Result:      "bool",
+		Description: "Checks that the value is less than or equal to the argument",
+		Examples:    []string{"lte(100)"},
+	},
+	{
+		Name:        "range",
+		Params:      []MethodParam{{Name: "min", Type: "int"}, {Name: "max", Type: "int"}},
+		Result:      "bool",
+		Description: "Checks that the value is in the range [min, max]",
+		Examples:    []string{"range(1, 10)"},
+	},
+	{
+		Name:        "toFloat",
+		Result:      "float",
+		Description: "Converts the value to a float",
+		Examples:    []string{"toFloat().lt(ratio)"},
+	},
+	{
+		Name:        "toString",
+		Result:      "string",
+		Description: "Converts the value to a string",
+		Examples:    []string{"toString().regex(\"^[0-9]{4}$\")"},
+	},
 }
 
 type tInt struct {
@@ -6,9 +6,20 @@ import (
 	"github.com/ConfigMate/configmate/parsers"
 )
 
-var tListMethodsDescriptions map[string]string = map[string]string{
-	"at":  "list.at(index int) elementtype - returns the element at the given index",
-	"len": "list.len() int - returns the length of the list",
+var tListMethodsInfo = []MethodInfo{
+	{
+		Name:        "at",
+		Params:      []MethodParam{{Name: "index", Type: "int"}},
+		Result:      "elementtype",
+		Description: "Returns the element at the given index",
+		Examples:    []string{"at(0).eq(\"admin\")"},
+	},
+	{
+		Name:        "len",
+		Result:      "int",
+		Description: "Returns the length of the list",
+		Examples:    []string{"len().gte(1)"},
+	},
 }
 
 type tList struct {
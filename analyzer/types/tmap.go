@@ -0,0 +1,210 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+var tMapMethodsInfo = []MethodInfo{
+	{
+		Name:        "get",
+		Params:      []MethodParam{{Name: "key", Type: "keytype"}},
+		Result:      "valuetype",
+		Description: "Returns the value of the given key",
+		Examples:    []string{"get(\"timeout\").lte(30)"},
+	},
+	{
+		Name:        "has",
+		Params:      []MethodParam{{Name: "key", Type: "keytype"}},
+		Result:      "bool",
+		Description: "Checks that the map has the given key",
+		Examples:    []string{"has(\"default\")"},
+	},
+	{
+		Name:        "keys",
+		Result:      "list<keytype>",
+		Description: "Returns the keys of the map in order",
+		Examples:    []string{"keys().len().eq(2)"},
+	},
+	{
+		Name:        "len",
+		Result:      "int",
+		Description: "Returns the number of entries of the map",
+		Examples:    []string{"len().lte(10)"},
+	},
+	{
+		Name:        "values",
+		Result:      "list<valuetype>",
+		Description: "Returns the values of the map in the order of their keys",
+		Examples:    []string{"values().at(0).eq(1)"},
+	},
+}
+
+// EntryError is the error of an entry of a map that doesn't match
+// the map type, with the location of the entry in the file.
+type EntryError struct {
+	Key      string
+	Location parsers.TokenLocation
+	Err      error
+}
+
+func (e *EntryError) Error() string {
+	return fmt.Sprintf("entry %s: %s", e.Key, e.Err.Error())
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+type tMap struct {
+	keyType   string
+	valueType string
+	names     []string
+	keys      []IType
+	values    map[string]IType
+}
+
+func mapFactory(keyType string, valueType string, value interface{}) (IType, error) {
+	// Check that the value is an object
+	mapValues, ok := value.(map[string]*parsers.Node)
+	if !ok {
+		return nil, fmt.Errorf("value is not an object")
+	}
+
+	// Create a new map
+	m := &tMap{
+		keyType:   keyType,
+		valueType: valueType,
+		names:     make([]string, 0, len(mapValues)),
+		keys:      make([]IType, 0, len(mapValues)),
+		values:    make(map[string]IType),
+	}
+
+	// Sort keys so entries are always checked in the same order
+	keys := make([]string, 0, len(mapValues))
+	for key := range mapValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		k, err := MakeType(keyType, key)
+		if err != nil {
+			return nil, &EntryError{Key: key, Location: mapValues[key].NameLocation, Err: err}
+		}
+
+		v, err := MakeType(valueType, mapValues[key].Value)
+		if entryErr := (*EntryError)(nil); errors.As(err, &entryErr) {
+			// Entries of nested maps keep their own location
+			return nil, &EntryError{Key: key + "." + entryErr.Key, Location: entryErr.Location, Err: entryErr.Err}
+		} else if err != nil {
+			return nil, &EntryError{Key: key, Location: mapValues[key].ValueLocation, Err: err}
+		}
+
+		m.names = append(m.names, key)
+		m.keys = append(m.keys, k)
+		m.values[key] = v
+	}
+
+	return m, nil
+}
+
+// MapTypes returns the key and value types of a map type, and
+// whether typename is a map type.
+func MapTypes(typename string) (string, string, bool) {
+	if !strings.HasPrefix(typename, "map<") || !strings.HasSuffix(typename, ">") {
+		return "", "", false
+	}
+
+	parts := splitTopLevel(typename[4:len(typename)-1], ',')
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func (t tMap) TypeName() string {
+	return "map<" + t.keyType + "," + t.valueType + ">"
+}
+
+func (t tMap) Value() interface{} {
+	return t.values
+}
+
+func (t tMap) GetMethod(method string) Method {
+	tMapMethods := map[string]Method{
+		"get": func(args []IType) (IType, error) {
+			// Check that the correct number of arguments were passed
+			if len(args) != 1 {
+				return nil, fmt.Errorf("map.get expects 1 argument")
+			}
+
+			// Check that the key exists
+			key := fmt.Sprint(args[0].Value())
+			if _, ok := t.values[key]; !ok {
+				return nil, fmt.Errorf("map.get failed: key %s not found", key)
+			}
+
+			return t.values[key], nil
+		},
+		"has": func(args []IType) (IType, error) {
+			// Check that the correct number of arguments were passed
+			if len(args) != 1 {
+				return nil, fmt.Errorf("map.has expects 1 argument")
+			}
+
+			// Check that the key exists
+			key := fmt.Sprint(args[0].Value())
+			if _, ok := t.values[key]; !ok {
+				return &tBool{value: false}, fmt.Errorf("map.has failed: key %s not found", key)
+			}
+
+			return &tBool{value: true}, nil
+		},
+		"keys": func(args []IType) (IType, error) {
+			// Check that the correct number of arguments were passed
+			if len(args) != 0 {
+				return nil, fmt.Errorf("map.keys expects 0 arguments")
+			}
+
+			return &tList{listType: t.keyType, values: t.keys}, nil
+		},
+		"len": func(args []IType) (IType, error) {
+			// Check that the correct number of arguments were passed
+			if len(args) != 0 {
+				return nil, fmt.Errorf("map.len expects 0 arguments")
+			}
+
+			// Return the number of entries of the map
+			return &tInt{value: len(t.values)}, nil
+		},
+		"values": func(args []IType) (IType, error) {
+			// Check that the correct number of arguments were passed
+			if len(args) != 0 {
+				return nil, fmt.Errorf("map.values expects 0 arguments")
+			}
+
+			// Values are in the order of their keys
+			values := make([]IType, 0, len(t.names))
+			for _, name := range t.names {
+				values = append(values, t.values[name])
+			}
+
+			return &tList{listType: t.valueType, values: values}, nil
+		},
+	}
+
+	// Check if method doesn't exist
+	if _, ok := tMapMethods[method]; !ok {
+		return func(args []IType) (IType, error) {
+			return nil, fmt.Errorf("map does not have method %s", method)
+		}
+	}
+
+	return tMapMethods[method]
+}
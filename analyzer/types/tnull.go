@@ -0,0 +1,52 @@
+package types
+
+import "fmt"
+
+var tNullMethodsInfo = []MethodInfo{
+	{
+		Name:        "isNull",
+		Result:      "bool",
+		Description: "Checks that the value is null, it can be used on values of every type",
+		Examples:    []string{"isNull()", "!isNull()"},
+	},
+}
+
+type tNull struct{}
+
+func nullFactory(value interface{}) (IType, error) {
+	if value == nil {
+		return &tNull{}, nil
+	}
+
+	return nil, fmt.Errorf("value is not null")
+}
+
+func (t tNull) TypeName() string {
+	return "null"
+}
+
+func (t tNull) Value() interface{} {
+	return nil
+}
+
+func (t tNull) GetMethod(method string) Method {
+	tNullMethods := map[string]Method{
+		"isNull": func(args []IType) (IType, error) {
+			// Check that the correct number of arguments were passed
+			if len(args) != 0 {
+				return nil, fmt.Errorf("isNull expects 0 arguments")
+			}
+
+			return &tBool{value: true}, nil
+		},
+	}
+
+	// Check if method doesn't exist
+	if _, ok := tNullMethods[method]; !ok {
+		return func(args []IType) (IType, error) {
+			return nil, fmt.Errorf("null does not have method %s", method)
+		}
+	}
+
+	return tNullMethods[method]
+}
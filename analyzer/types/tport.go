@@ -7,10 +7,25 @@ import (
 	"time"
 )
 
-var tPortMethodsDescriptions map[string]string = map[string]string{
-	"open":  "port.open() bool : Checks that the port is open",
-	"live":  "port.live() bool : Checks that the port is live",
-	"toInt": "port.toInt() int : Converts the value to an int",
+var tPortMethodsInfo = []MethodInfo{
+	{
+		Name:        "open",
+		Result:      "bool",
+		Description: "Checks that the port is open",
+		Examples:    []string{"open()"},
+	},
+	{
+		Name:        "live",
+		Result:      "bool",
+		Description: "Checks that the port is live",
+		Examples:    []string{"live()"},
+	},
+	{
+		Name:        "toInt",
+		Result:      "int",
+		Description: "Converts the value to an int",
+		Examples:    []string{"toInt().gte(1024)"},
+	},
 }
 
 type tPort struct {
@@ -5,9 +5,21 @@ import (
 	"regexp"
 )
 
-var tStringMethodsDescriptions map[string]string = map[string]string{
-	"eq":    "string.eq(s string) bool : Checks that the value is equal to s",
-	"regex": "string.regex(pattern string) bool : Checks that the value matches the pattern",
+var tStringMethodsInfo = []MethodInfo{
+	{
+		Name:        "eq",
+		Params:      []MethodParam{{Name: "s", Type: "string"}},
+		Result:      "bool",
+		Description: "Checks that the value is equal to s",
+		Examples:    []string{"eq(\"production\")"},
+	},
+	{
+		Name:        "regex",
+		Params:      []MethodParam{{Name: "pattern", Type: "string"}},
+		Result:      "bool",
+		Description: "Checks that the value matches the pattern",
+		Examples:    []string{"regex(\"^[a-z]+$\")"},
+	},
 }
 
 type tString struct {
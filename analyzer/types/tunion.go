@@ -0,0 +1,235 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// unionFactory makes a value of the first type in alternatives that
+// accepts it, in the order they were declared.
+func unionFactory(alternatives []string, value interface{}) (IType, error) {
+	errs := make([]string, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		t, err := MakeType(alternative, value)
+		if err == nil {
+			return t, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", alternative, err.Error()))
+	}
+
+	return nil, fmt.Errorf("value does not match any of the allowed types %s (%s)",
+		strings.Join(alternatives, " | "), strings.Join(errs, "; "))
+}
+
+// enumFactory makes a value of the type of the enum value it is equal to.
+// Values are the comma separated primitives of the enum, as in the spec.
+func enumFactory(values string, value interface{}) (IType, error) {
+	allowed := splitTopLevel(values, ',')
+	for _, allowedValue := range allowed {
+		enumValue, err := parseEnumValue(allowedValue)
+		if err != nil {
+			return nil, err
+		}
+
+		if enumValue != value {
+			continue
+		}
+
+		switch enumValue.(type) {
+		case string:
+			return stringFactory(value)
+		case int:
+			return intFactory(value)
+		case float64:
+			return floatFactory(value)
+		default:
+			return boolFactory(value)
+		}
+	}
+
+	return nil, fmt.Errorf("value %#v is not one of the allowed values: %s", value, strings.Join(allowed, ", "))
+}
+
+// taggedUnionFactory makes a value of the object type picked by its tag
+// property. Variants are the tag property followed by the comma separated
+// variants of the union, as in the spec.
+func taggedUnionFactory(variants string, value interface{}) (IType, error) {
+	objectType, err := taggedUnionVariant(variants, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeType(objectType, value)
+}
+
+// taggedUnionVariant returns the object type of the variant picked by the
+// value of the tag property.
+func taggedUnionVariant(variants string, value interface{}) (string, error) {
+	items := splitTopLevel(variants, ',')
+	tag := items[0]
+
+	// Map tag values to object types
+	tagValues := make([]string, 0, len(items)-1)
+	objectTypes := make(map[string]string)
+	for _, item := range items[1:] {
+		variant := splitTopLevel(item, ':')
+		if len(variant) != 2 {
+			return "", fmt.Errorf("invalid variant %s of tagged union", item)
+		}
+
+		tagValue, err := parseEnumValue(variant[0])
+		if err != nil {
+			return "", err
+		}
+		tagValues = append(tagValues, variant[0])
+		objectTypes[fmt.Sprint(tagValue)] = variant[1]
+	}
+
+	// Check that the value is an object with the tag
+	objValue, ok := value.(map[string]*parsers.Node)
+	if !ok {
+		return "", fmt.Errorf("value is not an object")
+	}
+
+	tagNode, ok := objValue[tag]
+	if !ok {
+		return "", fmt.Errorf("missing tag property %s, expected one of: %s", tag, strings.Join(tagValues, ", "))
+	}
+
+	tagValue, ok := tagNode.Value.(string)
+	if !ok {
+		return "", fmt.Errorf("tag property %s is not a string, expected one of: %s", tag, strings.Join(tagValues, ", "))
+	}
+
+	objectType, ok := objectTypes[tagValue]
+	if !ok {
+		return "", fmt.Errorf("tag property %s has value %q, expected one of: %s", tag, tagValue, strings.Join(tagValues, ", "))
+	}
+
+	return objectType, nil
+}
+
+// parseEnumValue returns the value of a primitive written in a type:
+// a quoted string, an int, a float or a bool.
+func parseEnumValue(text string) (interface{}, error) {
+	if strings.HasPrefix(text, "\"") {
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return strings.Trim(text, "\""), nil
+		}
+		return value, nil
+	}
+
+	if text == "true" || text == "false" {
+		return text == "true", nil
+	}
+
+	if value, err := strconv.Atoi(text); err == nil {
+		return value, nil
+	}
+
+	if value, err := strconv.ParseFloat(text, 64); err == nil {
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("invalid value %s in type", text)
+}
+
+// splitTopLevel splits a type name at each separator that is not
+// nested inside brackets or quotes.
+func splitTopLevel(typename string, separator rune) []string {
+	parts := []string{}
+	depth := 0
+	inString := false
+	escaped := false
+	start := 0
+
+	for i, c := range typename {
+		switch {
+		case inString && escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(' || c == '<':
+			depth++
+		case c == ')' || c == '>':
+			depth--
+		case c == separator && depth == 0:
+			parts = append(parts, typename[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, typename[start:])
+}
+
+// ResolveTypeName returns the object type picked by a tagged union for
+// the value, or the type name itself for any other type.
+func ResolveTypeName(typename string, value interface{}) string {
+	if !strings.HasPrefix(typename, "tagged(") || !strings.HasSuffix(typename, ")") {
+		return typename
+	}
+
+	objectType, err := taggedUnionVariant(typename[7:len(typename)-1], value)
+	if err != nil {
+		return typename
+	}
+
+	return objectType
+}
+
+// UnionTypes returns the alternatives of a union type, or the type
+// name itself for any other type.
+func UnionTypes(typename string) []string {
+	alternatives := splitTopLevel(typename, '|')
+	for i := range alternatives {
+		alternatives[i] = strings.TrimSpace(alternatives[i])
+	}
+
+	return alternatives
+}
+
+// EnumValues returns the values allowed by an enum type, and
+// whether typename is a valid enum type.
+func EnumValues(typename string) ([]interface{}, bool) {
+	if !strings.HasPrefix(typename, "enum(") || !strings.HasSuffix(typename, ")") {
+		return nil, false
+	}
+
+	values := []interface{}{}
+	for _, text := range splitTopLevel(typename[5:len(typename)-1], ',') {
+		value, err := parseEnumValue(strings.TrimSpace(text))
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+
+	return values, true
+}
+
+// TaggedVariants returns the tag property and the object types of the
+// variants of a tagged union, and whether typename is a tagged union.
+func TaggedVariants(typename string) (string, []string, bool) {
+	if !strings.HasPrefix(typename, "tagged(") || !strings.HasSuffix(typename, ")") {
+		return "", nil, false
+	}
+
+	items := splitTopLevel(typename[7:len(typename)-1], ',')
+	objectTypes := make([]string, 0, len(items)-1)
+	for _, item := range items[1:] {
+		variant := splitTopLevel(item, ':')
+		if len(variant) != 2 {
+			return "", nil, false
+		}
+		objectTypes = append(objectTypes, strings.TrimSpace(variant[1]))
+	}
+
+	return strings.TrimSpace(items[0]), objectTypes, true
+}
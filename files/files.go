@@ -3,12 +3,15 @@ package files
 import (
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // FileFetcher is an interface that returns the
 // content of files given a path
 type FileFetcher interface {
 	FetchFile(filename string) ([]byte, error)
+	MatchFiles(pattern string) ([]string, error)
 }
 
 // FileGetterImpl is an implementation of FileGetter
@@ -35,3 +38,43 @@ func (f *fileFetcherImpl) FetchFile(filename string) ([]byte, error) {
 
 	return fileBytes, nil
 }
+
+// MatchFiles returns the files matched by a pattern, which can be
+// a glob, a directory (all files directly inside it) or a file path.
+// Paths are returned sorted, and directories are never matched.
+func (f *fileFetcherImpl) MatchFiles(pattern string) ([]string, error) {
+	// Glob
+	if strings.ContainsAny(pattern, "*?[") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		filenames := []string{}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				filenames = append(filenames, match)
+			}
+		}
+		return filenames, nil
+	}
+
+	// Directory
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		filenames := []string{}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				filenames = append(filenames, filepath.Join(pattern, entry.Name()))
+			}
+		}
+		return filenames, nil
+	}
+
+	// Single file, errors are reported when fetching it
+	return []string{pattern}, nil
+}
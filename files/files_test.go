@@ -0,0 +1,63 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestMatchFiles tests the MatchFiles function of the file fetcher.
+func TestMatchFiles(t *testing.T) {
+	// Create test files
+	dir := t.TempDir()
+	for _, name := range []string{"a.toml", "b.toml", "c.json", filepath.Join("nested", "d.toml")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Test cases
+	type testCase struct {
+		pattern  string
+		expected []string
+	}
+
+	testCases := []testCase{
+		{
+			pattern:  filepath.Join(dir, "*.toml"),
+			expected: []string{filepath.Join(dir, "a.toml"), filepath.Join(dir, "b.toml")},
+		},
+		{
+			pattern:  filepath.Join(dir, "*"),
+			expected: []string{filepath.Join(dir, "a.toml"), filepath.Join(dir, "b.toml"), filepath.Join(dir, "c.json")},
+		},
+		{
+			pattern:  dir,
+			expected: []string{filepath.Join(dir, "a.toml"), filepath.Join(dir, "b.toml"), filepath.Join(dir, "c.json")},
+		},
+		{
+			pattern:  filepath.Join(dir, "*.yaml"),
+			expected: []string{},
+		},
+		{
+			pattern:  filepath.Join(dir, "missing.toml"),
+			expected: []string{filepath.Join(dir, "missing.toml")},
+		},
+	}
+
+	// Run tests
+	fetcher := NewFileFetcher()
+	for _, test := range testCases {
+		matches, err := fetcher.MatchFiles(test.pattern)
+		if err != nil {
+			t.Errorf("MatchFiles(%s) returned error %s", test.pattern, err)
+		} else if !reflect.DeepEqual(matches, test.expected) {
+			t.Errorf("MatchFiles(%s) returned %v, expected %v", test.pattern, matches, test.expected)
+		}
+	}
+}
@@ -111,6 +111,29 @@ func (s *semanticTokenProviderImpl) EnterConfigDeclaration(ctx *parser_cmsl.Conf
 	}
 }
 
+// EnterExtendsStatement is called when production extendsStatement is entered.
+func (s *semanticTokenProviderImpl) EnterExtendsStatement(ctx *parser_cmsl.ExtendsStatementContext) {
+	// Add the extends keyword token
+	if extendsKeyword := ctx.EXTENDS_KW(); extendsKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      extendsKeyword.GetSymbol().GetLine() - 1,
+			Column:    extendsKeyword.GetSymbol().GetColumn(),
+			Length:    len(extendsKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+
+	// Add file path token
+	if filePath := ctx.SHORT_STRING(); filePath != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      filePath.GetSymbol().GetLine() - 1,
+			Column:    filePath.GetSymbol().GetColumn(),
+			Length:    len(filePath.GetText()),
+			TokenType: STTString,
+		})
+	}
+}
+
 // EnterImportStatement is called when production importStatement is entered.
 func (s *semanticTokenProviderImpl) EnterImportStatement(ctx *parser_cmsl.ImportStatementContext) {
 	// Add the import keyword token
@@ -158,6 +181,16 @@ func (s *semanticTokenProviderImpl) EnterSpecificationBody(ctx *parser_cmsl.Spec
 			TokenType: STTKeyword,
 		})
 	}
+
+	// Add the strict keyword token
+	if strictKeyword := ctx.STRICT_METAD_KW(); strictKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      strictKeyword.GetSymbol().GetLine() - 1,
+			Column:    strictKeyword.GetSymbol().GetColumn(),
+			Length:    len(strictKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
 }
 
 // EnterObjectDefinitions is called when production objectDefinitions is entered.
@@ -199,6 +232,36 @@ func (s *semanticTokenProviderImpl) EnterObjectPropertyDefinition(ctx *parser_cm
 	}
 }
 
+// EnterPredicateDefinitions is called when production predicateDefinitions is entered.
+func (s *semanticTokenProviderImpl) EnterPredicateDefinitions(ctx *parser_cmsl.PredicateDefinitionsContext) {
+	// Add the checks keyword token
+	if checksKeyword := ctx.CHECKS_DEF_KW(); checksKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      checksKeyword.GetSymbol().GetLine() - 1,
+			Column:    checksKeyword.GetSymbol().GetColumn(),
+			Length:    len(checksKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+}
+
+// EnterPredicateDefinition is called when production predicateDefinition is entered.
+func (s *semanticTokenProviderImpl) EnterPredicateDefinition(ctx *parser_cmsl.PredicateDefinitionContext) {
+	// The first identifier is the predicate name, the rest are its parameters
+	for i, identifier := range ctx.AllIDENTIFIER() {
+		tokenType := STTVariable
+		if i == 0 {
+			tokenType = STTMethod
+		}
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      identifier.GetSymbol().GetLine() - 1,
+			Column:    identifier.GetSymbol().GetColumn(),
+			Length:    len(identifier.GetText()),
+			TokenType: tokenType,
+		})
+	}
+}
+
 // EnterFieldName is called when production fieldName is entered.
 func (s *semanticTokenProviderImpl) EnterFieldName(ctx *parser_cmsl.FieldNameContext) {
 	// Add the field name token
@@ -285,18 +348,138 @@ func (s *semanticTokenProviderImpl) EnterOptionalMetadata(ctx *parser_cmsl.Optio
 	}
 }
 
-// EnterTypeExpr is called when production typeExpr is entered.
-func (s *semanticTokenProviderImpl) EnterTypeExpr(ctx *parser_cmsl.TypeExprContext) {
-	// Check if the type is a primitive
-	if ctx.IDENTIFIER() != nil {
-		// Add the type token
+// EnterStrictMetadata is called when production strictMetadata is entered.
+func (s *semanticTokenProviderImpl) EnterStrictMetadata(ctx *parser_cmsl.StrictMetadataContext) {
+	// Add the strict keyword token
+	if strictKeyword := ctx.STRICT_METAD_KW(); strictKeyword != nil {
 		s.tokens = append(s.tokens, ParsedToken{
-			Line:      ctx.GetStart().GetLine() - 1,
-			Column:    ctx.GetStart().GetColumn(),
-			Length:    len(ctx.GetText()),
-			TokenType: STTType,
+			Line:      strictKeyword.GetSymbol().GetLine() - 1,
+			Column:    strictKeyword.GetSymbol().GetColumn(),
+			Length:    len(strictKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+
+	// Add the boolean token
+	if booleanKeyword := ctx.BOOL(); booleanKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      booleanKeyword.GetSymbol().GetLine() - 1,
+			Column:    booleanKeyword.GetSymbol().GetColumn(),
+			Length:    len(booleanKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+}
+
+// EnterNullableMetadata is called when production nullableMetadata is entered.
+func (s *semanticTokenProviderImpl) EnterNullableMetadata(ctx *parser_cmsl.NullableMetadataContext) {
+	// Add the nullable keyword token
+	if nullableKeyword := ctx.NULLABLE_METAD_KW(); nullableKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      nullableKeyword.GetSymbol().GetLine() - 1,
+			Column:    nullableKeyword.GetSymbol().GetColumn(),
+			Length:    len(nullableKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+
+	// Add the boolean token
+	if booleanKeyword := ctx.BOOL(); booleanKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      booleanKeyword.GetSymbol().GetLine() - 1,
+			Column:    booleanKeyword.GetSymbol().GetColumn(),
+			Length:    len(booleanKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+}
+
+// EnterSeverityMetadata is called when production severityMetadata is entered.
+func (s *semanticTokenProviderImpl) EnterSeverityMetadata(ctx *parser_cmsl.SeverityMetadataContext) {
+	// Add the severity keyword token
+	if severityKeyword := ctx.SEVERITY_METAD_KW(); severityKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      severityKeyword.GetSymbol().GetLine() - 1,
+			Column:    severityKeyword.GetSymbol().GetColumn(),
+			Length:    len(severityKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+
+	// Add the severity token
+	if severity := ctx.IDENTIFIER(); severity != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      severity.GetSymbol().GetLine() - 1,
+			Column:    severity.GetSymbol().GetColumn(),
+			Length:    len(severity.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+}
+
+// EnterRemoveMetadata is called when production removeMetadata is entered.
+func (s *semanticTokenProviderImpl) EnterRemoveMetadata(ctx *parser_cmsl.RemoveMetadataContext) {
+	// Add the remove keyword token
+	if removeKeyword := ctx.REMOVE_METAD_KW(); removeKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      removeKeyword.GetSymbol().GetLine() - 1,
+			Column:    removeKeyword.GetSymbol().GetColumn(),
+			Length:    len(removeKeyword.GetText()),
+			TokenType: STTKeyword,
 		})
-	} else if ctx.LIST_TYPE_KW() != nil {
+	}
+
+	// Add the tokens of the names of the removed checks
+	for _, checkName := range ctx.AllIDENTIFIER() {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      checkName.GetSymbol().GetLine() - 1,
+			Column:    checkName.GetSymbol().GetColumn(),
+			Length:    len(checkName.GetText()),
+			TokenType: STTVariable,
+		})
+	}
+}
+
+// EnterNamedCheck is called when production namedCheck is entered.
+func (s *semanticTokenProviderImpl) EnterNamedCheck(ctx *parser_cmsl.NamedCheckContext) {
+	// Add the check name token, names are optional
+	if checkName := ctx.IDENTIFIER(); checkName != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      checkName.GetSymbol().GetLine() - 1,
+			Column:    checkName.GetSymbol().GetColumn(),
+			Length:    len(checkName.GetText()),
+			TokenType: STTVariable,
+		})
+	}
+}
+
+// EnterCheckSeverity is called when production checkSeverity is entered.
+func (s *semanticTokenProviderImpl) EnterCheckSeverity(ctx *parser_cmsl.CheckSeverityContext) {
+	// Add the severity keyword token
+	if severityKeyword := ctx.SEVERITY_METAD_KW(); severityKeyword != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      severityKeyword.GetSymbol().GetLine() - 1,
+			Column:    severityKeyword.GetSymbol().GetColumn(),
+			Length:    len(severityKeyword.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+
+	// Add the severity token
+	if severity := ctx.IDENTIFIER(); severity != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      severity.GetSymbol().GetLine() - 1,
+			Column:    severity.GetSymbol().GetColumn(),
+			Length:    len(severity.GetText()),
+			TokenType: STTKeyword,
+		})
+	}
+}
+
+// EnterTypeTerm is called when production typeTerm is entered.
+func (s *semanticTokenProviderImpl) EnterTypeTerm(ctx *parser_cmsl.TypeTermContext) {
+	// Check the kind of type, tagged unions also have an identifier for the tag
+	if ctx.LIST_TYPE_KW() != nil {
 		// Get the list type keyword
 		s.tokens = append(s.tokens, ParsedToken{
 			Line:      ctx.LIST_TYPE_KW().GetSymbol().GetLine() - 1,
@@ -304,9 +487,68 @@ func (s *semanticTokenProviderImpl) EnterTypeExpr(ctx *parser_cmsl.TypeExprConte
 			Length:    len(ctx.LIST_TYPE_KW().GetText()),
 			TokenType: STTType,
 		})
+	} else if ctx.MAP_TYPE_KW() != nil {
+		// Get the map type keyword
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      ctx.MAP_TYPE_KW().GetSymbol().GetLine() - 1,
+			Column:    ctx.MAP_TYPE_KW().GetSymbol().GetColumn(),
+			Length:    len(ctx.MAP_TYPE_KW().GetText()),
+			TokenType: STTType,
+		})
+	} else if ctx.ENUM_TYPE_KW() != nil {
+		// Get the enum type keyword, the values are added as primitives
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      ctx.ENUM_TYPE_KW().GetSymbol().GetLine() - 1,
+			Column:    ctx.ENUM_TYPE_KW().GetSymbol().GetColumn(),
+			Length:    len(ctx.ENUM_TYPE_KW().GetText()),
+			TokenType: STTType,
+		})
+	} else if ctx.TAGGED_TYPE_KW() != nil {
+		// Get the tagged union keyword
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      ctx.TAGGED_TYPE_KW().GetSymbol().GetLine() - 1,
+			Column:    ctx.TAGGED_TYPE_KW().GetSymbol().GetColumn(),
+			Length:    len(ctx.TAGGED_TYPE_KW().GetText()),
+			TokenType: STTType,
+		})
+
+		// Add the tag property token
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      ctx.IDENTIFIER().GetSymbol().GetLine() - 1,
+			Column:    ctx.IDENTIFIER().GetSymbol().GetColumn(),
+			Length:    len(ctx.IDENTIFIER().GetText()),
+			TokenType: STTProperty,
+		})
+	} else if ctx.IDENTIFIER() != nil {
+		// Add the type token of a primitive or custom type
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      ctx.GetStart().GetLine() - 1,
+			Column:    ctx.GetStart().GetColumn(),
+			Length:    len(ctx.GetText()),
+			TokenType: STTType,
+		})
 	}
 }
 
+// EnterTaggedVariant is called when production taggedVariant is entered.
+func (s *semanticTokenProviderImpl) EnterTaggedVariant(ctx *parser_cmsl.TaggedVariantContext) {
+	// Add the tag value token
+	s.tokens = append(s.tokens, ParsedToken{
+		Line:      ctx.SHORT_STRING().GetSymbol().GetLine() - 1,
+		Column:    ctx.SHORT_STRING().GetSymbol().GetColumn(),
+		Length:    len(ctx.SHORT_STRING().GetText()),
+		TokenType: STTString,
+	})
+
+	// Add the object type token
+	s.tokens = append(s.tokens, ParsedToken{
+		Line:      ctx.IDENTIFIER().GetSymbol().GetLine() - 1,
+		Column:    ctx.IDENTIFIER().GetSymbol().GetColumn(),
+		Length:    len(ctx.IDENTIFIER().GetText()),
+		TokenType: STTType,
+	})
+}
+
 // EnterString is called when production string is entered.
 func (s *semanticTokenProviderImpl) EnterString(ctx *parser_cmsl.StringContext) {
 	// Add the string token
@@ -388,6 +630,19 @@ func (s *semanticTokenProviderImpl) EnterAndExpr(ctx *parser_cmsl.AndExprContext
 	}
 }
 
+// EnterFieldExpression is called when production fieldExpression is entered.
+func (s *semanticTokenProviderImpl) EnterFieldExpression(ctx *parser_cmsl.FieldExpressionContext) {
+	// Add the null-coalescing operator token
+	if coalesceOperator := ctx.COALESCE_SYM(); coalesceOperator != nil {
+		s.tokens = append(s.tokens, ParsedToken{
+			Line:      coalesceOperator.GetSymbol().GetLine() - 1,
+			Column:    coalesceOperator.GetSymbol().GetColumn(),
+			Length:    len(coalesceOperator.GetText()),
+			TokenType: STTOperator,
+		})
+	}
+}
+
 // EnterIf is called when production if is entered.
 func (s *semanticTokenProviderImpl) EnterIf(ctx *parser_cmsl.IfContext) {
 	// Add the if keyword token
@@ -3,9 +3,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ConfigMate/configmate/analyzer"
 	"github.com/ConfigMate/configmate/analyzer/check"
+	"github.com/ConfigMate/configmate/analyzer/infer"
+	"github.com/ConfigMate/configmate/analyzer/schema"
 	"github.com/ConfigMate/configmate/analyzer/spec"
 	"github.com/ConfigMate/configmate/analyzer/types"
 	"github.com/ConfigMate/configmate/files"
@@ -24,6 +27,14 @@ var BuildDate = "Not Provided"
 // GitHash contains the Git commit hash from which the controller was built. (set via ldflags during build)
 var GitHash = "Not Provided"
 
+// Exit codes of the application
+const (
+	exitPassed        = 0 // All checks passed
+	exitChecksFailed  = 1 // More checks failed than allowed, or files are not formatted
+	exitSpecError     = 2 // The specification could not be analyzed
+	exitInternalError = 3 // Invalid arguments or any other error
+)
+
 func main() {
 	app := &cli.App{
 		Name:                 "configm",
@@ -61,8 +72,12 @@ func main() {
 				UsageText: "configm types",
 				Action: func(c *cli.Context) error {
 					fmt.Println("Supported Types:")
-					for _, t := range types.GetTypes() {
-						fmt.Printf("\t%s\n", t)
+					for _, t := range types.GetTypesInfo() {
+						methods := make([]string, 0, len(t.Methods))
+						for _, m := range t.Methods {
+							methods = append(methods, m.Name)
+						}
+						fmt.Printf("\t%s: %s\n", t.Name, strings.Join(methods, ", "))
 					}
 
 					return nil
@@ -82,14 +97,18 @@ func main() {
 					t := c.Args().Get(0)
 
 					// Get methods
-					methods := types.GetTypeInfo(t)
+					methods := types.GetMethods(t)
 					if methods == nil {
 						return fmt.Errorf("invalid type")
 					}
 
 					fmt.Printf("Supported Methods for %s:\n", t)
-					for m, desc := range methods {
-						fmt.Printf("\t%s: %s\n", m, desc)
+					for _, m := range methods {
+						fmt.Printf("\t%s\n", m.Signature())
+						fmt.Printf("\t\t%s\n", m.Description)
+						for _, example := range m.Examples {
+							fmt.Printf("\t\tExample: %s\n", example)
+						}
 					}
 
 					return nil
@@ -98,8 +117,17 @@ func main() {
 			{
 				Name:      "check",
 				Usage:     "Check a configuration file specification.",
-				UsageText: "configm check <path-to-specification>",
+				UsageText: "configm check [--config <file-glob-or-directory>] [--strict] [--min-severity <severity>] <path-to-specification>",
 				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "Config files to check (file, glob or directory), replaces the config declaration of the specification.",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Reports keys in the config files that are not declared in the specification.",
+					},
 					&cli.BoolFlag{
 						Name:    "skipped",
 						Aliases: []string{"s"},
@@ -110,6 +138,26 @@ func main() {
 						Aliases: []string{"a"},
 						Usage:   "Outputs the result for successful and skipped checks also.",
 					},
+					&cli.BoolFlag{
+						Name:  "fail-on-skipped",
+						Usage: "Count skipped checks as failed checks for the exit code.",
+					},
+					&cli.IntFlag{
+						Name:  "max-failures",
+						Usage: "Number of failed checks allowed before exiting with an error.",
+						Value: 0,
+					},
+					&cli.StringFlag{
+						Name:  "min-severity",
+						Usage: fmt.Sprintf("Least severe checks counted as failed for the exit code and the TAP and JUnit formats, one of: %s.", strings.Join(spec.Severities, ", ")),
+						Value: spec.SeverityError,
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   fmt.Sprintf("Output format, one of: %s.", strings.Join(utils.OutputFormats, ", ")),
+						Value:   "text",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					// Check number of arguments
@@ -117,6 +165,18 @@ func main() {
 						return fmt.Errorf("invalid number of arguments")
 					}
 
+					// Check output format
+					format := c.String("format")
+					if !isOutputFormat(format) {
+						return fmt.Errorf("invalid output format '%s', expected one of: %s", format, strings.Join(utils.OutputFormats, ", "))
+					}
+
+					// Check minimum severity
+					minSeverity := c.String("min-severity")
+					if spec.SeverityLevel(minSeverity) < 0 {
+						return fmt.Errorf("invalid minimum severity '%s', expected one of: %s", minSeverity, strings.Join(spec.Severities, ", "))
+					}
+
 					// Get the rulebook path from the arguments
 					specFilePath := c.Args().Get(0)
 
@@ -124,21 +184,34 @@ func main() {
 					a := analyzer.NewAnalyzer(
 						spec.NewSpecParser(),
 						check.NewCheckEvaluator(),
+						check.NewCheckTypeChecker(),
 						files.NewFileFetcher(),
 						parsers.NewParserProvider(),
 					)
 
 					// Get all files
-					files := a.AllFilesContent(specFilePath)
+					files := a.AllFilesContent(specFilePath, c.String("config"))
 
 					// Map the files contents to the corresponding line numbers
 					filesLines := utils.CreateLinesMapForFiles(files)
 
-					_, res, specError := a.AnalyzeSpecification(specFilePath, nil)
-					if specError != nil {
-						formattedResult := utils.FormatSpecError(*specError, filesLines)
+					_, res, specErrors := a.AnalyzeSpecification(specFilePath, nil, c.String("config"), c.Bool("strict"))
+
+					// Print machine readable output, which includes all results
+					if format != "text" {
+						output, err := utils.FormatResults(format, res, specErrors, minSeverity)
+						if err != nil {
+							return err
+						}
+						fmt.Print(output)
+						return checkExitError(res, specErrors, c.Bool("fail-on-skipped"), c.Int("max-failures"), minSeverity)
+					}
+
+					// Print specification errors, the checks that could
+					// still be evaluated are printed after them
+					for _, specError := range specErrors {
+						formattedResult := utils.FormatSpecError(specError, filesLines)
 						fmt.Print(formattedResult)
-						return nil
 					}
 
 					passedChecks := make([]analyzer.CheckResult, 0)
@@ -155,7 +228,7 @@ func main() {
 						}
 					}
 
-					if len(passedChecks)+len(skippedChecks) == len(res) {
+					if len(passedChecks)+len(skippedChecks) == len(res) && len(specErrors) == 0 {
 						fmt.Println("All checks passed!")
 					}
 
@@ -175,6 +248,213 @@ func main() {
 						}
 					}
 
+					// Print a summary when several config files were checked
+					if countCheckedFiles(res) > 1 {
+						fmt.Print(utils.FormatSummary(res))
+					}
+
+					return checkExitError(res, specErrors, c.Bool("fail-on-skipped"), c.Int("max-failures"), minSeverity)
+				},
+			},
+			{
+				Name:      "infer",
+				Usage:     "Generate a starter specification from existing configuration files.",
+				UsageText: "configm infer [--config-format <format>] [--output <file>] <config-file>...",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "config-format",
+						Usage: "Format of the config files, detected from each file if omitted.",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "File to write the specification to, instead of the standard output.",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					// Check number of arguments
+					if c.NArg() < 1 {
+						return fmt.Errorf("invalid number of arguments")
+					}
+
+					// Parse the sample config files
+					samples, format, err := parseSamples(c.Args().Slice(), c.String("config-format"))
+					if err != nil {
+						return err
+					}
+
+					// Infer the specification, declared for the first config file
+					inferred := spec.WriteSpecification(infer.Infer(c.Args().Get(0), format, samples))
+
+					// Write the specification
+					if output := c.String("output"); output != "" {
+						return os.WriteFile(output, []byte(inferred), 0644)
+					}
+					fmt.Print(inferred)
+
+					return nil
+				},
+			},
+			{
+				Name:  "schema",
+				Usage: "Convert between JSON Schema and specifications.",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "import",
+						Usage:     "Generate a specification from a JSON Schema.",
+						UsageText: "configm schema import [--config <file>] [--config-format <format>] [--output <file>] <schema-file>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "config",
+								Usage: "Path of the config file described by the specification.",
+								Value: "./config.json",
+							},
+							&cli.StringFlag{
+								Name:  "config-format",
+								Usage: "Format of the config file, detected from the file if omitted.",
+							},
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "File to write the specification to, instead of the standard output.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							// Check number of arguments
+							if c.NArg() != 1 {
+								return fmt.Errorf("invalid number of arguments")
+							}
+
+							// Parse the schema
+							schemaFile := c.Args().Get(0)
+							documents, _, err := parseSamples([]string{schemaFile}, "")
+							if err != nil {
+								return err
+							}
+							if len(documents) != 1 {
+								return fmt.Errorf("%s must contain a single schema", schemaFile)
+							}
+
+							// Convert the schema, reporting what could not be converted
+							s, issues := schema.Import(c.String("config"), documents[0])
+							s.FileFormat = c.String("config-format")
+							printSchemaIssues(schemaFile, issues)
+							imported := spec.WriteSpecification(s)
+
+							// Write the specification
+							if output := c.String("output"); output != "" {
+								return os.WriteFile(output, []byte(imported), 0644)
+							}
+							fmt.Print(imported)
+
+							return nil
+						},
+					},
+					{
+						Name:      "export",
+						Usage:     "Generate a JSON Schema from a specification.",
+						UsageText: "configm schema export [--output <file>] <spec-file>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "File to write the schema to, instead of the standard output.",
+							},
+						},
+						Action: func(c *cli.Context) error {
+							// Check number of arguments
+							if c.NArg() != 1 {
+								return fmt.Errorf("invalid number of arguments")
+							}
+
+							// Parse the specification
+							specFile := c.Args().Get(0)
+							specContent, err := files.NewFileFetcher().FetchFile(specFile)
+							if err != nil {
+								return err
+							}
+							s, specErrs := spec.NewSpecParser().Parse(specContent)
+							if len(specErrs) > 0 {
+								return fmt.Errorf("failed to parse %s at line %d: %s", specFile, specErrs[0].Location.Start.Line+1, specErrs[0].ErrorMessage)
+							}
+
+							// Convert the specification, reporting what could not be converted
+							exported, issues := schema.Export(s)
+							printSchemaIssues(specFile, issues)
+							if exported == nil {
+								return fmt.Errorf("failed to export %s", specFile)
+							}
+
+							// Write the schema
+							if output := c.String("output"); output != "" {
+								return os.WriteFile(output, exported, 0644)
+							}
+							fmt.Print(string(exported))
+
+							return nil
+						},
+					},
+				},
+			},
+			{
+				Name:      "fmt",
+				Usage:     "Format specification files.",
+				UsageText: "configm fmt [--check | --write] <spec-file>...",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "check",
+						Usage: "Print the files that are not formatted, without changing them.",
+					},
+					&cli.BoolFlag{
+						Name:    "write",
+						Aliases: []string{"w"},
+						Usage:   "Write the formatted specifications to their files, instead of the standard output.",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					// Check number of arguments
+					if c.NArg() < 1 {
+						return fmt.Errorf("invalid number of arguments")
+					}
+					if c.Bool("check") && c.Bool("write") {
+						return fmt.Errorf("--check and --write can't be used together")
+					}
+
+					unformatted := false
+					for _, specFile := range c.Args().Slice() {
+						content, err := files.NewFileFetcher().FetchFile(specFile)
+						if err != nil {
+							return err
+						}
+
+						// Specifications with syntax errors can't be formatted
+						formatted, specErrs := spec.FormatSpecification(content)
+						if len(specErrs) > 0 {
+							fmt.Printf("Error: failed to parse %s at line %d: %s\n", specFile, specErrs[0].Location.Start.Line+1, specErrs[0].ErrorMessage)
+							return cli.Exit("", exitSpecError)
+						}
+
+						switch {
+						case c.Bool("check"):
+							if string(formatted) != string(content) {
+								fmt.Println(specFile)
+								unformatted = true
+							}
+						case c.Bool("write"):
+							if string(formatted) != string(content) {
+								if err := os.WriteFile(specFile, formatted, 0644); err != nil {
+									return err
+								}
+							}
+						default:
+							fmt.Print(string(formatted))
+						}
+					}
+
+					if unformatted {
+						return cli.Exit("", exitChecksFailed)
+					}
+
 					return nil
 				},
 			},
@@ -209,9 +489,117 @@ func main() {
 	if err := app.Run(os.Args); err != nil {
 		// Print error message
 		fmt.Printf("Error: %s\n", err.Error())
-		os.Exit(1)
+		os.Exit(exitInternalError)
 	}
 
 	// Exit with success
-	os.Exit(0)
+	os.Exit(exitPassed)
+}
+
+// checkExitError returns an error exiting with the exit code for the results
+// of the check command, or nil if all checks passed. Only checks at least as
+// severe as minSeverity are counted, skipped checks count as failed when
+// failOnSkipped is set, and up to maxFailures failed checks are allowed.
+func checkExitError(res []analyzer.CheckResult, specErrors []analyzer.SpecError, failOnSkipped bool, maxFailures int, minSeverity string) error {
+	if len(specErrors) > 0 {
+		return cli.Exit("", exitSpecError)
+	}
+
+	failures := 0
+	for _, result := range res {
+		if spec.SeverityLevel(result.Severity) < spec.SeverityLevel(minSeverity) {
+			continue
+		}
+		if result.Status == analyzer.CheckFailed || (failOnSkipped && result.Status == analyzer.CheckSkipped) {
+			failures++
+		}
+	}
+	if failures > maxFailures {
+		return cli.Exit("", exitChecksFailed)
+	}
+
+	return nil
+}
+
+// parseSamples parses the config files with the given format, or the format
+// detected from each file if empty. All the documents of the files are returned,
+// with the format of the files, which is empty if they don't share one.
+func parseSamples(paths []string, format string) ([]*parsers.Node, string, error) {
+	fileFetcher := files.NewFileFetcher()
+	parserProvider := parsers.NewParserProvider()
+
+	samples := []*parsers.Node{}
+	samplesFormat := ""
+	for i, path := range paths {
+		content, err := fileFetcher.FetchFile(path)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Detect format of the file if it was not specified
+		fileFormat := format
+		if fileFormat == "" {
+			fileFormat, err = parserProvider.DetectFormat(path, content)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to detect format of %s: %s", path, err.Error())
+			}
+		}
+		if i == 0 {
+			samplesFormat = fileFormat
+		} else if fileFormat != samplesFormat {
+			samplesFormat = ""
+		}
+
+		parser, err := parserProvider.GetParser(fileFormat)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Parse all the documents in the file
+		var documents []*parsers.Node
+		var parserErrs []parsers.CMParserError
+		if multiDocumentParser, ok := parser.(parsers.MultiDocumentParser); ok {
+			documents, parserErrs = multiDocumentParser.ParseDocuments(content)
+		} else {
+			var document *parsers.Node
+			document, parserErrs = parsers.ParseFile(parser, path, content)
+			documents = []*parsers.Node{document}
+		}
+		if len(parserErrs) > 0 {
+			return nil, "", fmt.Errorf("failed to parse %s at line %d: %s", path, parserErrs[0].Location.Start.Line+1, parserErrs[0].Message)
+		}
+
+		samples = append(samples, documents...)
+	}
+
+	return samples, samplesFormat, nil
+}
+
+// printSchemaIssues prints the parts of a file that could not be converted
+// between JSON Schema and specifications to the standard error.
+func printSchemaIssues(file string, issues []schema.Issue) {
+	for _, issue := range issues {
+		fmt.Fprintf(os.Stderr, "%s:%d:%d: %s\n", file, issue.Location.Start.Line+1, issue.Location.Start.Column+1, issue.Message)
+	}
+}
+
+// countCheckedFiles returns the number of config files with results.
+func countCheckedFiles(res []analyzer.CheckResult) int {
+	files := make(map[string]bool)
+	for _, result := range res {
+		files[result.File] = true
+	}
+
+	return len(files)
+}
+
+// isOutputFormat returns whether format is a supported output format.
+func isOutputFormat(format string) bool {
+	for _, outputFormat := range utils.OutputFormats {
+		if format == outputFormat {
+			return true
+		}
+	}
+
+	return false
 }
@@ -3,6 +3,8 @@ package parsers
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -63,10 +65,27 @@ func (ft FieldType) String() string {
 	}
 }
 
+// NodeKey is the path to a node in a configuration file. Array elements are
+// addressed with index segments (e.g. [0]), and every element of an array,
+// or every value of an object, with the wildcard segment ([*]).
 type NodeKey struct {
 	Segments []string
 }
 
+// WildcardSegment is the segment that matches every element of an array,
+// or every value of an object.
+const WildcardSegment = "[*]"
+
+// IndexSegment returns the segment for the element at index of an array.
+func IndexSegment(index int) string {
+	return fmt.Sprintf("[%d]", index)
+}
+
+// isIndexSegment checks if a segment is an index or a wildcard segment.
+func isIndexSegment(segment string) bool {
+	return strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]")
+}
+
 func (nk *NodeKey) String() string {
 	if len(nk.Segments) == 0 {
 		return ""
@@ -74,6 +93,12 @@ func (nk *NodeKey) String() string {
 
 	result := ""
 	for _, segment := range nk.Segments {
+		// Index segments are appended to the previous segment
+		if isIndexSegment(segment) {
+			result += segment
+			continue
+		}
+
 		// Check if segment contains spaces or dots
 		if strings.ContainsAny(segment, " .") {
 			// Escape the segment with single quotes
@@ -81,10 +106,44 @@ func (nk *NodeKey) String() string {
 		}
 
 		// Append the segment to the result
-		result += fmt.Sprintf("%s.", segment)
+		if result != "" {
+			result += "."
+		}
+		result += segment
+	}
+
+	return result
+}
+
+// Matches checks if the key, which can have wildcard segments,
+// matches another key without them. Wildcards match any segment,
+// indexes of arrays and keys of objects, as in Expand.
+func (nk *NodeKey) Matches(key *NodeKey) bool {
+	if len(nk.Segments) != len(key.Segments) {
+		return false
+	}
+
+	for i, segment := range nk.Segments {
+		if segment == WildcardSegment {
+			continue
+		}
+		if segment != key.Segments[i] {
+			return false
+		}
 	}
 
-	return result[:len(result)-1]
+	return true
+}
+
+// HasWildcard checks if the key has a wildcard segment.
+func (nk *NodeKey) HasWildcard() bool {
+	for _, segment := range nk.Segments {
+		if segment == WildcardSegment {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (nk *NodeKey) Join(otherKey *NodeKey) *NodeKey {
@@ -105,6 +164,11 @@ func (n *Node) Get(key *NodeKey) (*Node, error) {
 
 		switch currentNode.Type {
 		case Object:
+			// Objects can't be indexed
+			if isIndexSegment(segment) {
+				return nil, fmt.Errorf("cannot index object node with %s in path %s", segment, key.String())
+			}
+
 			// Cast value as map[string]*Node (unsafe)
 			objMap := currentNode.Value.(map[string]*Node)
 
@@ -116,8 +180,27 @@ func (n *Node) Get(key *NodeKey) (*Node, error) {
 			}
 
 		case Array:
-			// We're trying to traverse an array node
-			return nil, fmt.Errorf("cannot traverse array node %s in path %s", segment, key.String())
+			// Arrays can only be traversed with indexes
+			if segment == WildcardSegment {
+				return nil, fmt.Errorf("cannot get wildcard path %s, it must be expanded first", key.String())
+			} else if !isIndexSegment(segment) {
+				return nil, fmt.Errorf("cannot traverse array node %s in path %s", segment, key.String())
+			}
+
+			index, err := strconv.Atoi(segment[1 : len(segment)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %s in path %s", segment, key.String())
+			}
+
+			// Cast value as []*Node (unsafe)
+			elements := currentNode.Value.([]*Node)
+
+			// Check if the index exists in the array
+			if index >= 0 && index < len(elements) {
+				currentNode = elements[index]
+			} else {
+				return nil, nil
+			}
 
 		default:
 			// We're trying to traverse a leaf node
@@ -127,3 +210,93 @@ func (n *Node) Get(key *NodeKey) (*Node, error) {
 
 	return currentNode, nil
 }
+
+// Expand replaces the wildcards in a key with the index of each element of
+// the corresponding arrays, or with the name of each value of the
+// corresponding objects (like maps), returning a key for every element. If
+// the path to a wildcard doesn't exist or is null, no keys are returned.
+func (n *Node) Expand(key *NodeKey) ([]*NodeKey, error) {
+	for i, segment := range key.Segments {
+		if segment != WildcardSegment {
+			continue
+		}
+
+		// Get the array or object matched by the wildcard
+		wildcardNode, err := n.Get(&NodeKey{Segments: key.Segments[:i]})
+		if err != nil {
+			return nil, err
+		} else if wildcardNode == nil || wildcardNode.Type == Null {
+			return []*NodeKey{}, nil
+		}
+
+		elementSegments := []string{}
+		switch wildcardNode.Type {
+		case Array:
+			for index := range wildcardNode.Value.([]*Node) {
+				elementSegments = append(elementSegments, IndexSegment(index))
+			}
+		case Object:
+			elementSegments = wildcardNode.Keys()
+		default:
+			return nil, fmt.Errorf("cannot expand wildcard on %s node in path %s", wildcardNode.Type, key.String())
+		}
+
+		// Expand the rest of the key for each element
+		keys := []*NodeKey{}
+		for _, elementSegment := range elementSegments {
+			segments := append([]string{}, key.Segments[:i]...)
+			segments = append(segments, elementSegment)
+			segments = append(segments, key.Segments[i+1:]...)
+
+			elementKeys, err := n.Expand(&NodeKey{Segments: segments})
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, elementKeys...)
+		}
+
+		return keys, nil
+	}
+
+	// Keys without wildcards are already expanded
+	return []*NodeKey{key}, nil
+}
+
+// Keys returns the keys of an object node in the order they are in the
+// file, given by the location of their names, or of their values if the
+// format doesn't locate names. Keys without location are sorted by name.
+func (n *Node) Keys() []string {
+	values, ok := n.Value.(map[string]*Node)
+	if !ok {
+		return []string{}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		location, otherLocation := values[keys[i]].location(), values[keys[j]].location()
+		if location.Start.Line != otherLocation.Start.Line {
+			return location.Start.Line < otherLocation.Start.Line
+		}
+		if location.Start.Column != otherLocation.Start.Column {
+			return location.Start.Column < otherLocation.Start.Column
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// location returns the location of the name of the node, or of its value
+// if the name has no location.
+func (n *Node) location() TokenLocation {
+	if n == nil {
+		return TokenLocation{}
+	}
+	if n.NameLocation != (TokenLocation{}) {
+		return n.NameLocation
+	}
+	return n.ValueLocation
+}
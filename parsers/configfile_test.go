@@ -69,6 +69,30 @@ func TestNode_Get(t *testing.T) {
 			expected:    nil,
 			expectedErr: fmt.Errorf("cannot traverse leaf node in path server.'port. test'.something"),
 		},
+		{
+			configFile:  serversConfigFile(),
+			key:         &NodeKey{Segments: []string{"servers", "[1]", "port"}},
+			expected:    &Node{Type: Int, Value: 443},
+			expectedErr: nil,
+		},
+		{
+			configFile:  serversConfigFile(),
+			key:         &NodeKey{Segments: []string{"servers", "[2]", "port"}},
+			expected:    nil,
+			expectedErr: nil,
+		},
+		{
+			configFile:  serversConfigFile(),
+			key:         &NodeKey{Segments: []string{"servers", "[*]", "port"}},
+			expected:    nil,
+			expectedErr: fmt.Errorf("cannot get wildcard path servers[*].port, it must be expanded first"),
+		},
+		{
+			configFile:  serversConfigFile(),
+			key:         &NodeKey{Segments: []string{"servers", "[0]", "[0]"}},
+			expected:    nil,
+			expectedErr: fmt.Errorf("cannot index object node with [0] in path servers[0][0]"),
+		},
 	}
 
 	// Run tests
@@ -83,3 +107,175 @@ func TestNode_Get(t *testing.T) {
 		}
 	}
 }
+
+// TestNode_Expand tests the Expand function of a *Node.
+func TestNode_Expand(t *testing.T) {
+	// Test cases
+	type testCase struct {
+		configFile  *Node
+		key         *NodeKey
+		expected    []string
+		expectedErr bool
+	}
+
+	testCases := []testCase{
+		{
+			configFile: serversConfigFile(),
+			key:        &NodeKey{Segments: []string{"servers", "[*]", "port"}},
+			expected:   []string{"servers[0].port", "servers[1].port"},
+		},
+		{
+			configFile: serversConfigFile(),
+			key:        &NodeKey{Segments: []string{"servers", "[*]", "hosts", "[*]"}},
+			expected:   []string{"servers[0].hosts[0]", "servers[0].hosts[1]"},
+		},
+		{
+			configFile: serversConfigFile(),
+			key:        &NodeKey{Segments: []string{"clients", "[*]", "port"}},
+			expected:   []string{},
+		},
+		{
+			configFile: serversConfigFile(),
+			key:        &NodeKey{Segments: []string{"servers", "[0]", "port"}},
+			expected:   []string{"servers[0].port"},
+		},
+		{
+			configFile:  serversConfigFile(),
+			key:         &NodeKey{Segments: []string{"servers", "[*]", "port", "[*]"}},
+			expectedErr: true,
+		},
+		{
+			configFile: serversConfigFile(),
+			key:        &NodeKey{Segments: []string{"limits", "[*]", "max"}},
+			expected:   []string{"limits.api.max", "limits.web.max"},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		keys, err := test.configFile.Expand(test.key)
+		if err != nil && !test.expectedErr {
+			t.Errorf("Expand(%s) returned error %s, expected no error", test.key.String(), err.Error())
+		} else if err == nil && test.expectedErr {
+			t.Errorf("Expand(%s) returned no error, expected error", test.key.String())
+		} else if err == nil {
+			actual := []string{}
+			for _, key := range keys {
+				actual = append(actual, key.String())
+			}
+
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Expand(%s) returned %v, expected %v", test.key.String(), actual, test.expected)
+			}
+		}
+	}
+}
+
+// serversConfigFile returns a config file with an array of servers
+// and an object of limits.
+func serversConfigFile() *Node {
+	return &Node{
+		Type: Object,
+		Value: map[string]*Node{
+			"servers": {
+				Type: Array,
+				Value: []*Node{
+					{
+						Type: Object,
+						Value: map[string]*Node{
+							"port": {Type: Int, Value: 80},
+							"hosts": {
+								Type: Array,
+								Value: []*Node{
+									{Type: String, Value: "a.example.com"},
+									{Type: String, Value: "b.example.com"},
+								},
+							},
+						},
+					},
+					{
+						Type: Object,
+						Value: map[string]*Node{
+							"port": {Type: Int, Value: 443},
+						},
+					},
+				},
+			},
+			"limits": {
+				Type: Object,
+				Value: map[string]*Node{
+					"api": {Type: Object, Value: map[string]*Node{"max": {Type: Int, Value: 5}}},
+					"web": {Type: Object, Value: map[string]*Node{"max": {Type: Int, Value: 10}}},
+				},
+			},
+		},
+	}
+}
+
+// TestNodeKey_Matches tests the Matches function of a *NodeKey.
+func TestNodeKey_Matches(t *testing.T) {
+	// Test cases
+	type testCase struct {
+		pattern  *NodeKey
+		key      *NodeKey
+		expected bool
+	}
+
+	testCases := []testCase{
+		{
+			pattern:  &NodeKey{Segments: []string{"server", "port"}},
+			key:      &NodeKey{Segments: []string{"server", "port"}},
+			expected: true,
+		},
+		{
+			pattern:  &NodeKey{Segments: []string{"servers", "[*]", "port"}},
+			key:      &NodeKey{Segments: []string{"servers", "[3]", "port"}},
+			expected: true,
+		},
+		{
+			pattern:  &NodeKey{Segments: []string{"servers", "[0]", "port"}},
+			key:      &NodeKey{Segments: []string{"servers", "[3]", "port"}},
+			expected: false,
+		},
+		{
+			pattern:  &NodeKey{Segments: []string{"servers", "[*]"}},
+			key:      &NodeKey{Segments: []string{"servers", "port"}},
+			expected: true,
+		},
+		{
+			pattern:  &NodeKey{Segments: []string{"server"}},
+			key:      &NodeKey{Segments: []string{"server", "port"}},
+			expected: false,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		if actual := test.pattern.Matches(test.key); actual != test.expected {
+			t.Errorf("%s.Matches(%s) returned %t, expected %t", test.pattern.String(), test.key.String(), actual, test.expected)
+		}
+	}
+}
+
+// TestNode_Keys tests the Keys function of a *Node, which sorts keys by location.
+func TestNode_Keys(t *testing.T) {
+	onLine := func(line int) TokenLocation {
+		return TokenLocation{Start: CharLocation{Line: line}, End: CharLocation{Line: line, Column: 1}}
+	}
+
+	node := &Node{
+		Type: Object,
+		Value: map[string]*Node{
+			"port":     {Type: Int, Value: 80, NameLocation: onLine(2)},
+			"host":     {Type: String, Value: "localhost", NameLocation: onLine(1)},
+			"timeout":  {Type: Int, Value: 30, ValueLocation: onLine(3)},
+			"retries":  {Type: Int, Value: 3},
+			"attempts": {Type: Int, Value: 3},
+		},
+	}
+
+	expected := []string{"attempts", "retries", "host", "port", "timeout"}
+	if keys := node.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Keys() returned %v, expected %v", keys, expected)
+	}
+}
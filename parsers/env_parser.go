@@ -0,0 +1,184 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// envParser parses dotenv files. Keys are kept as they are, since
+// variable names are not hierarchical.
+type envParser struct {
+	configFile *Node
+	errs       []CMParserError
+}
+
+// Custom env parser
+func (p *envParser) Parse(data []byte) (*Node, []CMParserError) {
+	// Initialize config file to an object
+	p.configFile = &Node{
+		Type:  Object,
+		Value: map[string]*Node{},
+	}
+	p.errs = nil
+
+	physicalLines := splitPhysicalLines(data)
+	for index := 0; index < len(physicalLines); index++ {
+		line := &kvLine{}
+		line.appendLine(physicalLines[index], index, 0)
+
+		start := line.skipSpaces(0)
+
+		// Skip empty lines and comments
+		if start == len(line.text) || line.text[start] == '#' {
+			continue
+		}
+
+		// Quoted values can span multiple lines
+		index = p.parseKeyValue(line, start, physicalLines, index)
+	}
+
+	// Check for errors
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	return p.configFile, nil
+}
+
+// parseKeyValue parses a variable definition (e.g. export PORT=8080).
+// Returns the index of the last physical line used.
+func (p *envParser) parseKeyValue(line *kvLine, start int, physicalLines [][]rune, index int) int {
+	// Skip export prefix
+	if len(line.text) > start+7 && string(line.text[start:start+7]) == "export " {
+		start = line.skipSpaces(start + 7)
+	}
+
+	// Find separator
+	separator := start
+	for separator < len(line.text) && line.text[separator] != '=' {
+		separator++
+	}
+	if separator == len(line.text) {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "missing '=' in variable definition",
+			Location: line.span(start, len(line.text)),
+		})
+		return index
+	}
+
+	// Parse key
+	keyEnd := line.trimSpacesRight(start, separator)
+	key := string(line.text[start:keyEnd])
+	nameLocation := line.span(start, keyEnd)
+	if !envKeyRegex.MatchString(key) {
+		p.errs = append(p.errs, CMParserError{
+			Message:  fmt.Sprintf("invalid variable name: '%s'", key),
+			Location: nameLocation,
+		})
+		return index
+	}
+
+	// Parse value
+	valueStart := line.skipSpaces(separator + 1)
+	var node *Node
+	if valueStart < len(line.text) && (line.text[valueStart] == '"' || line.text[valueStart] == '\'') {
+		var err *CMParserError
+		node, index, err = p.parseQuotedValue(line, valueStart, physicalLines, index)
+		if err != nil {
+			p.errs = append(p.errs, *err)
+			return index
+		}
+	} else {
+		node = p.parseUnquotedValue(line, valueStart)
+	}
+	node.NameLocation = nameLocation
+
+	// Add variable to config file
+	setFlatField(p.configFile, NodeKey{Segments: []string{key}}, node)
+
+	return index
+}
+
+// parseQuotedValue parses a quoted value, which may continue on the next
+// physical lines. Escapes are only processed in double quoted values.
+func (p *envParser) parseQuotedValue(line *kvLine, start int, physicalLines [][]rune, index int) (*Node, int, *CMParserError) {
+	quote := line.text[start]
+	value := []rune{}
+	end := start + 1
+	for {
+		if end == len(line.text) {
+			// Closing quote not found, continue on next line
+			if index == len(physicalLines)-1 {
+				return nil, index, &CMParserError{
+					Message:  "missing closing quote in value",
+					Location: line.span(start, end),
+				}
+			}
+
+			index++
+			line.appendNewline()
+			line.appendLine(physicalLines[index], index, 0)
+			continue
+		}
+
+		c := line.text[end]
+		if c == quote {
+			break
+		}
+
+		if c == '\\' && quote == '"' && end+1 < len(line.text) {
+			end++
+			value = append(value, p.unescapeChar(line.text[end]))
+			end++
+			continue
+		}
+
+		value = append(value, c)
+		end++
+	}
+
+	// Only comments can follow quoted values
+	rest := line.skipSpaces(end + 1)
+	if rest < len(line.text) && line.text[rest] != '#' {
+		return nil, index, &CMParserError{
+			Message:  "unexpected characters after quoted value",
+			Location: line.span(rest, len(line.text)),
+		}
+	}
+
+	return &Node{Type: String, Value: string(value), ValueLocation: line.span(start, end+1)}, index, nil
+}
+
+// parseUnquotedValue parses an unquoted value, which ends at the first
+// comment preceded by whitespace.
+func (p *envParser) parseUnquotedValue(line *kvLine, start int) *Node {
+	end := start
+	for end < len(line.text) {
+		if line.text[end] == '#' && end > 0 && (line.text[end-1] == ' ' || line.text[end-1] == '\t') {
+			break
+		}
+		end++
+	}
+	end = line.trimSpacesRight(start, end)
+
+	node := inferScalar(string(line.text[start:end]))
+	node.ValueLocation = line.span(start, end)
+	return node
+}
+
+// unescapeChar returns the character represented by an escape sequence
+// in a double quoted value. Other characters are taken literally.
+func (p *envParser) unescapeChar(c rune) rune {
+	switch c {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	default: // \", \\ and \$
+		return c
+	}
+}
@@ -0,0 +1,280 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+type envParserTestCase struct {
+	input        []byte
+	expected     *Node
+	expectedErrs []CMParserError
+}
+
+func TestParseSimpleConfig_envParser(t *testing.T) {
+	// Input
+	testConfig, err := os.ReadFile("./test_configs/simple.env")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// Test cases
+	testCases := []envParserTestCase{
+		{
+			input: testConfig,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"DB_HOST": {
+						Type:          String,
+						Value:         "192.168.1.100",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 7}, End: CharLocation{Line: 3, Column: 14}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 15}, End: CharLocation{Line: 3, Column: 28}},
+					},
+					"DB_PORT": {
+						Type:          Int,
+						Value:         5432,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 0}, End: CharLocation{Line: 4, Column: 7}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 8}, End: CharLocation{Line: 4, Column: 12}},
+					},
+					"DB_TIMEOUT": {
+						Type:          Float,
+						Value:         2.5,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 5, Column: 0}, End: CharLocation{Line: 5, Column: 10}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 5, Column: 11}, End: CharLocation{Line: 5, Column: 14}},
+					},
+					"DEBUG": {
+						Type:          Bool,
+						Value:         false,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 0}, End: CharLocation{Line: 6, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 6, Column: 6}, End: CharLocation{Line: 6, Column: 11}},
+					},
+					"GREETING": {
+						Type:          String,
+						Value:         "Hello, $USER",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 7, Column: 0}, End: CharLocation{Line: 7, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 7, Column: 9}, End: CharLocation{Line: 7, Column: 23}},
+					},
+					"TITLE": {
+						Type:          String,
+						Value:         "Env Sample Configuration",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 6}, End: CharLocation{Line: 1, Column: 32}},
+					},
+				},
+			},
+			expectedErrs: []CMParserError{},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &envParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v", test.expected, result)
+		}
+	}
+}
+
+func TestShortSamples_envParser(t *testing.T) {
+	// Input
+	var shortEnvConfig0 = []byte(`
+	export HOST=localhost
+	PORT = 8080 # comment
+	EMPTY=
+	URL=http://example.com/#anchor
+	`)
+
+	var shortEnvConfig1 = []byte(`
+	MULTI="line1
+	line2"
+	ESCAPED="a\"b\n"
+	LITERAL='a\nb' # comment
+	`)
+
+	var shortEnvConfig2 = []byte(`
+	HOST=a
+	HOST=b
+	`)
+
+	testCases := []envParserTestCase{
+		{ // Export prefix, spacing, empty values and comments
+			input: shortEnvConfig0,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"EMPTY": {
+						Type:          String,
+						Value:         "",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 6}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 7}, End: CharLocation{Line: 3, Column: 7}},
+					},
+					"HOST": {
+						Type:          String,
+						Value:         "localhost",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 8}, End: CharLocation{Line: 1, Column: 12}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 13}, End: CharLocation{Line: 1, Column: 22}},
+					},
+					"PORT": {
+						Type:          Int,
+						Value:         8080,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 8}, End: CharLocation{Line: 2, Column: 12}},
+					},
+					"URL": {
+						Type:          String,
+						Value:         "http://example.com/#anchor",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 4}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 5}, End: CharLocation{Line: 4, Column: 31}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Quoted and multiline values
+			input: shortEnvConfig1,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"ESCAPED": {
+						Type:          String,
+						Value:         "a\"b\n",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 9}, End: CharLocation{Line: 3, Column: 17}},
+					},
+					"LITERAL": {
+						Type:          String,
+						Value:         "a\\nb",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 9}, End: CharLocation{Line: 4, Column: 15}},
+					},
+					"MULTI": {
+						Type:          String,
+						Value:         "line1\n\tline2",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 6}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 7}, End: CharLocation{Line: 2, Column: 7}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Duplicate variables, where the last one wins
+			input: shortEnvConfig2,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"HOST": {
+						Type:          String,
+						Value:         "b",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 6}, End: CharLocation{Line: 2, Column: 7}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &envParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestErrorConditions_envParser(t *testing.T) {
+	// Input
+	var errEnvConfig0 = []byte(`
+	HOST
+	`)
+
+	var errEnvConfig1 = []byte(`
+	1HOST=localhost
+	`)
+
+	var errEnvConfig2 = []byte(`
+	HOST="localhost
+	`)
+
+	var errEnvConfig3 = []byte(`
+	HOST="local" host
+	`)
+
+	testCases := []envParserTestCase{
+		{
+			input:    errEnvConfig0,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "missing '=' in variable definition",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			input:    errEnvConfig1,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "invalid variable name: '1HOST'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			input:    errEnvConfig2,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "missing closing quote in value",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 6},
+						End:   CharLocation{Line: 2, Column: 1},
+					},
+				},
+			},
+		},
+		{
+			input:    errEnvConfig3,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "unexpected characters after quoted value",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 14},
+						End:   CharLocation{Line: 1, Column: 18},
+					},
+				},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &envParser{}
+		_, errs := parser.Parse(test.input)
+
+		if len(errs) == 0 {
+			t.Errorf("Expected errors, got none, Input: %s", test.input)
+		} else if !reflect.DeepEqual(test.expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v, Input: %s", test.expectedErrs, errs, test.input)
+		}
+	}
+}
@@ -36,7 +36,12 @@ not: NOT_SYM atom;
 
 functionExpression: function (DOT function)*;
 
-fieldExpression: fieldName (DOT functionExpression)?;
+// A field expression applies functions to the value of a field. With a
+// null-coalescing default, the default is used when the field is null or missing.
+fieldExpression
+    : fieldName (DOT functionExpression)?
+    | LPAREN fieldName COALESCE_SYM primitive RPAREN (DOT functionExpression)?
+    ;
 
 function
     : IDENTIFIER LPAREN argument (COMMA argument)* RPAREN
@@ -52,20 +57,26 @@ primitive
     | BOOL # boolean
     ;
 
-fieldName: simpleName | dottedName;
+fieldName: simpleName fieldIndex* | dottedName;
+
+simpleName: LITERAL_STRING | IDENTIFIER | ATTRIBUTE_NAME;
 
-simpleName: LITERAL_STRING | IDENTIFIER;
+dottedName: simpleName fieldIndex* (DOT simpleName fieldIndex*)+;
 
-dottedName: simpleName (DOT simpleName)+;
+// An index of an array element, or a wildcard for all elements.
+fieldIndex: LBRACK (INT | STAR) RBRACK;
 
 // Common Tokens
 LPAREN : '(' ;            // Left parenthesis
 RPAREN : ')' ;            // Right parenthesis
 LBRACE : '{' ;            // Left curly brace
 RBRACE : '}' ;            // Right curly brace
+LBRACK : '[' ;            // Left square bracket
+RBRACK : ']' ;            // Right square bracket
 COMMA : ',' ;             // Comma
 COLON : ':' ;             // Colon
 DOT : '.' ;               // Dot
+STAR : '*' ;              // Star, used as wildcard
 
 SHORT_STRING: '"'  ('\\' (RN | .) | ~[\\\r\n"])* '"';
 LITERAL_STRING : '\'' (~['\n])*? '\'' ;
@@ -80,9 +91,11 @@ ELSE_SYM: 'else';
 FOREACH_SYM: 'foreach';
 AND_SYM: '&&';
 OR_SYM: '||';
+COALESCE_SYM: '??';
 NOT_SYM: '!';
 
 IDENTIFIER : (CHARACTER)+ ;    // Typical definition of an identifier
+ATTRIBUTE_NAME : '@' (CHARACTER)+ ;    // Attribute of an element (e.g. XML attributes)
 
 WS: [ \t\r\n]+ -> skip;
 
@@ -6,12 +6,18 @@ import CMCL;
 // The top-level rule of the grammar.
 cmsl: specification EOF;
 
-// A CMSL specification contains a file declaration, a list of imports,
-// a specification body, and an optional list of custom object types.
-specification: configDeclaration importStatement? specificationBody objectDefinitions?;
+// A CMSL specification contains a file declaration, the specification it extends,
+// a list of imports, a specification body, an optional list of custom object types,
+// and an optional list of named predicates.
+specification: configDeclaration extendsStatement? importStatement? specificationBody objectDefinitions? predicateDefinitions?;
 
-// A file declaration contains the path and format of the file.
-configDeclaration: CONFIG_DCLR_KW COLON SHORT_STRING IDENTIFIER;
+// A file declaration contains the path and format of the file. If the
+// format is omitted, it is detected from the file.
+configDeclaration: CONFIG_DCLR_KW COLON SHORT_STRING IDENTIFIER?;
+
+// An extends statement contains the path of the specification whose fields,
+// checks and custom object types are inherited.
+extendsStatement: EXTENDS_KW SHORT_STRING;
 
 // An import contains the name of the file to import.
 importStatement: IMPORT_KW LPAREN importItem (COMMA importItem)* RPAREN;
@@ -19,17 +25,32 @@ importStatement: IMPORT_KW LPAREN importItem (COMMA importItem)* RPAREN;
 // An import item contains the name of the file to import.
 importItem: IDENTIFIER COLON SHORT_STRING;
 
-// A specification body contains a list of declarations.
-specificationBody: SPEC_ROOT_KW LBRACE specificationItem* RBRACE;
+// A specification body contains a list of declarations. In a strict
+// specification, keys in the file that are not declared are reported.
+specificationBody: SPEC_ROOT_KW STRICT_METAD_KW? LBRACE specificationItem* RBRACE;
 
 // A collection of custom object types.
 objectDefinitions: OBJ_DEF_KW LBRACE objectDefinition* RBRACE;
 
+// A collection of named predicates, checks with parameters that can be
+// called from any check like a method of the value they are applied to.
+predicateDefinitions: CHECKS_DEF_KW LBRACE predicateDefinition* RBRACE;
+
+// A definition of a predicate contains its name, its parameters and its check.
+predicateDefinition: IDENTIFIER LPAREN (IDENTIFIER (COMMA IDENTIFIER)*)? RPAREN EQUALS check SEMICOLON;
+
 // A specification item starts with the field name, followed by the
 // metadata inside angled brackets, optionally followed by a list of semicolon separated
 // checks (CMCL expressions), and optionally followed with the specification of underlying
 // fields insided curly braces.
-specificationItem: fieldName (longMetadataExpression | shortMetadataExpression) ( LPAREN (check SEMICOLON)+ RPAREN )? (LBRACE specificationItem* RBRACE)?;
+specificationItem: fieldName (longMetadataExpression | shortMetadataExpression) ( LPAREN (namedCheck SEMICOLON)+ RPAREN )? (LBRACE specificationItem* RBRACE)?;
+
+// A check can be named, so that specifications extending this one can replace or remove it,
+// and can be followed by its severity, which overrides the severity of the field.
+namedCheck: (IDENTIFIER COLON)? check checkSeverity?;
+
+// The severity of a check inside angled brackets, like <severity: warning>.
+checkSeverity: LANGLE SEVERITY_METAD_KW COLON IDENTIFIER RANGLE;
 
 // A long metadata expression is a list of metadata items inside angled brackets.
 longMetadataExpression : LANGLE metadataItem (COMMA metadataItem)* RANGLE ; 
@@ -43,12 +64,31 @@ metadataItem
     | NOTES_METAD_KW COLON stringExpr  # notesMetadata
     | DEFAULT_METAD_KW COLON primitive # defaultMetadata
     | OPTIONAL_METAD_KW COLON BOOL # optionalMetadata
+    | STRICT_METAD_KW COLON BOOL # strictMetadata
+    | NULLABLE_METAD_KW COLON BOOL # nullableMetadata
+    | SEVERITY_METAD_KW COLON IDENTIFIER # severityMetadata
+    | REMOVE_METAD_KW COLON LBRACK IDENTIFIER (COMMA IDENTIFIER)* RBRACK # removeMetadata
     ;
 
-// A type expression denotes the type.
+// A type expression denotes the type. It can be a union of several
+// types separated by pipes, where the value must match one of them.
 typeExpr
+    : typeTerm (PIPE typeTerm)*
+    ;
+
+// A type term is a type name, a list, a map with dynamic keys, an enum of the values
+// allowed, or a tagged union, where the object type is picked by the value of a tag property.
+typeTerm
     : IDENTIFIER
     | LIST_TYPE_KW LANGLE typeExpr RANGLE
+    | MAP_TYPE_KW LANGLE typeExpr COMMA typeExpr RANGLE
+    | ENUM_TYPE_KW LPAREN primitive (COMMA primitive)* RPAREN
+    | TAGGED_TYPE_KW LPAREN IDENTIFIER (COMMA taggedVariant)+ RPAREN
+    ;
+
+// A variant of a tagged union maps a value of the tag to an object type.
+taggedVariant
+    : SHORT_STRING COLON IDENTIFIER
     ;
 
 // A definition of a custom object type.
@@ -56,9 +96,10 @@ objectDefinition
     : IDENTIFIER LBRACE objectPropertyDefinition* RBRACE
     ;
 
-// A definition of a property of a custom object type.
+// A definition of a property of a custom object type. Properties have the same metadata
+// and checks as specification items, and the checks run wherever the object type is used.
 objectPropertyDefinition
-    : simpleName shortMetadataExpression
+    : simpleName (longMetadataExpression | shortMetadataExpression) ( LPAREN (namedCheck SEMICOLON)+ RPAREN )?
     ;
 
 // A primitive is a string, an integer, a float, or a boolean.
@@ -69,11 +110,19 @@ primitive
     | BOOL # boolean
     ;
 
-fieldName: simpleName | dottedName;
+fieldName: simpleName fieldIndex* | dottedName;
+
+// Some keywords can also be names, since they never appear where a name can.
+simpleName
+    : LITERAL_STRING | IDENTIFIER | ATTRIBUTE_NAME
+    | EXTENDS_KW | CHECKS_DEF_KW | NULLABLE_METAD_KW | SEVERITY_METAD_KW | REMOVE_METAD_KW | STRICT_METAD_KW
+    | MAP_TYPE_KW | ENUM_TYPE_KW | TAGGED_TYPE_KW
+    ;
 
-simpleName: LITERAL_STRING | IDENTIFIER;
+dottedName: simpleName fieldIndex* (DOT simpleName fieldIndex*)+;
 
-dottedName: simpleName (DOT simpleName)+;
+// An index of an array element, or a wildcard for all elements.
+fieldIndex: LBRACK (INT | STAR) RBRACK;
 
 // A string expression is either a short string or a long string.
 stringExpr
@@ -84,25 +133,39 @@ stringExpr
 // Keywords
 CONFIG_DCLR_KW : 'config' ;     // Config declaration keyword
 IMPORT_KW : 'import' ;     // Import keyword
+EXTENDS_KW : 'extends' ;   // Extends keyword
 SPEC_ROOT_KW : 'spec' ;     // Specification keyword
 OBJ_DEF_KW : 'objects' ;     // Object definition keyword
+CHECKS_DEF_KW : 'checks' ;   // Predicate definition keyword
 TYPE_METAD_KW : 'type' ;         // Type keyword
 OPTIONAL_METAD_KW : 'optional' ; // Optional keyword
 DEFAULT_METAD_KW : 'default' ;   // Default keyword
 NOTES_METAD_KW : 'notes' ;       // Notes keyword
+STRICT_METAD_KW : 'strict' ;     // Strict keyword
+NULLABLE_METAD_KW : 'nullable' ; // Nullable keyword
+SEVERITY_METAD_KW : 'severity' ; // Severity keyword
+REMOVE_METAD_KW : 'remove' ;     // Remove keyword
 LIST_TYPE_KW : 'list' ;         // List keyword
+MAP_TYPE_KW : 'map' ;           // Map keyword
+ENUM_TYPE_KW : 'enum' ;         // Enum keyword
+TAGGED_TYPE_KW : 'tagged' ;     // Tagged union keyword
 
 // Common Tokens
 LPAREN : '(' ;            // Left parenthesis
 RPAREN : ')' ;            // Right parenthesis
 LBRACE : '{' ;            // Left curly brace
 RBRACE : '}' ;            // Right curly brace
+LBRACK : '[' ;            // Left square bracket
+RBRACK : ']' ;            // Right square bracket
 LANGLE : '<' ;            // Less than symbol, used as left angle bracket
 RANGLE : '>' ;            // Greater than symbol, used as right angle bracket
 SEMICOLON : ';' ;         // Semicolon
 COMMA : ',' ;             // Comma
 COLON : ':' ;             // Colon
 DOT : '.' ;               // Dot
+STAR : '*' ;              // Star, used as wildcard
+EQUALS : '=' ;            // Equals sign
+PIPE : '|' ;              // Pipe, used to separate the types of a union
 DOUBLE_QUOTES : '""' ;      // Double quote
 
 SHORT_STRING: '"'  ('\\' (RN | .) | ~[\\\r\n"])* '"';
@@ -122,7 +185,9 @@ OR_SYM: '||';
 NOT_SYM: '!';
 
 IDENTIFIER : (CHARACTER)+ ;    // Typical definition of an identifier
+ATTRIBUTE_NAME : '@' (CHARACTER)+ ;    // Attribute of an element (e.g. XML attributes)
 
+COMMENT : '//' ~[\r\n]* -> channel(HIDDEN) ;    // Comments, kept for the formatter
 WS : [ \t\r\n]+ -> skip ;    // Skip whitespace
 
 // Auxiliary lexer rules
@@ -0,0 +1,200 @@
+// Derived from https://github.com/lightbend/config/blob/main/HOCON.md
+grammar HOCON;
+
+hocon
+   : NL* (object NL* | objectBody) EOF
+   ;
+
+object
+   : LBRACE NL* objectBody RBRACE
+   ;
+
+objectBody
+   : member (separator member)* separator?
+   |
+   ;
+
+separator
+   : COMMA NL*
+   | NL+
+   ;
+
+member
+   : include                           # includeMember
+   | path (COLON | EQUALS) value       # assignMember
+   | path PLUS_EQUALS value            # appendMember
+   | path object                       # objectMember
+   ;
+
+include
+   : INCLUDE includeResource
+   ;
+
+includeResource
+   : STRING                                                        # includeString
+   | (INCLUDE_FILE | INCLUDE_URL | INCLUDE_CLASSPATH) STRING RPAREN # includeQualified
+   | INCLUDE_REQUIRED includeResource RPAREN                       # includeRequired
+   ;
+
+path
+   : pathElement+
+   ;
+
+pathElement
+   : UNQUOTED
+   | STRING
+   | NUMBER
+   | TRUE
+   | FALSE
+   | NULL
+   | INCLUDE
+   ;
+
+array
+   : LBRACK NL* (value (separator value)* separator?)? RBRACK
+   ;
+
+value
+   : valuePart+
+   ;
+
+valuePart
+   : object                # objectValue
+   | array                 # arrayValue
+   | STRING                # stringValue
+   | MULTILINE_STRING      # multilineStringValue
+   | NUMBER                # numberValue
+   | TRUE                  # trueValue
+   | FALSE                 # falseValue
+   | NULL                  # nullValue
+   | SUBSTITUTION          # substitutionValue
+   | (UNQUOTED | INCLUDE | LPAREN | RPAREN) # unquotedValue
+   ;
+
+INCLUDE
+   : 'include'
+   ;
+
+INCLUDE_FILE
+   : 'file('
+   ;
+
+INCLUDE_URL
+   : 'url('
+   ;
+
+INCLUDE_CLASSPATH
+   : 'classpath('
+   ;
+
+INCLUDE_REQUIRED
+   : 'required('
+   ;
+
+LPAREN
+   : '('
+   ;
+
+RPAREN
+   : ')'
+   ;
+
+LBRACE
+   : '{'
+   ;
+
+RBRACE
+   : '}'
+   ;
+
+LBRACK
+   : '['
+   ;
+
+RBRACK
+   : ']'
+   ;
+
+COMMA
+   : ','
+   ;
+
+COLON
+   : ':'
+   ;
+
+EQUALS
+   : '='
+   ;
+
+PLUS_EQUALS
+   : '+='
+   ;
+
+TRUE
+   : 'true'
+   ;
+
+FALSE
+   : 'false'
+   ;
+
+NULL
+   : 'null'
+   ;
+
+// ${path} or ${?path}, the path is split by the parser
+SUBSTITUTION
+   : '${' '?'? ~[}\r\n]+ '}'
+   ;
+
+// Triple quoted strings end at the last quote of the closing sequence
+MULTILINE_STRING
+   : '"""' .*? '"""' '"'*
+   ;
+
+STRING
+   : '"' (ESC | ~["\\\r\n])* '"'
+   ;
+
+fragment ESC
+   : '\\' (["\\/bfnrt] | UNICODE)
+   ;
+
+fragment UNICODE
+   : 'u' HEX HEX HEX HEX
+   ;
+
+fragment HEX
+   : [0-9a-fA-F]
+   ;
+
+NUMBER
+   : '-'? [0-9]+ ('.' [0-9]+)? EXP?
+   ;
+
+fragment EXP
+   : [Ee] [+\-]? [0-9]+
+   ;
+
+COMMENT
+   : ('#' | '//') ~[\r\n]* -> skip
+   ;
+
+// Unquoted strings can't contain '//' since it starts a comment.
+// Parentheses are separate tokens so that include resources can be nested.
+UNQUOTED
+   : (UNQUOTED_CHAR | '/' UNQUOTED_CHAR)+
+   ;
+
+fragment UNQUOTED_CHAR
+   : ~[$"{}[\]:=,+#`^?!@*&\\/ \t\r\n\u00A0\uFEFF()]
+   ;
+
+NL
+   : [\r\n]+
+   ;
+
+WS
+   : [ \t\u00A0\uFEFF]+ -> skip
+   ;
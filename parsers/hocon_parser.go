@@ -0,0 +1,941 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ConfigMate/configmate/parsers/gen/parser_hocon"
+	"github.com/antlr4-go/antlr/v4"
+)
+
+// hoconParser parses HOCON files in two phases. First the parse tree is
+// turned into a tree of hoconValue, where object merging, += and includes
+// are applied and substitutions are kept unresolved. Then the tree is
+// resolved into *Node, replacing substitutions by the values they point to.
+type hoconParser struct {
+	root  *hoconValue
+	input []rune
+	errs  []CMParserError
+	dir   string // Directory of the parsed file, the working directory if empty
+
+	// Include handling. Values coming from included files are located
+	// at the include statement, since locations refer to the parsed file.
+	includeStack    []string
+	includeLocation *TokenLocation
+
+	// Substitution resolution
+	resolved  map[*hoconValue]*Node
+	resolving map[*hoconValue]bool
+}
+
+type hoconValueKind int
+
+const (
+	hoconSimple        hoconValueKind = iota // Null, Bool, Int, Float or String
+	hoconArray                               // Array of values
+	hoconObject                              // Object with fields
+	hoconSubstitution                        // ${path} or ${?path}
+	hoconConcatenation                       // Values next to each other
+	hoconMerge                               // Value redefining a previous value
+)
+
+// hoconValue is a HOCON value before substitutions are resolved.
+type hoconValue struct {
+	kind hoconValueKind
+
+	node       *Node                  // Value of simple values
+	text       string                 // Original text of simple values
+	whitespace bool                   // Whitespace between concatenated values
+	elements   []*hoconValue          // Elements of arrays
+	fields     map[string]*hoconValue // Fields of objects
+	parts      []*hoconValue          // Parts of concatenations and merges
+	path       []string               // Path of substitutions
+	optional   bool                   // Substitution is optional (${?path})
+
+	nameLocation  TokenLocation
+	valueLocation TokenLocation
+}
+
+// Custom HOCON parser. Included files are found relative to the working directory.
+func (p *hoconParser) Parse(data []byte) (*Node, []CMParserError) {
+	return p.parse(data, "")
+}
+
+// ParseFile parses the content of the file at path. Included files are
+// found relative to the directory of the file.
+func (p *hoconParser) ParseFile(path string, data []byte) (*Node, []CMParserError) {
+	return p.parse(data, filepath.Dir(path))
+}
+
+// parse parses HOCON content, with the included files found relative to dir.
+func (p *hoconParser) parse(data []byte, dir string) (*Node, []CMParserError) {
+	// Parse content
+	tree, errs := p.parseTree(data)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Initialize parser state
+	p.input = []rune(string(data))
+	p.errs = nil
+	p.dir = dir
+	p.includeStack = nil
+	p.includeLocation = nil
+	p.resolved = make(map[*hoconValue]*Node)
+	p.resolving = make(map[*hoconValue]bool)
+
+	// Initialize root object
+	p.root = &hoconValue{kind: hoconObject, fields: map[string]*hoconValue{}}
+	if tree.Object() != nil {
+		p.root.valueLocation = p.contextLocation(tree.Object())
+		p.applyMembers(p.root, tree.Object().ObjectBody(), nil)
+	} else {
+		p.applyMembers(p.root, tree.ObjectBody(), nil)
+	}
+
+	// Check for errors
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	// Resolve substitutions
+	configFile, _ := p.resolve(p.root)
+
+	// Check for errors
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	return configFile, nil
+}
+
+// parseTree parses HOCON content into a parse tree.
+func (p *hoconParser) parseTree(data []byte) (parser_hocon.IHoconContext, []CMParserError) {
+	// Initialize the error listener
+	errorListener := &CMErrorListener{}
+
+	input := antlr.NewInputStream(string(data))
+	lexer := parser_hocon.NewHOCONLexer(input)
+
+	// Attach the error listener to the lexer
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(errorListener)
+
+	tokenStream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	parser := parser_hocon.NewHOCONParser(tokenStream)
+
+	// Attach the error listener to the parser
+	parser.RemoveErrorListeners()
+	parser.AddErrorListener(errorListener)
+	tree := parser.Hocon()
+
+	// Check for errors after parsing
+	if len(errorListener.errors) > 0 {
+		return nil, errorListener.errors
+	}
+
+	return tree, nil
+}
+
+// applyMembers adds the members of an object body to an object. The prefix
+// is the path of the object from the root, used to detect self references.
+func (p *hoconParser) applyMembers(object *hoconValue, body parser_hocon.IObjectBodyContext, prefix []string) {
+	for _, member := range body.AllMember() {
+		switch ctx := member.(type) {
+		case *parser_hocon.IncludeMemberContext:
+			p.include(object, ctx.Include(), prefix)
+
+		case *parser_hocon.AssignMemberContext:
+			segments, ok := p.parsePath(ctx.Path())
+			if !ok {
+				continue
+			}
+
+			// Objects assigned directly are merged in place
+			parts := ctx.Value().AllValuePart()
+			if objectCtx, isObject := parts[0].(*parser_hocon.ObjectValueContext); isObject && len(parts) == 1 {
+				p.assignObject(object, prefix, segments, p.contextLocation(ctx.Path()), objectCtx.Object())
+			} else {
+				p.assign(object, prefix, segments, p.contextLocation(ctx.Path()), ctx.Value(), false)
+			}
+
+		case *parser_hocon.AppendMemberContext:
+			segments, ok := p.parsePath(ctx.Path())
+			if !ok {
+				continue
+			}
+
+			p.assign(object, prefix, segments, p.contextLocation(ctx.Path()), ctx.Value(), true)
+
+		case *parser_hocon.ObjectMemberContext:
+			segments, ok := p.parsePath(ctx.Path())
+			if !ok {
+				continue
+			}
+
+			p.assignObject(object, prefix, segments, p.contextLocation(ctx.Path()), ctx.Object())
+		}
+	}
+}
+
+// assign sets the value of the field at the given path of the object.
+// If appending, the value is added to the array previously in the field.
+func (p *hoconParser) assign(object *hoconValue, prefix, segments []string, nameLocation TokenLocation, valueCtx parser_hocon.IValueContext, appending bool) {
+	// Get parent object of the field
+	parent := p.navigate(object, segments[:len(segments)-1], nameLocation)
+	key := segments[len(segments)-1]
+	fullPath := append(append([]string{}, prefix...), segments...)
+	existing := parent.fields[key]
+
+	// Build value
+	value, selfReferenced := p.buildValue(valueCtx, fullPath, existing)
+	if value == nil { // Optional self reference without previous value
+		return
+	}
+
+	if appending {
+		// a += b is the same as a = ${?a} [b]
+		array := &hoconValue{kind: hoconArray, elements: []*hoconValue{value}, valueLocation: value.valueLocation}
+		if existing != nil {
+			value = &hoconValue{
+				kind:          hoconConcatenation,
+				parts:         []*hoconValue{existing, array},
+				valueLocation: value.valueLocation,
+			}
+		} else {
+			value = array
+		}
+	} else if existing != nil && !selfReferenced && value.kind != hoconSimple && value.kind != hoconArray {
+		// The new value may resolve to an object, which would be merged with the previous one
+		value = &hoconValue{
+			kind:          hoconMerge,
+			parts:         []*hoconValue{existing, value},
+			valueLocation: value.valueLocation,
+		}
+	}
+
+	value.nameLocation = nameLocation
+	parent.fields[key] = value
+}
+
+// assignObject merges an object into the field at the given path of the object.
+func (p *hoconParser) assignObject(object *hoconValue, prefix, segments []string, nameLocation TokenLocation, objectCtx parser_hocon.IObjectContext) {
+	// Get parent object of the field
+	parent := p.navigate(object, segments[:len(segments)-1], nameLocation)
+	key := segments[len(segments)-1]
+	fullPath := append(append([]string{}, prefix...), segments...)
+	existing := parent.fields[key]
+
+	var target *hoconValue
+	if existing != nil && existing.kind == hoconObject {
+		// Merge into the existing object
+		target = existing
+	} else {
+		target = &hoconValue{kind: hoconObject, fields: map[string]*hoconValue{}}
+		if existing != nil && existing.kind != hoconSimple && existing.kind != hoconArray {
+			// The previous value may resolve to an object
+			parent.fields[key] = &hoconValue{
+				kind:  hoconMerge,
+				parts: []*hoconValue{existing, target},
+			}
+		} else {
+			parent.fields[key] = target
+		}
+	}
+
+	// Set locations
+	parent.fields[key].nameLocation = nameLocation
+	parent.fields[key].valueLocation = p.contextLocation(objectCtx)
+	target.nameLocation = nameLocation
+	target.valueLocation = p.contextLocation(objectCtx)
+
+	p.applyMembers(target, objectCtx.ObjectBody(), fullPath)
+}
+
+// navigate gets the object at the given path, creating objects as needed.
+func (p *hoconParser) navigate(object *hoconValue, segments []string, nameLocation TokenLocation) *hoconValue {
+	for _, segment := range segments {
+		next := object.fields[segment]
+		if next != nil && next.kind == hoconObject {
+			object = next
+			continue
+		}
+
+		// Create new object
+		newObject := &hoconValue{
+			kind:          hoconObject,
+			fields:        map[string]*hoconValue{},
+			nameLocation:  nameLocation,
+			valueLocation: nameLocation,
+		}
+
+		if next != nil && next.kind != hoconSimple && next.kind != hoconArray {
+			// The previous value may resolve to an object
+			object.fields[segment] = &hoconValue{
+				kind:          hoconMerge,
+				parts:         []*hoconValue{next, newObject},
+				nameLocation:  nameLocation,
+				valueLocation: nameLocation,
+			}
+		} else {
+			object.fields[segment] = newObject
+		}
+
+		object = newObject
+	}
+
+	return object
+}
+
+// include adds the members of an included file to the object.
+func (p *hoconParser) include(object *hoconValue, ctx parser_hocon.IIncludeContext, prefix []string) {
+	location := p.contextLocation(ctx)
+
+	// Get file name
+	filename, required, ok := p.includeFilename(ctx.IncludeResource())
+	if !ok {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "only file includes are supported",
+			Location: location,
+		})
+		return
+	}
+
+	// Included files are found relative to the file including them
+	if !filepath.IsAbs(filename) {
+		includingDir := p.dir
+		if len(p.includeStack) > 0 {
+			includingDir = filepath.Dir(p.includeStack[len(p.includeStack)-1])
+		}
+		filename = filepath.Join(includingDir, filename)
+	}
+
+	// Check for include cycles
+	for _, includedFile := range p.includeStack {
+		if includedFile == filename {
+			p.errs = append(p.errs, CMParserError{
+				Message:  fmt.Sprintf("include cycle detected for file '%s'", filename),
+				Location: location,
+			})
+			return
+		}
+	}
+
+	// Read file. Missing files are ignored unless required.
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		if required {
+			p.errs = append(p.errs, CMParserError{
+				Message:  fmt.Sprintf("could not read included file '%s': %s", filename, err.Error()),
+				Location: location,
+			})
+		}
+		return
+	}
+
+	// Parse file
+	tree, errs := p.parseTree(content)
+	if len(errs) > 0 {
+		for _, parserError := range errs {
+			p.errs = append(p.errs, CMParserError{
+				Message: fmt.Sprintf("error in included file '%s' at line %d: %s",
+					filename, parserError.Location.Start.Line+1, parserError.Message),
+				Location: location,
+			})
+		}
+		return
+	}
+
+	// Save state and switch to the included file
+	previousInput, previousIncludeLocation := p.input, p.includeLocation
+	p.input = []rune(string(content))
+	if p.includeLocation == nil {
+		p.includeLocation = &location
+	}
+	p.includeStack = append(p.includeStack, filename)
+
+	if tree.Object() != nil {
+		p.applyMembers(object, tree.Object().ObjectBody(), prefix)
+	} else {
+		p.applyMembers(object, tree.ObjectBody(), prefix)
+	}
+
+	// Restore state
+	p.input, p.includeLocation = previousInput, previousIncludeLocation
+	p.includeStack = p.includeStack[:len(p.includeStack)-1]
+}
+
+// includeFilename gets the file name of an include and whether it is required.
+func (p *hoconParser) includeFilename(ctx parser_hocon.IIncludeResourceContext) (string, bool, bool) {
+	switch resourceCtx := ctx.(type) {
+	case *parser_hocon.IncludeRequiredContext:
+		filename, _, ok := p.includeFilename(resourceCtx.IncludeResource())
+		return filename, true, ok
+
+	case *parser_hocon.IncludeStringContext:
+		return p.unquote(resourceCtx.STRING().GetText()), false, true
+
+	case *parser_hocon.IncludeQualifiedContext:
+		if resourceCtx.INCLUDE_FILE() == nil { // url() and classpath()
+			return "", false, false
+		}
+
+		return p.unquote(resourceCtx.STRING().GetText()), false, true
+	}
+
+	return "", false, false
+}
+
+// buildValue builds a value from its parse tree. The field path and its previous
+// value are used to resolve self references (e.g. path = ${path} ["/usr/bin"]).
+// It returns nil if the value is undefined, and whether a self reference was found.
+func (p *hoconParser) buildValue(ctx parser_hocon.IValueContext, fieldPath []string, existing *hoconValue) (*hoconValue, bool) {
+	selfReferenced := false
+	parts := []*hoconValue{}
+	var previousStop antlr.Token
+	for _, partCtx := range ctx.AllValuePart() {
+		// Keep whitespace between values
+		if previousStop != nil {
+			if gap := p.gap(previousStop, partCtx.GetStart()); gap != "" {
+				parts = append(parts, &hoconValue{
+					kind:       hoconSimple,
+					node:       &Node{Type: String, Value: gap},
+					text:       gap,
+					whitespace: true,
+				})
+			}
+		}
+		previousStop = partCtx.GetStop()
+
+		part, partSelfReferenced := p.buildValuePart(partCtx, fieldPath, existing)
+		selfReferenced = selfReferenced || partSelfReferenced
+		if part != nil {
+			parts = append(parts, part)
+		}
+	}
+
+	// Remove leading and trailing whitespace left by undefined values
+	for len(parts) > 0 && parts[0].whitespace {
+		parts = parts[1:]
+	}
+	for len(parts) > 0 && parts[len(parts)-1].whitespace {
+		parts = parts[:len(parts)-1]
+	}
+
+	switch len(parts) {
+	case 0:
+		return nil, selfReferenced
+	case 1:
+		return parts[0], selfReferenced
+	default:
+		return &hoconValue{
+			kind:          hoconConcatenation,
+			parts:         parts,
+			valueLocation: p.contextLocation(ctx),
+		}, selfReferenced
+	}
+}
+
+// buildValuePart builds a single part of a value.
+func (p *hoconParser) buildValuePart(ctx parser_hocon.IValuePartContext, fieldPath []string, existing *hoconValue) (*hoconValue, bool) {
+	location := p.contextLocation(ctx)
+	text := ctx.GetText()
+
+	switch partCtx := ctx.(type) {
+	case *parser_hocon.ObjectValueContext:
+		object := &hoconValue{kind: hoconObject, fields: map[string]*hoconValue{}, valueLocation: location}
+		p.applyMembers(object, partCtx.Object().ObjectBody(), fieldPath)
+		return object, false
+
+	case *parser_hocon.ArrayValueContext:
+		selfReferenced := false
+		array := &hoconValue{kind: hoconArray, elements: []*hoconValue{}, valueLocation: location}
+		for _, elementCtx := range partCtx.Array().AllValue() {
+			element, elementSelfReferenced := p.buildValue(elementCtx, fieldPath, existing)
+			selfReferenced = selfReferenced || elementSelfReferenced
+			if element != nil {
+				array.elements = append(array.elements, element)
+			}
+		}
+		return array, selfReferenced
+
+	case *parser_hocon.StringValueContext:
+		return p.simpleValue(String, p.unquote(text), text, location), false
+
+	case *parser_hocon.MultilineStringValueContext:
+		return p.simpleValue(String, text[3:len(text)-3], text, location), false
+
+	case *parser_hocon.NumberValueContext:
+		if !strings.ContainsAny(text, ".eE") {
+			if value, err := strconv.Atoi(text); err == nil {
+				return p.simpleValue(Int, value, text, location), false
+			}
+		}
+
+		// Numbers too large for a float, like 1e400, are valid HOCON
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			p.errs = append(p.errs, CMParserError{
+				Message:  fmt.Sprintf("invalid number '%s': %s", text, err.(*strconv.NumError).Err),
+				Location: location,
+			})
+			return nil, false
+		}
+		return p.simpleValue(Float, value, text, location), false
+
+	case *parser_hocon.TrueValueContext:
+		return p.simpleValue(Bool, true, text, location), false
+
+	case *parser_hocon.FalseValueContext:
+		return p.simpleValue(Bool, false, text, location), false
+
+	case *parser_hocon.NullValueContext:
+		return p.simpleValue(Null, nil, text, location), false
+
+	case *parser_hocon.UnquotedValueContext:
+		return p.simpleValue(String, text, text, location), false
+
+	case *parser_hocon.SubstitutionValueContext:
+		// Remove ${ and }
+		expression := text[2 : len(text)-1]
+		optional := strings.HasPrefix(expression, "?")
+		expression = strings.TrimSpace(strings.TrimPrefix(expression, "?"))
+
+		path, err := p.splitPath(expression)
+		if err != nil {
+			p.errs = append(p.errs, CMParserError{Message: err.Error(), Location: location})
+			return nil, false
+		}
+
+		// Self references point to the previous value of the field
+		if p.samePath(path, fieldPath) {
+			if existing == nil && !optional {
+				p.errs = append(p.errs, CMParserError{
+					Message:  fmt.Sprintf("substitution '%s' refers to a field with no previous value", text),
+					Location: location,
+				})
+			}
+			return existing, true
+		}
+
+		return &hoconValue{
+			kind:          hoconSubstitution,
+			text:          text,
+			path:          path,
+			optional:      optional,
+			valueLocation: location,
+		}, false
+	}
+
+	return nil, false
+}
+
+// samePath checks if two paths are equal.
+func (p *hoconParser) samePath(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// simpleValue creates a simple value.
+func (p *hoconParser) simpleValue(fieldType FieldType, value interface{}, text string, location TokenLocation) *hoconValue {
+	return &hoconValue{
+		kind:          hoconSimple,
+		node:          &Node{Type: fieldType, Value: value},
+		text:          text,
+		valueLocation: location,
+	}
+}
+
+// parsePath splits a path expression (e.g. a.b."c.d") into its segments.
+func (p *hoconParser) parsePath(ctx parser_hocon.IPathContext) ([]string, bool) {
+	segments := []string{""}
+	var previousStop antlr.Token
+	for _, elementCtx := range ctx.AllPathElement() {
+		// Keep whitespace between path elements
+		if previousStop != nil {
+			segments[len(segments)-1] += p.gap(previousStop, elementCtx.GetStart())
+		}
+		previousStop = elementCtx.GetStop()
+
+		// Quoted elements are never split
+		if elementCtx.STRING() != nil {
+			segments[len(segments)-1] += p.unquote(elementCtx.GetText())
+			continue
+		}
+
+		pieces := strings.Split(elementCtx.GetText(), ".")
+		segments[len(segments)-1] += pieces[0]
+		segments = append(segments, pieces[1:]...)
+	}
+
+	// Check for empty segments
+	for _, segment := range segments {
+		if segment == "" {
+			p.errs = append(p.errs, CMParserError{
+				Message:  fmt.Sprintf("invalid path expression: '%s'", ctx.GetText()),
+				Location: p.contextLocation(ctx),
+			})
+			return nil, false
+		}
+	}
+
+	return segments, true
+}
+
+// splitPath splits the path of a substitution into its segments.
+func (p *hoconParser) splitPath(expression string) ([]string, error) {
+	segments := []string{""}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '"':
+			// Find closing quote
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("invalid substitution path: '%s'", expression)
+			}
+
+			segments[len(segments)-1] += p.unquote(string(runes[i : end+1]))
+			i = end
+
+		case '.':
+			segments = append(segments, "")
+
+		default:
+			segments[len(segments)-1] += string(runes[i])
+		}
+	}
+
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid substitution path: '%s'", expression)
+		}
+	}
+
+	return segments, nil
+}
+
+// resolve resolves a value into a node. It returns false if the
+// value is undefined (i.e. made only of missing optional substitutions).
+func (p *hoconParser) resolve(value *hoconValue) (*Node, bool) {
+	// Check if the value was already resolved
+	if node, ok := p.resolved[value]; ok {
+		return node, node != nil
+	}
+
+	// Check for cycles
+	if p.resolving[value] {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "substitution cycle detected",
+			Location: value.valueLocation,
+		})
+		return &Node{Type: Null, Value: nil, ValueLocation: value.valueLocation}, true
+	}
+
+	p.resolving[value] = true
+	node := p.resolveValue(value)
+	p.resolving[value] = false
+	p.resolved[value] = node
+
+	return node, node != nil
+}
+
+// resolveValue resolves a value according to its kind.
+func (p *hoconParser) resolveValue(value *hoconValue) *Node {
+	switch value.kind {
+	case hoconSimple:
+		return &Node{Type: value.node.Type, Value: value.node.Value, ValueLocation: value.valueLocation}
+
+	case hoconArray:
+		elements := []*Node{}
+		for _, element := range value.elements {
+			if elementNode, ok := p.resolve(element); ok {
+				elements = append(elements, elementNode)
+			}
+		}
+		return &Node{Type: Array, Value: elements, ValueLocation: value.valueLocation}
+
+	case hoconObject:
+		// Resolve fields in order, so that errors are reported deterministically
+		keys := make([]string, 0, len(value.fields))
+		for key := range value.fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fields := map[string]*Node{}
+		for _, key := range keys {
+			field := value.fields[key]
+			if fieldNode, ok := p.resolve(field); ok {
+				// Fields keeping a previous value also keep its name location
+				nameLocation := field.nameLocation
+				if fieldNode.NameLocation != (TokenLocation{}) {
+					nameLocation = fieldNode.NameLocation
+				}
+
+				fields[key] = p.relocate(fieldNode, nameLocation, fieldNode.ValueLocation)
+			}
+		}
+		return &Node{Type: Object, Value: fields, ValueLocation: value.valueLocation}
+
+	case hoconSubstitution:
+		node, found := p.lookup(value.path)
+		if !found {
+			// Fall back to environment variables
+			if env, ok := os.LookupEnv(strings.Join(value.path, ".")); ok {
+				return &Node{Type: String, Value: env, ValueLocation: value.valueLocation}
+			}
+
+			if !value.optional {
+				p.errs = append(p.errs, CMParserError{
+					Message:  fmt.Sprintf("could not resolve substitution '%s'", value.text),
+					Location: value.valueLocation,
+				})
+				return &Node{Type: Null, Value: nil, ValueLocation: value.valueLocation}
+			}
+
+			return nil
+		}
+
+		return p.relocate(node, TokenLocation{}, value.valueLocation)
+
+	case hoconConcatenation:
+		return p.resolveConcatenation(value)
+
+	case hoconMerge:
+		newNode, ok := p.resolve(value.parts[1])
+		if !ok { // New value is undefined, keep the previous one
+			previousNode, ok := p.resolve(value.parts[0])
+			if !ok {
+				return nil
+			}
+
+			return p.relocate(previousNode, value.parts[0].nameLocation, previousNode.ValueLocation)
+		}
+
+		if newNode.Type == Object {
+			if previousNode, ok := p.resolve(value.parts[0]); ok && previousNode.Type == Object {
+				return p.mergeObjects(previousNode, newNode)
+			}
+		}
+
+		return newNode
+	}
+
+	return nil
+}
+
+// resolveConcatenation resolves values next to each other. Objects
+// are merged, arrays are joined and simple values form a string.
+func (p *hoconParser) resolveConcatenation(value *hoconValue) *Node {
+	// Resolve parts, skipping undefined ones
+	nodes := []*Node{}
+	texts := []string{}
+	for _, part := range value.parts {
+		node, ok := p.resolve(part)
+		if !ok {
+			continue
+		}
+
+		// Whitespace and simple values keep their original text
+		if part.whitespace {
+			texts = append(texts, part.text)
+			continue
+		} else if part.kind == hoconSimple && node.Type != String {
+			texts = append(texts, part.text)
+		} else {
+			texts = append(texts, p.text(node))
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	// Check that all parts have compatible types
+	concatType := p.concatenationType(nodes[0])
+	for _, node := range nodes[1:] {
+		if p.concatenationType(node) != concatType {
+			p.errs = append(p.errs, CMParserError{
+				Message: fmt.Sprintf("cannot concatenate %s and %s values",
+					concatType.String(), p.concatenationType(node).String()),
+				Location: value.valueLocation,
+			})
+			return &Node{Type: Null, Value: nil, ValueLocation: value.valueLocation}
+		}
+	}
+
+	// A single value keeps its type
+	if len(nodes) == 1 {
+		return p.relocate(nodes[0], TokenLocation{}, value.valueLocation)
+	}
+
+	switch concatType {
+	case Object:
+		result := nodes[0]
+		for _, node := range nodes[1:] {
+			result = p.mergeObjects(result, node)
+		}
+		return p.relocate(result, TokenLocation{}, value.valueLocation)
+
+	case Array:
+		elements := []*Node{}
+		for _, node := range nodes {
+			elements = append(elements, node.Value.([]*Node)...)
+		}
+		return &Node{Type: Array, Value: elements, ValueLocation: value.valueLocation}
+
+	default:
+		return &Node{Type: String, Value: strings.Join(texts, ""), ValueLocation: value.valueLocation}
+	}
+}
+
+// concatenationType gets the type of a node when concatenated.
+// Simple values are concatenated as strings.
+func (p *hoconParser) concatenationType(node *Node) FieldType {
+	if node.Type == Object || node.Type == Array {
+		return node.Type
+	}
+
+	return String
+}
+
+// lookup finds the node at the given path from the root.
+func (p *hoconParser) lookup(path []string) (*Node, bool) {
+	current := p.root
+	for i, segment := range path {
+		// Resolve values that aren't objects yet and continue on the resolved node
+		if current.kind != hoconObject {
+			node, ok := p.resolve(current)
+			if !ok {
+				return nil, false
+			}
+
+			found, err := node.Get(&NodeKey{Segments: path[i:]})
+			if err != nil || found == nil {
+				return nil, false
+			}
+
+			return found, true
+		}
+
+		next, ok := current.fields[segment]
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+
+	return p.resolve(current)
+}
+
+// mergeObjects merges two object nodes, fields of the second object take precedence.
+func (p *hoconParser) mergeObjects(base, override *Node) *Node {
+	fields := map[string]*Node{}
+	for key, field := range base.Value.(map[string]*Node) {
+		fields[key] = field
+	}
+
+	for key, field := range override.Value.(map[string]*Node) {
+		if baseField, ok := fields[key]; ok && baseField.Type == Object && field.Type == Object {
+			fields[key] = p.relocate(p.mergeObjects(baseField, field), field.NameLocation, field.ValueLocation)
+		} else {
+			fields[key] = field
+		}
+	}
+
+	return &Node{Type: Object, Value: fields, NameLocation: override.NameLocation, ValueLocation: override.ValueLocation}
+}
+
+// relocate returns a copy of a node with new locations. Resolved
+// nodes can be shared, so their locations are never modified.
+func (p *hoconParser) relocate(node *Node, nameLocation, valueLocation TokenLocation) *Node {
+	return &Node{Type: node.Type, Value: node.Value, NameLocation: nameLocation, ValueLocation: valueLocation}
+}
+
+// text returns the text of a simple node, used when concatenating values.
+func (p *hoconParser) text(node *Node) string {
+	switch node.Type {
+	case Null:
+		return "null"
+	case Bool:
+		return strconv.FormatBool(node.Value.(bool))
+	case Int:
+		return strconv.Itoa(node.Value.(int))
+	case Float:
+		return strconv.FormatFloat(node.Value.(float64), 'f', -1, 64)
+	case String:
+		return node.Value.(string)
+	default:
+		return ""
+	}
+}
+
+// unquote removes the quotes of a quoted string and resolves its escape
+// sequences. HOCON quoted strings follow the JSON string syntax.
+func (p *hoconParser) unquote(text string) string {
+	var value string
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return removeQuotes(text)
+	}
+
+	return value
+}
+
+// gap returns the text between two tokens.
+func (p *hoconParser) gap(previous, next antlr.Token) string {
+	if previous.GetStop()+1 >= next.GetStart() || next.GetStart() > len(p.input) {
+		return ""
+	}
+
+	return string(p.input[previous.GetStop()+1 : next.GetStart()])
+}
+
+// contextLocation gets the location of a parse tree context.
+func (p *hoconParser) contextLocation(ctx antlr.ParserRuleContext) TokenLocation {
+	// Values from included files are located at the include statement
+	if p.includeLocation != nil {
+		return *p.includeLocation
+	}
+
+	// Get end of the last token, which may span multiple lines
+	stop := ctx.GetStop()
+	lines := strings.Split(stop.GetText(), "\n")
+	endLine := stop.GetLine() - 1 + len(lines) - 1
+	endColumn := len([]rune(lines[len(lines)-1]))
+	if len(lines) == 1 {
+		endColumn += stop.GetColumn()
+	}
+
+	return TokenLocation{
+		Start: CharLocation{
+			Line:   ctx.GetStart().GetLine() - 1,
+			Column: ctx.GetStart().GetColumn(),
+		},
+		End: CharLocation{
+			Line:   endLine,
+			Column: endColumn,
+		},
+	}
+}
@@ -0,0 +1,503 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type hoconParserTestCase struct {
+	input        []byte
+	expected     *Node
+	expectedErrs []CMParserError
+}
+
+func TestParseSimpleConfig_hoconParser(t *testing.T) {
+	// Input
+	testConfig, err := os.ReadFile("./test_configs/simple.conf")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// Test cases
+	testCases := []hoconParserTestCase{
+		{
+			input: testConfig,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"app": {
+						Type: Object,
+						Value: map[string]*Node{
+							"debug": {
+								Type:          Bool,
+								Value:         false,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 4}, End: CharLocation{Line: 6, Column: 9}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 6, Column: 12}, End: CharLocation{Line: 6, Column: 17}},
+							},
+							"name": {
+								Type:          String,
+								Value:         "ConfigMate",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 4}, End: CharLocation{Line: 4, Column: 8}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 11}, End: CharLocation{Line: 4, Column: 23}},
+							},
+							"servers": {
+								Type: Array,
+								Value: []*Node{
+									{
+										Type:          String,
+										Value:         "alpha",
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 16}, End: CharLocation{Line: 9, Column: 21}},
+									},
+									{
+										Type:          String,
+										Value:         "beta",
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 23}, End: CharLocation{Line: 9, Column: 27}},
+									},
+									{
+										Type:          String,
+										Value:         "gamma",
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 15}, End: CharLocation{Line: 10, Column: 20}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 10, Column: 0}, End: CharLocation{Line: 10, Column: 11}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 15}, End: CharLocation{Line: 10, Column: 20}},
+							},
+							"version": {
+								Type:          Float,
+								Value:         1.5,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 5, Column: 4}, End: CharLocation{Line: 5, Column: 11}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 5, Column: 14}, End: CharLocation{Line: 5, Column: 17}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 0}, End: CharLocation{Line: 3, Column: 3}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 4}, End: CharLocation{Line: 7, Column: 1}},
+					},
+					"database": {
+						Type: Object,
+						Value: map[string]*Node{
+							"host": {
+								Type:          String,
+								Value:         "db.local",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 13, Column: 4}, End: CharLocation{Line: 13, Column: 8}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 13, Column: 11}, End: CharLocation{Line: 13, Column: 27}},
+							},
+							"timeout": {
+								Type:          String,
+								Value:         "10 seconds",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 15, Column: 4}, End: CharLocation{Line: 15, Column: 11}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 15, Column: 14}, End: CharLocation{Line: 15, Column: 24}},
+							},
+							"url": {
+								Type:          String,
+								Value:         "jdbc:db.local/app",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 14, Column: 4}, End: CharLocation{Line: 14, Column: 7}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 14, Column: 10}, End: CharLocation{Line: 14, Column: 39}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 12, Column: 0}, End: CharLocation{Line: 12, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 12, Column: 9}, End: CharLocation{Line: 16, Column: 1}},
+					},
+					"defaults": {
+						Type: Object,
+						Value: map[string]*Node{
+							"host": {
+								Type:          String,
+								Value:         "db.local",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 23}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 23}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 23}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 23}},
+					},
+				},
+			},
+			expectedErrs: []CMParserError{},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &hoconParser{}
+		result, errs := parser.ParseFile("./test_configs/simple.conf", test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v", test.expected, result)
+		}
+	}
+}
+
+// TestIncludeRelativeToFile_hoconParser tests that included files are found
+// relative to the including file, whatever the working directory is.
+func TestIncludeRelativeToFile_hoconParser(t *testing.T) {
+	path, err := filepath.Abs("./test_configs/simple.conf")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	testConfig, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Run from another directory
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer os.Chdir(workingDir)
+
+	parser := &hoconParser{}
+	result, errs := parser.ParseFile(path, testConfig)
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %#v", errs)
+	}
+
+	// The host of the defaults is in the included file
+	defaults, ok := result.Value.(map[string]*Node)["defaults"]
+	if !ok {
+		t.Fatalf("Expected the fields of the included file, got %#+v", result)
+	}
+	if host := defaults.Value.(map[string]*Node)["host"]; host == nil || host.Value != "db.local" {
+		t.Errorf("Expected host db.local from the included file, got %#+v", host)
+	}
+}
+
+func TestShortSamples_hoconParser(t *testing.T) {
+	// Input
+	var shortHoconConfig0 = []byte(`
+	server { host = localhost, port = 80 }
+	server { port = 8080 }
+	server.debug = true
+	`)
+
+	var shortHoconConfig1 = []byte(`
+	base = /usr
+	path = ${base}/bin
+	dirs = [${base}]
+	dirs += /opt
+	`)
+
+	var shortHoconConfig2 = []byte(`
+	timeout = 10
+	timeout = ${?CONFIGMATE_UNSET_VARIABLE}
+	ports = [80]
+	ports = ${ports} [443]
+	`)
+
+	var shortHoconConfig3 = []byte(`
+	{
+		"quoted.key" : 1,
+		unquoted.key : null
+	}
+	`)
+
+	testCases := []hoconParserTestCase{
+		{ // Object merging
+			input: shortHoconConfig0,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"server": {
+						Type: Object,
+						Value: map[string]*Node{
+							"debug": {
+								Type:          Bool,
+								Value:         true,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 13}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 16}, End: CharLocation{Line: 3, Column: 20}},
+							},
+							"host": {
+								Type:          String,
+								Value:         "localhost",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 10}, End: CharLocation{Line: 1, Column: 14}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 17}, End: CharLocation{Line: 1, Column: 26}},
+							},
+							"port": {
+								Type:          Int,
+								Value:         8080,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 10}, End: CharLocation{Line: 2, Column: 14}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 17}, End: CharLocation{Line: 2, Column: 21}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 7}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 8}, End: CharLocation{Line: 2, Column: 23}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Substitutions, concatenation and +=
+			input: shortHoconConfig1,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"base": {
+						Type:          String,
+						Value:         "/usr",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 8}, End: CharLocation{Line: 1, Column: 12}},
+					},
+					"dirs": {
+						Type: Array,
+						Value: []*Node{
+							{
+								Type:          String,
+								Value:         "/usr",
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 9}, End: CharLocation{Line: 3, Column: 16}},
+							},
+							{
+								Type:          String,
+								Value:         "/opt",
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 9}, End: CharLocation{Line: 4, Column: 13}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 9}, End: CharLocation{Line: 4, Column: 13}},
+					},
+					"path": {
+						Type:          String,
+						Value:         "/usr/bin",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 8}, End: CharLocation{Line: 2, Column: 19}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Optional and self-referential substitutions
+			input: shortHoconConfig2,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"ports": {
+						Type: Array,
+						Value: []*Node{
+							{
+								Type:          Int,
+								Value:         80,
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 10}, End: CharLocation{Line: 3, Column: 12}},
+							},
+							{
+								Type:          Int,
+								Value:         443,
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 19}, End: CharLocation{Line: 4, Column: 22}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 6}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 9}, End: CharLocation{Line: 4, Column: 23}},
+					},
+					"timeout": {
+						Type:          Int,
+						Value:         10,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 11}, End: CharLocation{Line: 1, Column: 13}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Root braces and quoted keys
+			input: shortHoconConfig3,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"quoted.key": {
+						Type:          Int,
+						Value:         1,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 2}, End: CharLocation{Line: 2, Column: 14}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 17}, End: CharLocation{Line: 2, Column: 18}},
+					},
+					"unquoted": {
+						Type: Object,
+						Value: map[string]*Node{
+							"key": {
+								Type:          Null,
+								Value:         nil,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 2}, End: CharLocation{Line: 3, Column: 14}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 17}, End: CharLocation{Line: 3, Column: 21}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 2}, End: CharLocation{Line: 3, Column: 14}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 2}, End: CharLocation{Line: 3, Column: 14}},
+					},
+				},
+				ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 4, Column: 2}},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &hoconParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestHighLevelErrorConditions_hoconParser(t *testing.T) {
+	// Input
+	var hlErrHoconConfig0 = []byte(`
+	a = ${missing}
+	`)
+
+	var hlErrHoconConfig1 = []byte(`
+	a = ${b}
+	b = ${a}
+	`)
+
+	var hlErrHoconConfig2 = []byte(`
+	a = [1] { b = 1 }
+	`)
+
+	var hlErrHoconConfig3 = []byte(`
+	a = ${a} [1]
+	`)
+
+	var hlErrHoconConfig4 = []byte(`
+	include required(file("test_configs/missing.conf"))
+	`)
+
+	var hlErrHoconConfig5 = []byte(`
+	include url("http://example.com/app.conf")
+	`)
+
+	var hlErrHoconConfig6 = []byte(`
+	a..b = 1
+	`)
+
+	var hlErrHoconConfig7 = []byte(`
+	a = 1e400
+	`)
+
+	testCases := []hoconParserTestCase{
+		{
+			input:    hlErrHoconConfig0,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "could not resolve substitution '${missing}'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig1,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "substitution cycle detected",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig2,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "cannot concatenate array and object values",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig3,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "substitution '${a}' refers to a field with no previous value",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig4,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "could not read included file 'test_configs/missing.conf': open test_configs/missing.conf: no such file or directory",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 52},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig5,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "only file includes are supported",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 43},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig6,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "invalid path expression: 'a..b'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			input:    hlErrHoconConfig7,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "invalid number '1e400': value out of range",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &hoconParser{}
+		_, errs := parser.Parse(test.input)
+
+		if len(errs) == 0 {
+			t.Errorf("Expected errors, got none, Input: %s", test.input)
+		} else if !reflect.DeepEqual(test.expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v, Input: %s", test.expectedErrs, errs, test.input)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// iniParser parses INI files. Sections and dotted keys are mapped
+// into nested objects, and keys ending in [] are collected into arrays.
+type iniParser struct {
+	configFile      *Node
+	section         *Node
+	definedSections map[string]bool
+	errs            []CMParserError
+}
+
+// Custom INI parser
+func (p *iniParser) Parse(data []byte) (*Node, []CMParserError) {
+	// Initialize config file to an object
+	p.configFile = &Node{
+		Type:  Object,
+		Value: map[string]*Node{},
+	}
+
+	// Keys before the first section belong to the root
+	p.section = p.configFile
+	p.definedSections = make(map[string]bool)
+	p.errs = nil
+
+	for _, line := range readContinuedLines(data, ";#") {
+		start := line.skipSpaces(0)
+
+		// Skip empty lines and comments
+		if start == len(line.text) || line.text[start] == ';' || line.text[start] == '#' {
+			continue
+		}
+
+		if line.text[start] == '[' {
+			p.parseSection(line, start)
+		} else {
+			p.parseKeyValue(line, start)
+		}
+	}
+
+	// Check for errors
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	return p.configFile, nil
+}
+
+// parseSection parses a section header (e.g. [server.http]).
+func (p *iniParser) parseSection(line *kvLine, start int) {
+	// Find closing bracket
+	end := start + 1
+	for end < len(line.text) && line.text[end] != ']' {
+		end++
+	}
+	if end == len(line.text) {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "missing closing ']' in section header",
+			Location: line.span(start, end),
+		})
+		return
+	}
+
+	// Only comments can follow the section header
+	rest := line.skipSpaces(end + 1)
+	if rest < len(line.text) && line.text[rest] != ';' && line.text[rest] != '#' {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "unexpected characters after section header",
+			Location: line.span(rest, len(line.text)),
+		})
+		return
+	}
+
+	// Parse section name
+	nameStart := line.skipSpaces(start + 1)
+	nameEnd := line.trimSpacesRight(nameStart, end)
+	sectionKey, err := p.parseKey(string(line.text[nameStart:nameEnd]))
+	if err != nil {
+		p.errs = append(p.errs, CMParserError{
+			Message:  err.Error(),
+			Location: line.span(start, end+1),
+		})
+		return
+	}
+
+	// Check if section was already defined
+	if p.definedSections[sectionKey.String()] {
+		p.errs = append(p.errs, CMParserError{
+			Message:  fmt.Errorf("can't redefine existing section: '%s'", sectionKey.String()).Error(),
+			Location: line.span(start, end+1),
+		})
+		return
+	}
+	p.definedSections[sectionKey.String()] = true
+
+	// Get or create section object
+	section, err := getOrCreateObject(p.configFile, sectionKey)
+	if err != nil {
+		p.errs = append(p.errs, CMParserError{
+			Message:  err.Error(),
+			Location: line.span(start, end+1),
+		})
+		return
+	}
+
+	// Section headers don't have a value, using name location to
+	// guarantee better display result in case this is used
+	section.NameLocation = line.span(start, end+1)
+	section.ValueLocation = section.NameLocation
+
+	p.section = section
+}
+
+// parseKeyValue parses a key-value pair (e.g. host = localhost).
+func (p *iniParser) parseKeyValue(line *kvLine, start int) {
+	// Find separator
+	separator := start
+	for separator < len(line.text) && line.text[separator] != '=' && line.text[separator] != ':' {
+		separator++
+	}
+
+	// Parse key
+	keyEnd := line.trimSpacesRight(start, separator)
+	keyText := string(line.text[start:keyEnd])
+	appending := strings.HasSuffix(keyText, "[]")
+	fieldKey, err := p.parseKey(strings.TrimSuffix(keyText, "[]"))
+	if err != nil {
+		p.errs = append(p.errs, CMParserError{
+			Message:  err.Error(),
+			Location: line.span(start, keyEnd),
+		})
+		return
+	}
+
+	node := &Node{NameLocation: line.span(start, keyEnd)}
+	if separator == len(line.text) {
+		// Keys without value
+		node.Type = Null
+		node.Value = nil
+		node.ValueLocation = node.NameLocation
+	} else {
+		// Parse value
+		valueNode, err := p.parseValue(line, line.skipSpaces(separator+1))
+		if err != nil {
+			p.errs = append(p.errs, *err)
+			return
+		}
+
+		node.Type = valueNode.Type
+		node.Value = valueNode.Value
+		node.ValueLocation = valueNode.ValueLocation
+	}
+
+	// Add field to current section
+	if err := setField(p.section, fieldKey, node, appending); err != nil {
+		p.errs = append(p.errs, CMParserError{
+			Message:  err.Error(),
+			Location: node.NameLocation,
+		})
+	}
+}
+
+// parseValue parses the value starting at start. Quoted values are
+// strings, where escapes are processed in double quotes. Unquoted values
+// are taken as they are and can end with a comment.
+func (p *iniParser) parseValue(line *kvLine, start int) (*Node, *CMParserError) {
+	// Quoted values
+	if start < len(line.text) && (line.text[start] == '"' || line.text[start] == '\'') {
+		quote := line.text[start]
+		value := []rune{}
+		end := start + 1
+		for ; end < len(line.text) && line.text[end] != quote; end++ {
+			if line.text[end] == '\\' && quote == '"' && end+1 < len(line.text) {
+				end++
+				value = append(value, unescapeChar(line.text[end]))
+				continue
+			}
+
+			value = append(value, line.text[end])
+		}
+
+		if end == len(line.text) {
+			return nil, &CMParserError{
+				Message:  "missing closing quote in value",
+				Location: line.span(start, end),
+			}
+		}
+
+		// Only comments can follow quoted values
+		rest := line.skipSpaces(end + 1)
+		if rest < len(line.text) && line.text[rest] != ';' && line.text[rest] != '#' {
+			return nil, &CMParserError{
+				Message:  "unexpected characters after quoted value",
+				Location: line.span(rest, len(line.text)),
+			}
+		}
+
+		return &Node{Type: String, Value: string(value), ValueLocation: line.span(start, end+1)}, nil
+	}
+
+	// Unquoted values end at comments preceded by whitespace. Backslashes
+	// are kept, so paths like C:\new aren't changed by escapes.
+	end := start
+	for ; end < len(line.text); end++ {
+		if (line.text[end] == ';' || line.text[end] == '#') && end > 0 &&
+			(line.text[end-1] == ' ' || line.text[end-1] == '\t') {
+			break
+		}
+	}
+
+	// Remove trailing whitespace
+	end = line.trimSpacesRight(start, end)
+
+	node := inferScalar(string(line.text[start:end]))
+	node.ValueLocation = line.span(start, end)
+	return node, nil
+}
+
+// parseKey splits a key into its dotted segments.
+func (p *iniParser) parseKey(key string) (NodeKey, error) {
+	segments := strings.Split(key, ".")
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(segment)
+		if segments[i] == "" {
+			return NodeKey{}, fmt.Errorf("invalid key: '%s'", key)
+		}
+	}
+
+	return NodeKey{Segments: segments}, nil
+}
+
+// unescapeChar returns the character represented by an escape sequence.
+func unescapeChar(c rune) rune {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case 'f':
+		return '\f'
+	case '0':
+		return 0
+	default: // \\, \", \', \;, \#, \=, \: and any other character
+		return c
+	}
+}
@@ -0,0 +1,445 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+type iniParserTestCase struct {
+	input        []byte
+	expected     *Node
+	expectedErrs []CMParserError
+}
+
+func TestParseSimpleConfig_iniParser(t *testing.T) {
+	// Input
+	testConfig, err := os.ReadFile("./test_configs/simple.ini")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// Test cases
+	testCases := []iniParserTestCase{
+		{
+			input: testConfig,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"database_settings": {
+						Type: Object,
+						Value: map[string]*Node{
+							"host": {
+								Type:          String,
+								Value:         "192.168.1.100",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 8, Column: 0}, End: CharLocation{Line: 8, Column: 4}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 7}, End: CharLocation{Line: 8, Column: 20}},
+							},
+							"max_connections": {
+								Type:          Int,
+								Value:         5000,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 12, Column: 0}, End: CharLocation{Line: 12, Column: 15}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 12, Column: 18}, End: CharLocation{Line: 12, Column: 22}},
+							},
+							"port": {
+								Type:          Int,
+								Value:         5432,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 9, Column: 0}, End: CharLocation{Line: 9, Column: 4}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 7}, End: CharLocation{Line: 9, Column: 11}},
+							},
+							"ports": {
+								Type: Array,
+								Value: []*Node{
+									{
+										Type:          Int,
+										Value:         8005,
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 10}, End: CharLocation{Line: 10, Column: 14}},
+									},
+									{
+										Type:          Int,
+										Value:         8006,
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 11, Column: 10}, End: CharLocation{Line: 11, Column: 14}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 10, Column: 0}, End: CharLocation{Line: 10, Column: 7}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 10}, End: CharLocation{Line: 11, Column: 14}},
+							},
+							"replica": {
+								Type: Object,
+								Value: map[string]*Node{
+									"host": {
+										Type:          String,
+										Value:         "10.0.0.2",
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 16, Column: 0}, End: CharLocation{Line: 16, Column: 4}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 16, Column: 7}, End: CharLocation{Line: 16, Column: 15}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 15, Column: 0}, End: CharLocation{Line: 15, Column: 27}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 15, Column: 0}, End: CharLocation{Line: 15, Column: 27}},
+							},
+							"timeout": {
+								Type:          Float,
+								Value:         2.5,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 13, Column: 0}, End: CharLocation{Line: 13, Column: 7}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 13, Column: 10}, End: CharLocation{Line: 13, Column: 13}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 7, Column: 0}, End: CharLocation{Line: 7, Column: 19}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 7, Column: 0}, End: CharLocation{Line: 7, Column: 19}},
+					},
+					"project_lead": {
+						Type: Object,
+						Value: map[string]*Node{
+							"active": {
+								Type:          Bool,
+								Value:         true,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 5, Column: 0}, End: CharLocation{Line: 5, Column: 6}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 5, Column: 9}, End: CharLocation{Line: 5, Column: 13}},
+							},
+							"name": {
+								Type:          String,
+								Value:         "Charlie Example",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 0}, End: CharLocation{Line: 4, Column: 4}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 7}, End: CharLocation{Line: 4, Column: 24}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 0}, End: CharLocation{Line: 3, Column: 14}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 0}, End: CharLocation{Line: 3, Column: 14}},
+					},
+					"title": {
+						Type:          String,
+						Value:         "INI Sample Configuration",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 8}, End: CharLocation{Line: 1, Column: 32}},
+					},
+				},
+			},
+			expectedErrs: []CMParserError{},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &iniParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v", test.expected, result)
+		}
+	}
+}
+
+func TestShortSamples_iniParser(t *testing.T) {
+	// Input
+	var shortIniConfig0 = []byte(`
+	name = app
+	server.host: localhost
+	server.port = 8080
+	verbose
+	`)
+
+	var shortIniConfig1 = []byte(`
+	[paths]
+	home = "C:\\Users\\app" ; user home
+	pattern = 'a\nb'
+	message = first \
+			second
+	hash = a#b # comment
+	dir = C:\new\table
+	`)
+
+	var shortIniConfig2 = []byte(`
+	[server]
+	hosts[] = a
+	hosts[] = b
+	[server.tls]
+	enabled = true
+	`)
+
+	testCases := []iniParserTestCase{
+		{ // Root keys, dotted keys and keys without value
+			input: shortIniConfig0,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"name": {
+						Type:          String,
+						Value:         "app",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 8}, End: CharLocation{Line: 1, Column: 11}},
+					},
+					"server": {
+						Type: Object,
+						Value: map[string]*Node{
+							"host": {
+								Type:          String,
+								Value:         "localhost",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 12}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 14}, End: CharLocation{Line: 2, Column: 23}},
+							},
+							"port": {
+								Type:          Int,
+								Value:         8080,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 12}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 15}, End: CharLocation{Line: 3, Column: 19}},
+							},
+						},
+					},
+					"verbose": {
+						Type:          Null,
+						Value:         nil,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 8}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Quoted values, escapes and continuation lines
+			input: shortIniConfig1,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"paths": {
+						Type: Object,
+						Value: map[string]*Node{
+							"dir": {
+								Type:          String,
+								Value:         "C:\\new\\table",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 7, Column: 1}, End: CharLocation{Line: 7, Column: 4}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 7, Column: 7}, End: CharLocation{Line: 7, Column: 19}},
+							},
+							"hash": {
+								Type:          String,
+								Value:         "a#b",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 1}, End: CharLocation{Line: 6, Column: 5}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 6, Column: 8}, End: CharLocation{Line: 6, Column: 11}},
+							},
+							"home": {
+								Type:          String,
+								Value:         "C:\\Users\\app",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 5}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 8}, End: CharLocation{Line: 2, Column: 24}},
+							},
+							"message": {
+								Type:          String,
+								Value:         "first second",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 8}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 11}, End: CharLocation{Line: 5, Column: 9}},
+							},
+							"pattern": {
+								Type:          String,
+								Value:         "a\\nb",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 8}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 11}, End: CharLocation{Line: 3, Column: 17}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 8}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Arrays and nested sections
+			input: shortIniConfig2,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"server": {
+						Type: Object,
+						Value: map[string]*Node{
+							"hosts": {
+								Type: Array,
+								Value: []*Node{
+									{
+										Type:          String,
+										Value:         "a",
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 11}, End: CharLocation{Line: 2, Column: 12}},
+									},
+									{
+										Type:          String,
+										Value:         "b",
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 11}, End: CharLocation{Line: 3, Column: 12}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 8}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 11}, End: CharLocation{Line: 3, Column: 12}},
+							},
+							"tls": {
+								Type: Object,
+								Value: map[string]*Node{
+									"enabled": {
+										Type:          Bool,
+										Value:         true,
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 5, Column: 1}, End: CharLocation{Line: 5, Column: 8}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 5, Column: 11}, End: CharLocation{Line: 5, Column: 15}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 13}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 13}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 9}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 9}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &iniParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestErrorConditions_iniParser(t *testing.T) {
+	// Input
+	var errIniConfig0 = []byte(`
+	a = 1
+	a = 2
+	`)
+
+	var errIniConfig1 = []byte(`
+	[server]
+	[server]
+	`)
+
+	var errIniConfig2 = []byte(`
+	[server
+	`)
+
+	var errIniConfig3 = []byte(`
+	a = "x" y
+	`)
+
+	var errIniConfig4 = []byte(`
+	a = 1
+	a.b = 2
+	`)
+
+	var errIniConfig5 = []byte(`
+	a..b = 1
+	`)
+
+	var errIniConfig6 = []byte(`
+	a = "x
+	`)
+
+	testCases := []iniParserTestCase{
+		{
+			input:    errIniConfig0,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "can't redefine existing key: 'a'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 1},
+						End:   CharLocation{Line: 2, Column: 2},
+					},
+				},
+			},
+		},
+		{
+			input:    errIniConfig1,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "can't redefine existing section: 'server'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 1},
+						End:   CharLocation{Line: 2, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			input:    errIniConfig2,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "missing closing ']' in section header",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 8},
+					},
+				},
+			},
+		},
+		{
+			input:    errIniConfig3,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "unexpected characters after quoted value",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 9},
+						End:   CharLocation{Line: 1, Column: 10},
+					},
+				},
+			},
+		},
+		{
+			input:    errIniConfig4,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "can't redefine existing key: 'a'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 1},
+						End:   CharLocation{Line: 2, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			input:    errIniConfig5,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "invalid key: 'a..b'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			input:    errIniConfig6,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "missing closing quote in value",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 7},
+					},
+				},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &iniParser{}
+		_, errs := parser.Parse(test.input)
+
+		if len(errs) == 0 {
+			t.Errorf("Expected errors, got none, Input: %s", test.input)
+		} else if !reflect.DeepEqual(test.expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v, Input: %s", test.expectedErrs, errs, test.input)
+		}
+	}
+}
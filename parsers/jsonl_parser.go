@@ -0,0 +1,84 @@
+package parsers
+
+import (
+	"strings"
+)
+
+// jsonLinesParser parses JSON Lines files, where each line is a JSON
+// document. Blank lines are ignored.
+type jsonLinesParser struct{}
+
+// Custom JSON Lines parser, the documents are the elements of the root array
+func (p *jsonLinesParser) Parse(data []byte) (*Node, []CMParserError) {
+	documents, errs := p.ParseDocuments(data)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	configFile := &Node{Type: Array, Value: documents}
+	if len(documents) > 0 {
+		configFile.ValueLocation = TokenLocation{
+			Start: documents[0].ValueLocation.Start,
+			End:   documents[len(documents)-1].ValueLocation.End,
+		}
+	}
+
+	return configFile, nil
+}
+
+// ParseDocuments parses each line as a separate document.
+func (p *jsonLinesParser) ParseDocuments(data []byte) ([]*Node, []CMParserError) {
+	documents := []*Node{}
+	errs := []CMParserError{}
+	for lineNumber, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Each line is parsed on its own, so locations must be moved to the line
+		document, lineErrs := (&jsonParser{}).Parse([]byte(line))
+		for _, err := range lineErrs {
+			err.Location = shiftLocation(err.Location, lineNumber)
+			errs = append(errs, err)
+		}
+		if len(lineErrs) == 0 {
+			documents = append(documents, shiftNodeLocations(document, lineNumber))
+		}
+	}
+
+	// Check for errors
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return documents, nil
+}
+
+// shiftNodeLocations moves the locations of a node and its children down by lines.
+func shiftNodeLocations(node *Node, lines int) *Node {
+	// Nodes without name (root and array elements) keep an empty name location
+	if node.NameLocation != (TokenLocation{}) {
+		node.NameLocation = shiftLocation(node.NameLocation, lines)
+	}
+	node.ValueLocation = shiftLocation(node.ValueLocation, lines)
+
+	switch node.Type {
+	case Object:
+		for _, child := range node.Value.(map[string]*Node) {
+			shiftNodeLocations(child, lines)
+		}
+	case Array:
+		for _, child := range node.Value.([]*Node) {
+			shiftNodeLocations(child, lines)
+		}
+	}
+
+	return node
+}
+
+// shiftLocation moves a location down by lines.
+func shiftLocation(location TokenLocation, lines int) TokenLocation {
+	location.Start.Line += lines
+	location.End.Line += lines
+	return location
+}
@@ -0,0 +1,85 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+)
+
+type jsonLinesParserTestCase struct {
+	input        []byte
+	expected     []*Node
+	expectedErrs []CMParserError
+}
+
+func TestParseDocuments_jsonLinesParser(t *testing.T) {
+	// Test cases
+	testCases := []jsonLinesParserTestCase{
+		{ // blank lines are ignored
+			input: []byte("{\"name\": \"app\"}\n\n{\"name\": \"worker\", \"replicas\": 2}\n"),
+			expected: []*Node{
+				{
+					Type: Object,
+					Value: map[string]*Node{
+						"name": {
+							Type:          String,
+							Value:         "app",
+							NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 1}, End: CharLocation{Line: 0, Column: 7}},
+							ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 9}, End: CharLocation{Line: 0, Column: 14}},
+						},
+					},
+					ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 15}},
+				},
+				{
+					Type: Object,
+					Value: map[string]*Node{
+						"name": {
+							Type:          String,
+							Value:         "worker",
+							NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 7}},
+							ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 9}, End: CharLocation{Line: 2, Column: 17}},
+						},
+						"replicas": {
+							Type:          Int,
+							Value:         2,
+							NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 19}, End: CharLocation{Line: 2, Column: 29}},
+							ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 31}, End: CharLocation{Line: 2, Column: 32}},
+						},
+					},
+					ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 0}, End: CharLocation{Line: 2, Column: 33}},
+				},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &jsonLinesParser{}
+		result, errs := parser.ParseDocuments(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestErrorConditions_jsonLinesParser(t *testing.T) {
+	// Input
+	var errJsonLinesConfig = []byte("{\"name\": \"app\"}\n{\"name\": }\n")
+
+	// Run test
+	parser := &jsonLinesParser{}
+	_, errs := parser.ParseDocuments(errJsonLinesConfig)
+
+	// Errors must be located in the line of the document
+	if len(errs) == 0 {
+		t.Errorf("Expected errors, got none")
+	}
+	for _, err := range errs {
+		if err.Location.Start.Line != 1 {
+			t.Errorf("Expected error in line 1, got %#v", err)
+		}
+	}
+}
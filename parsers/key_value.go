@@ -0,0 +1,277 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Helpers shared by the line based formats (ini, properties and env),
+// where every line holds a key and a value without type information.
+
+var kvIntRegex = regexp.MustCompile(`^[+-]?[0-9]+$`)
+var kvFloatRegex = regexp.MustCompile(`^[+-]?([0-9]+\.[0-9]*|\.[0-9]+|[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// kvLine is a logical line, made of one or more physical lines.
+// Each character keeps its location in the file.
+type kvLine struct {
+	text      []rune
+	locations []CharLocation
+	end       CharLocation // Location after the last character
+}
+
+// appendLine adds a physical line to the logical line.
+func (l *kvLine) appendLine(line []rune, lineNumber, startColumn int) {
+	for i := startColumn; i < len(line); i++ {
+		l.text = append(l.text, line[i])
+		l.locations = append(l.locations, CharLocation{Line: lineNumber, Column: i})
+	}
+	l.end = CharLocation{Line: lineNumber, Column: len(line)}
+}
+
+// appendNewline adds a line break between two physical lines.
+func (l *kvLine) appendNewline() {
+	l.text = append(l.text, '\n')
+	l.locations = append(l.locations, l.end)
+}
+
+// span returns the location of the characters in [start, end).
+func (l *kvLine) span(start, end int) TokenLocation {
+	if start >= len(l.text) {
+		return TokenLocation{Start: l.end, End: l.end}
+	}
+
+	if end <= start {
+		return TokenLocation{Start: l.locations[start], End: l.locations[start]}
+	}
+
+	last := l.locations[end-1]
+	return TokenLocation{
+		Start: l.locations[start],
+		End:   CharLocation{Line: last.Line, Column: last.Column + 1},
+	}
+}
+
+// skipSpaces returns the index of the first non whitespace character from start.
+func (l *kvLine) skipSpaces(start int) int {
+	for start < len(l.text) && (l.text[start] == ' ' || l.text[start] == '\t' || l.text[start] == '\f') {
+		start++
+	}
+
+	return start
+}
+
+// trimSpacesRight returns the index after the last non whitespace character before end.
+func (l *kvLine) trimSpacesRight(start, end int) int {
+	for end > start && (l.text[end-1] == ' ' || l.text[end-1] == '\t' || l.text[end-1] == '\f') {
+		end--
+	}
+
+	return end
+}
+
+// splitPhysicalLines splits content into lines without line terminators.
+func splitPhysicalLines(content []byte) [][]rune {
+	lines := [][]rune{}
+	for _, line := range strings.Split(string(content), "\n") {
+		lines = append(lines, []rune(strings.TrimSuffix(line, "\r")))
+	}
+
+	return lines
+}
+
+// readContinuedLines splits content into logical lines. A line ending with an
+// odd number of backslashes continues on the next one. The backslash and the
+// leading whitespace of the next line are not part of the logical line.
+// Comments, lines starting with one of commentChars, never continue.
+func readContinuedLines(content []byte, commentChars string) []*kvLine {
+	physicalLines := splitPhysicalLines(content)
+
+	lines := []*kvLine{}
+	for index := 0; index < len(physicalLines); index++ {
+		line := &kvLine{end: CharLocation{Line: index, Column: 0}}
+		if isCommentLine(physicalLines[index], commentChars) {
+			line.appendLine(physicalLines[index], index, 0)
+			lines = append(lines, line)
+			continue
+		}
+
+		startColumn := 0
+		for {
+			physicalLine := physicalLines[index]
+			if !endsWithContinuation(physicalLine) || index == len(physicalLines)-1 {
+				line.appendLine(physicalLine, index, startColumn)
+				break
+			}
+
+			// Remove backslash and continue on next line
+			line.appendLine(physicalLine[:len(physicalLine)-1], index, startColumn)
+			index++
+			startColumn = 0
+			for startColumn < len(physicalLines[index]) &&
+				(physicalLines[index][startColumn] == ' ' || physicalLines[index][startColumn] == '\t' || physicalLines[index][startColumn] == '\f') {
+				startColumn++
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// isCommentLine checks if the first non whitespace character of a line is one of commentChars.
+func isCommentLine(line []rune, commentChars string) bool {
+	for _, c := range line {
+		if c != ' ' && c != '\t' && c != '\f' {
+			return strings.ContainsRune(commentChars, c)
+		}
+	}
+
+	return false
+}
+
+// endsWithContinuation checks if a line ends with an odd number of backslashes.
+func endsWithContinuation(line []rune) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+
+	return count%2 == 1
+}
+
+// inferScalar creates a node from an unquoted value, recognizing
+// integers, floats and booleans. Anything else is a string.
+func inferScalar(text string) *Node {
+	if kvIntRegex.MatchString(text) {
+		if value, err := strconv.Atoi(text); err == nil {
+			return &Node{Type: Int, Value: value}
+		}
+	}
+
+	if kvFloatRegex.MatchString(text) {
+		if value, err := strconv.ParseFloat(text, 64); err == nil {
+			return &Node{Type: Float, Value: value}
+		}
+	}
+
+	if strings.EqualFold(text, "true") {
+		return &Node{Type: Bool, Value: true}
+	} else if strings.EqualFold(text, "false") {
+		return &Node{Type: Bool, Value: false}
+	}
+
+	return &Node{Type: String, Value: text}
+}
+
+// getOrCreateObject gets the object at the given path from parent,
+// creating the missing objects on the way.
+func getOrCreateObject(parent *Node, key NodeKey) (*Node, error) {
+	current := parent
+	for index, segment := range key.Segments {
+		objMap := current.Value.(map[string]*Node)
+
+		next, exists := objMap[segment]
+		if !exists {
+			next = &Node{Type: Object, Value: map[string]*Node{}}
+			objMap[segment] = next
+		} else if next.Type != Object {
+			return nil, fmt.Errorf("can't redefine existing key: '%s'", (&NodeKey{Segments: key.Segments[:index+1]}).String())
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
+
+// setField adds a field at the given path from parent. If appending,
+// the field is an array and the node is added to it.
+func setField(parent *Node, key NodeKey, node *Node, appending bool) error {
+	// Get parent object of the field
+	object, err := getOrCreateObject(parent, NodeKey{Segments: key.Segments[:len(key.Segments)-1]})
+	if err != nil {
+		return err
+	}
+
+	objMap := object.Value.(map[string]*Node)
+	name := key.Segments[len(key.Segments)-1]
+	existing, exists := objMap[name]
+
+	if appending {
+		if !exists {
+			objMap[name] = &Node{
+				Type:          Array,
+				Value:         []*Node{},
+				NameLocation:  node.NameLocation,
+				ValueLocation: node.ValueLocation,
+			}
+		} else if existing.Type != Array {
+			return fmt.Errorf("can't redefine existing key: '%s'", key.String())
+		}
+
+		// Elements don't have names
+		array := objMap[name]
+		element := &Node{Type: node.Type, Value: node.Value, ValueLocation: node.ValueLocation}
+		array.Value = append(array.Value.([]*Node), element)
+		array.ValueLocation.End = node.ValueLocation.End
+		return nil
+	}
+
+	if exists {
+		return fmt.Errorf("can't redefine existing key: '%s'", key.String())
+	}
+
+	objMap[name] = node
+	return nil
+}
+
+// setFlatField adds a field of a format without sections, where the keys
+// are flat (properties and env). The last definition of a key wins, and a
+// key can also be a prefix of other keys, e.g. logging.level and
+// logging.level.root. Such a key can't be both a value and an object, so the
+// key found later is kept flat in the root object, e.g. 'logging.level.root'.
+func setFlatField(root *Node, key NodeKey, node *Node) {
+	rootMap := root.Value.(map[string]*Node)
+	flatKey := strings.Join(key.Segments, ".")
+
+	object, err := getOrCreateObject(root, NodeKey{Segments: key.Segments[:len(key.Segments)-1]})
+	if err != nil {
+		// A prefix of the key is a value
+		rootMap[flatKey] = node
+		return
+	}
+
+	objMap := object.Value.(map[string]*Node)
+	name := key.Segments[len(key.Segments)-1]
+	existing, exists := objMap[name]
+	if !exists || existing.Type != Object {
+		objMap[name] = node
+		return
+	}
+
+	// The key is a prefix of other keys
+	if len(key.Segments) > 1 {
+		rootMap[flatKey] = node
+		return
+	}
+
+	// The flat key is the object itself, so its keys are kept flat instead
+	delete(rootMap, name)
+	flattenObject(rootMap, name, existing)
+	rootMap[name] = node
+}
+
+// flattenObject adds the values in object to objMap, with their keys
+// prefixed by the key of the object.
+func flattenObject(objMap map[string]*Node, prefix string, object *Node) {
+	for name, child := range object.Value.(map[string]*Node) {
+		if child.Type == Object {
+			flattenObject(objMap, prefix+"."+name, child)
+		} else {
+			objMap[prefix+"."+name] = child
+		}
+	}
+}
@@ -2,25 +2,79 @@ package parsers
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 )
 
 type Parser interface {
 	Parse(content []byte) (*Node, []CMParserError)
 }
 
+// MultiDocumentParser is a parser for formats that can hold multiple
+// documents in a single file, e.g. '---' separated YAML or JSON Lines.
+type MultiDocumentParser interface {
+	Parser
+	ParseDocuments(content []byte) ([]*Node, []CMParserError)
+}
+
+// FileParser is a parser for formats whose files can include other files,
+// e.g. HOCON, which are found relative to the directory of the file parsed.
+type FileParser interface {
+	Parser
+	ParseFile(path string, content []byte) (*Node, []CMParserError)
+}
+
+// ParseFile parses the content of the file at path, passing the path
+// to the parser if it includes other files.
+func ParseFile(parser Parser, path string, content []byte) (*Node, []CMParserError) {
+	if fileParser, ok := parser.(FileParser); ok {
+		return fileParser.ParseFile(path, content)
+	}
+
+	return parser.Parse(content)
+}
+
 type ParserProvider interface {
 	GetParser(format string) (Parser, error)
+	DetectFormat(path string, content []byte) (string, error)
 }
 
 type parserProviderImpl struct {
 	parsers map[string]Parser
 }
 
+// formatExtensions maps file extensions to formats.
+var formatExtensions = map[string]string{
+	".json":       "json",
+	".jsonl":      "jsonl",
+	".ndjson":     "jsonl",
+	".toml":       "toml",
+	".yaml":       "yaml",
+	".yml":        "yaml",
+	".conf":       "hocon",
+	".hocon":      "hocon",
+	".ini":        "ini",
+	".properties": "properties",
+	".env":        "env",
+	".xml":        "xml",
+}
+
+// sniffedFormats are the formats that can be recognized from the
+// content of a file. The rest are too permissive to tell them apart.
+var sniffedFormats = []string{"json", "toml", "xml", "yaml"}
+
 func NewParserProvider() ParserProvider {
 	return &parserProviderImpl{
 		parsers: map[string]Parser{
-			"json": &jsonParser{},
-			"toml": &tomlParser{},
+			"json":       &jsonParser{},
+			"jsonl":      &jsonLinesParser{},
+			"toml":       &tomlParser{},
+			"yaml":       &yamlParser{},
+			"hocon":      &hoconParser{},
+			"ini":        &iniParser{},
+			"properties": &propertiesParser{},
+			"env":        &envParser{},
+			"xml":        &xmlParser{},
 		},
 	}
 }
@@ -33,3 +87,43 @@ func (p *parserProviderImpl) GetParser(format string) (Parser, error) {
 
 	return parser, nil
 }
+
+// DetectFormat detects the format of a file from its extension. If the
+// extension is unknown, the content is parsed with the formats that can be
+// recognized, and the format is detected if exactly one of them succeeds.
+func (p *parserProviderImpl) DetectFormat(path string, content []byte) (string, error) {
+	// Dotenv files are usually named .env, .env.local, etc.
+	base := filepath.Base(path)
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return "env", nil
+	}
+
+	if format, ok := formatExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+		return format, nil
+	}
+
+	// Find formats that can parse the content into an object
+	candidates := []string{}
+	isJson := false
+	for _, format := range sniffedFormats {
+		// JSON is a subset of YAML, so JSON is preferred
+		if format == "yaml" && isJson {
+			continue
+		}
+
+		node, errs := p.parsers[format].Parse(content)
+		if len(errs) == 0 && node != nil && node.Type == Object {
+			candidates = append(candidates, format)
+			isJson = isJson || format == "json"
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("could not detect format of '%s', specify it after the file path", path)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("format of '%s' is ambiguous, it could be any of: %s; specify it after the file path", path, strings.Join(candidates, ", "))
+	}
+}
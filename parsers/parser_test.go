@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	"testing"
+)
+
+type detectFormatTestCase struct {
+	path           string
+	content        []byte
+	expectedFormat string
+	expectErr      bool
+}
+
+func TestDetectFormatFromExtension_parserProvider(t *testing.T) {
+	testCases := []detectFormatTestCase{
+		{path: "./config.json", expectedFormat: "json"},
+		{path: "./config.TOML", expectedFormat: "toml"},
+		{path: "./config.yml", expectedFormat: "yaml"},
+		{path: "./application.conf", expectedFormat: "hocon"},
+		{path: "./settings.ini", expectedFormat: "ini"},
+		{path: "./app.properties", expectedFormat: "properties"},
+		{path: "./.env", expectedFormat: "env"},
+		{path: "./.env.local", expectedFormat: "env"},
+		{path: "./server.xml", expectedFormat: "xml"},
+	}
+
+	// Run tests
+	provider := NewParserProvider()
+	for _, test := range testCases {
+		format, err := provider.DetectFormat(test.path, test.content)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %s, Path: %s", err, test.path)
+		} else if format != test.expectedFormat {
+			t.Errorf("Expected %s, got %s, Path: %s", test.expectedFormat, format, test.path)
+		}
+	}
+}
+
+func TestDetectFormatFromContent_parserProvider(t *testing.T) {
+	testCases := []detectFormatTestCase{
+		{path: "./config", content: []byte(`{"server": {"port": 80}}`), expectedFormat: "json"},
+		{path: "./config", content: []byte("[server]\nport = 80\n"), expectedFormat: "toml"},
+		{path: "./config", content: []byte("server:\n  port: 80\n"), expectedFormat: "yaml"},
+		{path: "./config", content: []byte("<server><port>80</port></server>"), expectedFormat: "xml"},
+		{path: "./config", content: []byte("just some text"), expectErr: true},
+	}
+
+	// Run tests
+	provider := NewParserProvider()
+	for _, test := range testCases {
+		format, err := provider.DetectFormat(test.path, test.content)
+
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("Expected error, got format %s, Content: %s", format, test.content)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error: %s, Content: %s", err, test.content)
+		} else if format != test.expectedFormat {
+			t.Errorf("Expected %s, got %s, Content: %s", test.expectedFormat, format, test.content)
+		}
+	}
+}
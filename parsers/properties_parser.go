@@ -0,0 +1,150 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// propertiesParser parses Java properties files. Dotted keys are
+// mapped into nested objects.
+type propertiesParser struct {
+	configFile *Node
+	errs       []CMParserError
+}
+
+// Custom properties parser
+func (p *propertiesParser) Parse(data []byte) (*Node, []CMParserError) {
+	// Initialize config file to an object
+	p.configFile = &Node{
+		Type:  Object,
+		Value: map[string]*Node{},
+	}
+	p.errs = nil
+
+	for _, line := range readContinuedLines(data, "#!") {
+		start := line.skipSpaces(0)
+
+		// Skip empty lines and comments
+		if start == len(line.text) || line.text[start] == '#' || line.text[start] == '!' {
+			continue
+		}
+
+		p.parseKeyValue(line, start)
+	}
+
+	// Check for errors
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	return p.configFile, nil
+}
+
+// parseKeyValue parses a key-value pair (e.g. server.port=8080).
+func (p *propertiesParser) parseKeyValue(line *kvLine, start int) {
+	// Key ends at the first unescaped separator or whitespace
+	key := []rune{}
+	keyEnd := start
+	for ; keyEnd < len(line.text); keyEnd++ {
+		c := line.text[keyEnd]
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			break
+		}
+
+		if c == '\\' && keyEnd+1 < len(line.text) {
+			unescaped, next, err := p.unescape(line, keyEnd)
+			if err != nil {
+				p.errs = append(p.errs, *err)
+				return
+			}
+
+			key = append(key, unescaped)
+			keyEnd = next - 1
+			continue
+		}
+
+		key = append(key, c)
+	}
+
+	// Skip separator, which can be surrounded by whitespace
+	valueStart := line.skipSpaces(keyEnd)
+	if valueStart < len(line.text) && (line.text[valueStart] == '=' || line.text[valueStart] == ':') {
+		valueStart = line.skipSpaces(valueStart + 1)
+	}
+
+	// Parse key
+	nameLocation := line.span(start, keyEnd)
+	fieldKey, err := p.parseKey(string(key))
+	if err != nil {
+		p.errs = append(p.errs, CMParserError{
+			Message:  err.Error(),
+			Location: nameLocation,
+		})
+		return
+	}
+
+	// Parse value, which is the rest of the line
+	value := []rune{}
+	for i := valueStart; i < len(line.text); i++ {
+		if line.text[i] == '\\' && i+1 < len(line.text) {
+			unescaped, next, err := p.unescape(line, i)
+			if err != nil {
+				p.errs = append(p.errs, *err)
+				return
+			}
+
+			value = append(value, unescaped)
+			i = next - 1
+			continue
+		}
+
+		value = append(value, line.text[i])
+	}
+
+	node := inferScalar(string(value))
+	node.NameLocation = nameLocation
+	node.ValueLocation = line.span(valueStart, len(line.text))
+
+	// Add field to config file
+	setFlatField(p.configFile, fieldKey, node)
+}
+
+// unescape processes the escape sequence starting at index, returning
+// the resulting character and the index after the sequence.
+func (p *propertiesParser) unescape(line *kvLine, index int) (rune, int, *CMParserError) {
+	c := line.text[index+1]
+	if c != 'u' {
+		return unescapeChar(c), index + 2, nil
+	}
+
+	// Unicode escapes (e.g. é)
+	if index+6 > len(line.text) {
+		return 0, 0, &CMParserError{
+			Message:  "invalid unicode escape sequence",
+			Location: line.span(index, len(line.text)),
+		}
+	}
+
+	code, err := strconv.ParseUint(string(line.text[index+2:index+6]), 16, 32)
+	if err != nil {
+		return 0, 0, &CMParserError{
+			Message:  "invalid unicode escape sequence",
+			Location: line.span(index, index+6),
+		}
+	}
+
+	return rune(code), index + 6, nil
+}
+
+// parseKey splits a key into its dotted segments.
+func (p *propertiesParser) parseKey(key string) (NodeKey, error) {
+	segments := strings.Split(key, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return NodeKey{}, fmt.Errorf("invalid key: '%s'", key)
+		}
+	}
+
+	return NodeKey{Segments: segments}, nil
+}
@@ -0,0 +1,414 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+type propertiesParserTestCase struct {
+	input        []byte
+	expected     *Node
+	expectedErrs []CMParserError
+}
+
+func TestParseSimpleConfig_propertiesParser(t *testing.T) {
+	// Input
+	testConfig, err := os.ReadFile("./test_configs/simple.properties")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// Test cases
+	testCases := []propertiesParserTestCase{
+		{
+			input: testConfig,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"database_settings": {
+						Type: Object,
+						Value: map[string]*Node{
+							"description": {
+								Type:          String,
+								Value:         "Primary database for the project",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 9, Column: 0}, End: CharLocation{Line: 9, Column: 29}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 30}, End: CharLocation{Line: 10, Column: 19}},
+							},
+							"host": {
+								Type:          String,
+								Value:         "192.168.1.100",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 0}, End: CharLocation{Line: 6, Column: 22}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 6, Column: 23}, End: CharLocation{Line: 6, Column: 36}},
+							},
+							"port": {
+								Type:          Int,
+								Value:         5432,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 7, Column: 0}, End: CharLocation{Line: 7, Column: 22}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 7, Column: 23}, End: CharLocation{Line: 7, Column: 27}},
+							},
+							"timeout": {
+								Type:          Float,
+								Value:         2.5,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 8, Column: 0}, End: CharLocation{Line: 8, Column: 25}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 26}, End: CharLocation{Line: 8, Column: 29}},
+							},
+						},
+					},
+					"project_lead": {
+						Type: Object,
+						Value: map[string]*Node{
+							"active": {
+								Type:          Bool,
+								Value:         true,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 0}, End: CharLocation{Line: 4, Column: 19}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 22}, End: CharLocation{Line: 4, Column: 26}},
+							},
+							"name": {
+								Type:          String,
+								Value:         "Charlie Example",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 0}, End: CharLocation{Line: 3, Column: 17}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 20}, End: CharLocation{Line: 3, Column: 35}},
+							},
+						},
+					},
+					"title": {
+						Type:          String,
+						Value:         "Properties Sample Configuration",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 6}, End: CharLocation{Line: 1, Column: 37}},
+					},
+				},
+			},
+			expectedErrs: []CMParserError{},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &propertiesParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v", test.expected, result)
+		}
+	}
+}
+
+func TestShortSamples_propertiesParser(t *testing.T) {
+	// Input
+	var shortPropertiesConfig0 = []byte(`
+	a=1
+	b : two
+	c three
+	d
+	`)
+
+	var shortPropertiesConfig1 = []byte(`
+	key\ with\ spaces = value
+	unicode = caf\u00e9
+	path = C:\\temp
+	tab = a\tb
+	`)
+
+	var shortPropertiesConfig2 = []byte(`
+	server.hosts = a,\
+			b,\
+			c
+	server.port = 8080
+	`)
+
+	var shortPropertiesConfig3 = []byte(`
+	a = 1
+	a = 2
+	`)
+
+	var shortPropertiesConfig4 = []byte(`
+	logging.level = INFO
+	logging.level.org.hibernate = DEBUG
+	`)
+
+	var shortPropertiesConfig5 = []byte(`
+	logging.level.root = WARN
+	logging.level = INFO
+	`)
+
+	var shortPropertiesConfig6 = []byte(`
+	server.port = 80
+	server = on
+	`)
+
+	var shortPropertiesConfig7 = []byte(`
+	# dir C:\tmp\
+	dir = C:\\tmp
+	`)
+
+	testCases := []propertiesParserTestCase{
+		{ // Separators and keys without value
+			input: shortPropertiesConfig0,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"a": {
+						Type:          Int,
+						Value:         1,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 2}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 3}, End: CharLocation{Line: 1, Column: 4}},
+					},
+					"b": {
+						Type:          String,
+						Value:         "two",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 2}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 5}, End: CharLocation{Line: 2, Column: 8}},
+					},
+					"c": {
+						Type:          String,
+						Value:         "three",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 2}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 3}, End: CharLocation{Line: 3, Column: 8}},
+					},
+					"d": {
+						Type:          String,
+						Value:         "",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 2}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 2}, End: CharLocation{Line: 4, Column: 2}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Escapes
+			input: shortPropertiesConfig1,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"key with spaces": {
+						Type:          String,
+						Value:         "value",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 18}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 21}, End: CharLocation{Line: 1, Column: 26}},
+					},
+					"path": {
+						Type:          String,
+						Value:         "C:\\temp",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 1}, End: CharLocation{Line: 3, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 8}, End: CharLocation{Line: 3, Column: 16}},
+					},
+					"tab": {
+						Type:          String,
+						Value:         "a\tb",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 4}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 7}, End: CharLocation{Line: 4, Column: 11}},
+					},
+					"unicode": {
+						Type:          String,
+						Value:         "café",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 11}, End: CharLocation{Line: 2, Column: 20}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Continuation lines and nested keys
+			input: shortPropertiesConfig2,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"server": {
+						Type: Object,
+						Value: map[string]*Node{
+							"hosts": {
+								Type:          String,
+								Value:         "a,b,c",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 13}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 16}, End: CharLocation{Line: 3, Column: 4}},
+							},
+							"port": {
+								Type:          Int,
+								Value:         8080,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 1}, End: CharLocation{Line: 4, Column: 12}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 15}, End: CharLocation{Line: 4, Column: 19}},
+							},
+						},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Duplicate keys, where the last one wins
+			input: shortPropertiesConfig3,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"a": {
+						Type:          Int,
+						Value:         2,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 2}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 5}, End: CharLocation{Line: 2, Column: 6}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Key that is a value and a prefix of a later key, which is kept flat
+			input: shortPropertiesConfig4,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"logging": {
+						Type: Object,
+						Value: map[string]*Node{
+							"level": {
+								Type:          String,
+								Value:         "INFO",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 14}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 17}, End: CharLocation{Line: 1, Column: 21}},
+							},
+						},
+					},
+					"logging.level.org.hibernate": {
+						Type:          String,
+						Value:         "DEBUG",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 28}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 31}, End: CharLocation{Line: 2, Column: 36}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Key that is a prefix of a previous key, which is kept flat
+			input: shortPropertiesConfig5,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"logging": {
+						Type: Object,
+						Value: map[string]*Node{
+							"level": {
+								Type: Object,
+								Value: map[string]*Node{
+									"root": {
+										Type:          String,
+										Value:         "WARN",
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 19}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 22}, End: CharLocation{Line: 1, Column: 26}},
+									},
+								},
+							},
+						},
+					},
+					"logging.level": {
+						Type:          String,
+						Value:         "INFO",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 14}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 17}, End: CharLocation{Line: 2, Column: 21}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Top level key that is a prefix of a previous key, whose keys are kept flat
+			input: shortPropertiesConfig6,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"server": {
+						Type:          String,
+						Value:         "on",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 7}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 10}, End: CharLocation{Line: 2, Column: 12}},
+					},
+					"server.port": {
+						Type:          Int,
+						Value:         80,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 1, Column: 12}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 15}, End: CharLocation{Line: 1, Column: 17}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Comment ending with a backslash, which doesn't continue
+			input: shortPropertiesConfig7,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"dir": {
+						Type:          String,
+						Value:         "C:\\tmp",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 4}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 7}, End: CharLocation{Line: 2, Column: 14}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &propertiesParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestErrorConditions_propertiesParser(t *testing.T) {
+	// Input
+	var errPropertiesConfig0 = []byte(`
+	a = \u00g1
+	`)
+
+	var errPropertiesConfig1 = []byte(`
+	a..b = 1
+	`)
+
+	testCases := []propertiesParserTestCase{
+		{
+			input:    errPropertiesConfig0,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "invalid unicode escape sequence",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 5},
+						End:   CharLocation{Line: 1, Column: 11},
+					},
+				},
+			},
+		},
+		{
+			input:    errPropertiesConfig1,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "invalid key: 'a..b'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 5},
+					},
+				},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &propertiesParser{}
+		_, errs := parser.Parse(test.input)
+
+		if len(errs) == 0 {
+			t.Errorf("Expected errors, got none, Input: %s", test.input)
+		} else if !reflect.DeepEqual(test.expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v, Input: %s", test.expectedErrs, errs, test.input)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// xmlParser parses XML files. Elements are mapped into objects, attributes
+// into '@name' keys and repeated elements into arrays. Elements with only
+// text are mapped into scalars; if they also have attributes or children,
+// the text is kept under the '#text' key.
+type xmlParser struct {
+	data       []byte
+	lineStarts []int
+	stack      []*xmlElement
+	root       *Node
+	errs       []CMParserError
+}
+
+// xmlElement is an element which is still being parsed.
+type xmlElement struct {
+	name      string
+	start     int // Offset of '<'
+	fields    map[string]*Node
+	hasFields bool
+	text      strings.Builder
+	textStart int // Offset of the first non whitespace character of the text
+	textEnd   int // Offset after the last non whitespace character of the text
+}
+
+// Custom XML parser
+func (p *xmlParser) Parse(data []byte) (*Node, []CMParserError) {
+	p.data = data
+	p.stack = nil
+	p.root = nil
+	p.errs = nil
+
+	// Keep the offsets where each line starts, encoding/xml
+	// only provides offsets in bytes
+	p.lineStarts = []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+
+	// Tokens are read raw to keep namespace prefixes as they are
+	// written, which means the parser checks that elements match
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+	for {
+		start := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			p.errs = append(p.errs, p.makeXmlError(err, int(decoder.InputOffset())))
+			return nil, p.errs
+		}
+		end := int(decoder.InputOffset())
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			p.startElement(t, start, end)
+		case xml.EndElement:
+			p.endElement(t, start, end)
+		case xml.CharData:
+			p.charData(t, start, end)
+		}
+
+		// Stop at the first structural error
+		if len(p.errs) > 0 {
+			return nil, p.errs
+		}
+	}
+
+	// Check for unclosed elements
+	if len(p.stack) > 0 {
+		element := p.stack[len(p.stack)-1]
+		return nil, []CMParserError{{
+			Message:  fmt.Sprintf("element <%s> is never closed", element.name),
+			Location: p.span(element.start+1, element.start+1+len(element.name)),
+		}}
+	}
+
+	// Empty document
+	if p.root == nil {
+		return nil, []CMParserError{{
+			Message:  "missing root element",
+			Location: p.span(len(data), len(data)),
+		}}
+	}
+
+	return p.root, nil
+}
+
+// startElement pushes a new element, adding its attributes as fields.
+func (p *xmlParser) startElement(t xml.StartElement, start, end int) {
+	name := xmlName(t.Name)
+	if len(p.stack) == 0 && p.root != nil {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "multiple root elements",
+			Location: p.span(start+1, start+1+len(name)),
+		})
+		return
+	}
+
+	element := &xmlElement{
+		name:      name,
+		start:     start,
+		fields:    map[string]*Node{},
+		textStart: -1,
+	}
+
+	// Attributes are located by scanning the start tag
+	locations := p.attributeLocations(start+1+len(name), end)
+	for i, attr := range t.Attr {
+		node := inferScalar(attr.Value)
+		if i < len(locations) {
+			node.NameLocation = locations[i][0]
+			node.ValueLocation = locations[i][1]
+		}
+
+		key := "@" + xmlName(attr.Name)
+		if _, exists := element.fields[key]; exists {
+			p.errs = append(p.errs, CMParserError{
+				Message:  fmt.Sprintf("can't redefine existing key: '%s'", key),
+				Location: node.NameLocation,
+			})
+			return
+		}
+
+		element.fields[key] = node
+		element.hasFields = true
+	}
+
+	p.stack = append(p.stack, element)
+}
+
+// endElement pops the current element and adds it to its parent.
+func (p *xmlParser) endElement(t xml.EndElement, start, end int) {
+	name := xmlName(t.Name)
+	if len(p.stack) == 0 {
+		p.errs = append(p.errs, CMParserError{
+			Message:  fmt.Sprintf("unexpected closing tag </%s>", name),
+			Location: p.span(start, end),
+		})
+		return
+	}
+
+	element := p.stack[len(p.stack)-1]
+	if element.name != name {
+		p.errs = append(p.errs, CMParserError{
+			Message:  fmt.Sprintf("element <%s> closed by </%s>", element.name, name),
+			Location: p.span(start, end),
+		})
+		return
+	}
+	p.stack = p.stack[:len(p.stack)-1]
+
+	node := p.makeNode(element, end)
+	node.NameLocation = p.span(element.start+1, element.start+1+len(element.name))
+
+	// The root element is the only field of the config file
+	if len(p.stack) == 0 {
+		p.root = &Node{
+			Type:  Object,
+			Value: map[string]*Node{name: node},
+		}
+		return
+	}
+
+	// Add element to parent, repeated elements are grouped in arrays
+	parent := p.stack[len(p.stack)-1]
+	parent.hasFields = true
+	existing, exists := parent.fields[name]
+	if !exists {
+		parent.fields[name] = node
+		return
+	}
+
+	if existing.Type != Array {
+		existing = &Node{
+			Type:          Array,
+			Value:         []*Node{arrayElement(existing)},
+			NameLocation:  existing.NameLocation,
+			ValueLocation: existing.ValueLocation,
+		}
+		parent.fields[name] = existing
+	}
+
+	existing.Value = append(existing.Value.([]*Node), arrayElement(node))
+	existing.ValueLocation.End = node.ValueLocation.End
+}
+
+// charData adds text to the current element.
+func (p *xmlParser) charData(t xml.CharData, start, end int) {
+	if len(bytes.TrimSpace(t)) == 0 {
+		return
+	}
+
+	// Text is only allowed inside the root element
+	if len(p.stack) == 0 {
+		p.errs = append(p.errs, CMParserError{
+			Message:  "text outside of root element",
+			Location: p.trimmedSpan(start, end),
+		})
+		return
+	}
+
+	element := p.stack[len(p.stack)-1]
+	location := p.trimmedOffsets(start, end)
+	if element.textStart == -1 {
+		element.textStart = location[0]
+	} else {
+		element.text.WriteString(" ")
+	}
+	element.textEnd = location[1]
+	element.text.Write(bytes.TrimSpace(t))
+}
+
+// makeNode creates the node of a closed element.
+func (p *xmlParser) makeNode(element *xmlElement, end int) *Node {
+	text := element.text.String()
+
+	// Elements with only text are scalars
+	if !element.hasFields {
+		if element.textStart == -1 {
+			return &Node{Type: Null, Value: nil, ValueLocation: p.span(element.start, end)}
+		}
+
+		node := inferScalar(text)
+		node.ValueLocation = p.span(element.textStart, element.textEnd)
+		return node
+	}
+
+	// Text in elements with attributes or children
+	if element.textStart != -1 {
+		node := inferScalar(text)
+		node.ValueLocation = p.span(element.textStart, element.textEnd)
+		element.fields["#text"] = node
+	}
+
+	return &Node{Type: Object, Value: element.fields, ValueLocation: p.span(element.start, end)}
+}
+
+// attributeLocations scans a start tag between start (after the element
+// name) and end, returning the name and value locations of each attribute.
+func (p *xmlParser) attributeLocations(start, end int) [][2]TokenLocation {
+	locations := [][2]TokenLocation{}
+	i := start
+	for i < end {
+		// Skip whitespace
+		for i < end && isXmlSpace(p.data[i]) {
+			i++
+		}
+		if i >= end || p.data[i] == '/' || p.data[i] == '>' {
+			break
+		}
+
+		// Attribute name
+		nameStart := i
+		for i < end && p.data[i] != '=' && !isXmlSpace(p.data[i]) {
+			i++
+		}
+		nameEnd := i
+
+		// Separator
+		for i < end && (p.data[i] == '=' || isXmlSpace(p.data[i])) {
+			i++
+		}
+		if i >= end {
+			break
+		}
+
+		// Quoted value
+		quote := p.data[i]
+		valueStart := i
+		i++
+		for i < end && p.data[i] != quote {
+			i++
+		}
+		i++
+
+		locations = append(locations, [2]TokenLocation{p.span(nameStart, nameEnd), p.span(valueStart, i)})
+	}
+
+	return locations
+}
+
+// makeXmlError converts an error from encoding/xml into a CMParserError.
+func (p *xmlParser) makeXmlError(err error, offset int) CMParserError {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return CMParserError{
+			Message:  syntaxErr.Msg,
+			Location: p.span(offset, offset),
+		}
+	}
+
+	return CMParserError{
+		Message:  err.Error(),
+		Location: p.span(offset, offset),
+	}
+}
+
+// trimmedOffsets returns the offsets of the text in [start, end)
+// without leading and trailing whitespace.
+func (p *xmlParser) trimmedOffsets(start, end int) [2]int {
+	for start < end && isXmlSpace(p.data[start]) {
+		start++
+	}
+	for end > start && isXmlSpace(p.data[end-1]) {
+		end--
+	}
+
+	return [2]int{start, end}
+}
+
+// trimmedSpan returns the location of the text in [start, end)
+// without leading and trailing whitespace.
+func (p *xmlParser) trimmedSpan(start, end int) TokenLocation {
+	offsets := p.trimmedOffsets(start, end)
+	return p.span(offsets[0], offsets[1])
+}
+
+// span returns the location of the bytes in [start, end).
+func (p *xmlParser) span(start, end int) TokenLocation {
+	return TokenLocation{Start: p.location(start), End: p.location(end)}
+}
+
+// location converts a byte offset into a line and a column in runes.
+func (p *xmlParser) location(offset int) CharLocation {
+	if offset > len(p.data) {
+		offset = len(p.data)
+	}
+
+	line := sort.Search(len(p.lineStarts), func(i int) bool { return p.lineStarts[i] > offset }) - 1
+	return CharLocation{
+		Line:   line,
+		Column: utf8.RuneCount(p.data[p.lineStarts[line]:offset]),
+	}
+}
+
+// xmlName returns a name as it is written, including its namespace prefix.
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+
+	return name.Local
+}
+
+// arrayElement returns a copy of an element without its name,
+// as elements of an array don't have names.
+func arrayElement(node *Node) *Node {
+	return &Node{Type: node.Type, Value: node.Value, ValueLocation: node.ValueLocation}
+}
+
+func isXmlSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
@@ -0,0 +1,452 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+type xmlParserTestCase struct {
+	input        []byte
+	expected     *Node
+	expectedErrs []CMParserError
+}
+
+func TestParseSimpleConfig_xmlParser(t *testing.T) {
+	// Input
+	testConfig, err := os.ReadFile("./test_configs/simple.xml")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// Test cases
+	testCases := []xmlParserTestCase{
+		{
+			input: testConfig,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"settings": {
+						Type: Object,
+						Value: map[string]*Node{
+							"@version": {
+								Type:          Float,
+								Value:         1.2,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 10}, End: CharLocation{Line: 2, Column: 17}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 18}, End: CharLocation{Line: 2, Column: 23}},
+							},
+							"mirrors": {
+								Type: Object,
+								Value: map[string]*Node{
+									"mirror": {
+										Type: Array,
+										Value: []*Node{
+											{
+												Type: Object,
+												Value: map[string]*Node{
+													"#text": {
+														Type:          String,
+														Value:         "https://mirror-one.example.com",
+														ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 25}, End: CharLocation{Line: 9, Column: 55}},
+													},
+													"@priority": {
+														Type:          Int,
+														Value:         1,
+														NameLocation:  TokenLocation{Start: CharLocation{Line: 9, Column: 12}, End: CharLocation{Line: 9, Column: 20}},
+														ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 21}, End: CharLocation{Line: 9, Column: 24}},
+													},
+												},
+												ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 4}, End: CharLocation{Line: 9, Column: 64}},
+											},
+											{
+												Type: Object,
+												Value: map[string]*Node{
+													"#text": {
+														Type:          String,
+														Value:         "https://mirror-two.example.com",
+														ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 25}, End: CharLocation{Line: 10, Column: 55}},
+													},
+													"@priority": {
+														Type:          Int,
+														Value:         2,
+														NameLocation:  TokenLocation{Start: CharLocation{Line: 10, Column: 12}, End: CharLocation{Line: 10, Column: 20}},
+														ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 21}, End: CharLocation{Line: 10, Column: 24}},
+													},
+												},
+												ValueLocation: TokenLocation{Start: CharLocation{Line: 10, Column: 4}, End: CharLocation{Line: 10, Column: 64}},
+											},
+										},
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 9, Column: 5}, End: CharLocation{Line: 9, Column: 11}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 4}, End: CharLocation{Line: 10, Column: 64}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 8, Column: 3}, End: CharLocation{Line: 8, Column: 10}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 2}, End: CharLocation{Line: 11, Column: 12}},
+							},
+							"server": {
+								Type: Object,
+								Value: map[string]*Node{
+									"@id": {
+										Type:          String,
+										Value:         "primary",
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 10}, End: CharLocation{Line: 4, Column: 12}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 13}, End: CharLocation{Line: 4, Column: 22}},
+									},
+									"@port": {
+										Type:          Int,
+										Value:         8080,
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 23}, End: CharLocation{Line: 4, Column: 27}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 28}, End: CharLocation{Line: 4, Column: 34}},
+									},
+									"host": {
+										Type:          String,
+										Value:         "localhost",
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 5, Column: 5}, End: CharLocation{Line: 5, Column: 9}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 5, Column: 10}, End: CharLocation{Line: 5, Column: 19}},
+									},
+									"secure": {
+										Type:          Null,
+										Value:         nil,
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 5}, End: CharLocation{Line: 6, Column: 11}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 6, Column: 4}, End: CharLocation{Line: 6, Column: 13}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 3}, End: CharLocation{Line: 4, Column: 9}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 2}, End: CharLocation{Line: 7, Column: 11}},
+							},
+							"timeout": {
+								Type:          Float,
+								Value:         2.5,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 12, Column: 3}, End: CharLocation{Line: 12, Column: 10}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 12, Column: 11}, End: CharLocation{Line: 12, Column: 14}},
+							},
+							"title": {
+								Type:          String,
+								Value:         "XML Sample Configuration",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 3}, End: CharLocation{Line: 3, Column: 8}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 9}, End: CharLocation{Line: 3, Column: 33}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 1}, End: CharLocation{Line: 2, Column: 9}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 0}, End: CharLocation{Line: 13, Column: 11}},
+					},
+				},
+			},
+			expectedErrs: []CMParserError{},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &xmlParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v", test.expected, result)
+		}
+	}
+}
+
+func TestShortSamples_xmlParser(t *testing.T) {
+	// Input
+	var shortXmlConfig0 = []byte(`
+	<ns:config xmlns:ns="http://example.com/ns">
+		<ns:name>Tom &amp; Jerry</ns:name>
+		<script><![CDATA[a < b]]></script>
+	</ns:config>
+	`)
+
+	var shortXmlConfig1 = []byte(`
+	<log level="debug">
+		starting
+		<file>app.log</file>
+		done
+	</log>
+	`)
+
+	var shortXmlConfig2 = []byte(`
+	<servers>
+		<server><port>80</port></server>
+		<server><port>443</port></server>
+		<server/>
+	</servers>
+	`)
+
+	testCases := []xmlParserTestCase{
+		{ // Namespaces, entities and CDATA
+			input: shortXmlConfig0,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"ns:config": {
+						Type: Object,
+						Value: map[string]*Node{
+							"@xmlns:ns": {
+								Type:          String,
+								Value:         "http://example.com/ns",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 12}, End: CharLocation{Line: 1, Column: 20}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 21}, End: CharLocation{Line: 1, Column: 44}},
+							},
+							"ns:name": {
+								Type:          String,
+								Value:         "Tom & Jerry",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 3}, End: CharLocation{Line: 2, Column: 10}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 11}, End: CharLocation{Line: 2, Column: 26}},
+							},
+							"script": {
+								Type:          String,
+								Value:         "a < b",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 3}, End: CharLocation{Line: 3, Column: 9}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 10}, End: CharLocation{Line: 3, Column: 27}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 2}, End: CharLocation{Line: 1, Column: 11}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 4, Column: 13}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Text in elements with attributes and children
+			input: shortXmlConfig1,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"log": {
+						Type: Object,
+						Value: map[string]*Node{
+							"#text": {
+								Type:          String,
+								Value:         "starting done",
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 2}, End: CharLocation{Line: 4, Column: 6}},
+							},
+							"@level": {
+								Type:          String,
+								Value:         "debug",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 6}, End: CharLocation{Line: 1, Column: 11}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 12}, End: CharLocation{Line: 1, Column: 19}},
+							},
+							"file": {
+								Type:          String,
+								Value:         "app.log",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 3}, End: CharLocation{Line: 3, Column: 7}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 8}, End: CharLocation{Line: 3, Column: 15}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 2}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 5, Column: 7}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{ // Repeated nested elements
+			input: shortXmlConfig2,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"servers": {
+						Type: Object,
+						Value: map[string]*Node{
+							"server": {
+								Type: Array,
+								Value: []*Node{
+									{
+										Type: Object,
+										Value: map[string]*Node{
+											"port": {
+												Type:          Int,
+												Value:         80,
+												NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 11}, End: CharLocation{Line: 2, Column: 15}},
+												ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 16}, End: CharLocation{Line: 2, Column: 18}},
+											},
+										},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 2}, End: CharLocation{Line: 2, Column: 34}},
+									},
+									{
+										Type: Object,
+										Value: map[string]*Node{
+											"port": {
+												Type:          Int,
+												Value:         443,
+												NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 11}, End: CharLocation{Line: 3, Column: 15}},
+												ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 16}, End: CharLocation{Line: 3, Column: 19}},
+											},
+										},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 2}, End: CharLocation{Line: 3, Column: 35}},
+									},
+									{
+										Type:          Null,
+										Value:         nil,
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 2}, End: CharLocation{Line: 4, Column: 11}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 3}, End: CharLocation{Line: 2, Column: 9}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 2}, End: CharLocation{Line: 4, Column: 11}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 2}, End: CharLocation{Line: 1, Column: 9}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 1}, End: CharLocation{Line: 5, Column: 11}},
+					},
+				},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &xmlParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestErrorConditions_xmlParser(t *testing.T) {
+	// Input
+	var errXmlConfig0 = []byte(`
+	<a>
+		<b></c>
+	</a>
+	`)
+
+	var errXmlConfig1 = []byte(`
+	<a>
+		<b>
+	`)
+
+	var errXmlConfig2 = []byte(`
+	<a x="1" x="2"/>
+	`)
+
+	var errXmlConfig3 = []byte(`
+	<a/>
+	<b/>
+	`)
+
+	var errXmlConfig4 = []byte(`
+	text
+	<a/>
+	`)
+
+	var errXmlConfig5 = []byte(`
+	<a b=1/>
+	`)
+
+	var errXmlConfig6 = []byte(`
+	<!-- empty -->
+	`)
+
+	testCases := []xmlParserTestCase{
+		{
+			input:    errXmlConfig0,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "element <b> closed by </c>",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 5},
+						End:   CharLocation{Line: 2, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			input:    errXmlConfig1,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "element <b> is never closed",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 3},
+						End:   CharLocation{Line: 2, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			input:    errXmlConfig2,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "can't redefine existing key: '@x'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 10},
+						End:   CharLocation{Line: 1, Column: 11},
+					},
+				},
+			},
+		},
+		{
+			input:    errXmlConfig3,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "multiple root elements",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 2},
+						End:   CharLocation{Line: 2, Column: 3},
+					},
+				},
+			},
+		},
+		{
+			input:    errXmlConfig4,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "text outside of root element",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 1},
+						End:   CharLocation{Line: 1, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			input:    errXmlConfig5,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "unquoted or missing attribute value in element",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 7},
+						End:   CharLocation{Line: 1, Column: 7},
+					},
+				},
+			},
+		},
+		{
+			input:    errXmlConfig6,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "missing root element",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 2, Column: 1},
+						End:   CharLocation{Line: 2, Column: 1},
+					},
+				},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &xmlParser{}
+		_, errs := parser.Parse(test.input)
+
+		if len(errs) == 0 {
+			t.Errorf("Expected errors, got none, Input: %s", test.input)
+		} else if !reflect.DeepEqual(test.expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v, Input: %s", test.expectedErrs, errs, test.input)
+		}
+	}
+}
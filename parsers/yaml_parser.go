@@ -0,0 +1,566 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlParser struct {
+	lines     [][]rune
+	flowNodes map[*yaml.Node]bool
+	errs      []CMParserError
+
+	expandingAlias *yaml.Node // Outermost alias being expanded, nil if none
+	expandedNodes  int        // Number of nodes converted while expanding aliases
+}
+
+// maxYamlExpandedNodes is the number of nodes that aliases can expand to
+// in a document. Aliases are converted again each time they are used, so
+// nested aliases grow exponentially ("billion laughs").
+const maxYamlExpandedNodes = 100000
+
+// yamlErrorLineRegex extracts the line number from the errors returned by yaml.v3
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+): `)
+
+// yamlUnknownAnchorRegex extracts the anchor name from unknown anchor errors,
+// which yaml.v3 reports without a line number
+var yamlUnknownAnchorRegex = regexp.MustCompile(`unknown anchor '([^']*)' referenced`)
+
+// Custom YAML parser
+func (p *yamlParser) Parse(data []byte) (*Node, []CMParserError) {
+	p.readLines(data)
+
+	// Parse the file into a YAML document tree
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, []CMParserError{p.makeYamlError(err)}
+	}
+
+	return p.convertDocument(&document)
+}
+
+// ParseDocuments parses a stream of '---' separated documents.
+func (p *yamlParser) ParseDocuments(data []byte) ([]*Node, []CMParserError) {
+	p.readLines(data)
+
+	documents := []*Node{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		// Parse the next document into a YAML document tree
+		var document yaml.Node
+		if err := decoder.Decode(&document); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, []CMParserError{p.makeYamlError(err)}
+		}
+
+		node, errs := p.convertDocument(&document)
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		documents = append(documents, node)
+	}
+
+	// Empty file
+	if len(documents) == 0 {
+		documents = append(documents, &Node{Type: Null, Value: nil})
+	}
+
+	return documents, nil
+}
+
+// readLines keeps the lines of the file, these are needed to find
+// where each token ends (yaml.v3 only provides the start)
+func (p *yamlParser) readLines(data []byte) {
+	p.lines = nil
+	for _, line := range strings.Split(string(data), "\n") {
+		p.lines = append(p.lines, []rune(strings.TrimRight(line, "\r")))
+	}
+}
+
+// convertDocument converts a YAML document tree into a *Node.
+func (p *yamlParser) convertDocument(document *yaml.Node) (*Node, []CMParserError) {
+	p.errs = nil
+	p.expandingAlias = nil
+	p.expandedNodes = 0
+
+	// Empty document
+	if document.Kind == 0 || len(document.Content) == 0 {
+		return &Node{Type: Null, Value: nil}, nil
+	}
+
+	// Find the nodes inside flow collections
+	p.flowNodes = make(map[*yaml.Node]bool)
+	p.markFlowNodes(document.Content[0], false)
+
+	// Convert the root of the document
+	configFile := p.convertNode(document.Content[0])
+
+	// Check for errors
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	return configFile, nil
+}
+
+// markFlowNodes marks the nodes that are inside flow collections,
+// where flow indicators (',', ']' and '}') end plain scalars.
+func (p *yamlParser) markFlowNodes(yn *yaml.Node, inFlow bool) {
+	p.flowNodes[yn] = inFlow
+	if (yn.Kind == yaml.MappingNode || yn.Kind == yaml.SequenceNode) && yn.Style&yaml.FlowStyle != 0 {
+		inFlow = true
+	}
+
+	for _, child := range yn.Content {
+		p.markFlowNodes(child, inFlow)
+	}
+}
+
+// convertNode converts a yaml.Node into a *Node. Only the value location
+// is set; name locations are set by the parent mapping.
+func (p *yamlParser) convertNode(yn *yaml.Node) *Node {
+	if p.expandingAlias != nil && !p.countExpandedNode() {
+		return &Node{Type: Null, Value: nil, ValueLocation: p.valueLocation(yn)}
+	}
+
+	switch yn.Kind {
+	case yaml.MappingNode:
+		return p.convertMapping(yn)
+
+	case yaml.SequenceNode:
+		elements := make([]*Node, 0, len(yn.Content))
+		for _, element := range yn.Content {
+			elements = append(elements, p.convertNode(element))
+		}
+
+		return &Node{
+			Type:          Array,
+			Value:         elements,
+			ValueLocation: p.valueLocation(yn),
+		}
+
+	case yaml.ScalarNode:
+		return p.convertScalar(yn)
+
+	case yaml.AliasNode:
+		// Convert the anchored node again, and point the value location
+		// to the alias, which is where the value is used
+		node := p.expandAlias(yn, p.convertNode)
+		node.ValueLocation = p.valueLocation(yn)
+		return node
+
+	default:
+		panic(fmt.Sprintf("unknown yaml node kind: %v", yn.Kind))
+	}
+}
+
+// convertMapping converts a mapping node into an Object node, resolving
+// merge keys (<<) along the way.
+func (p *yamlParser) convertMapping(yn *yaml.Node) *Node {
+	objMap := map[string]*Node{}
+
+	// Keys merged in via '<<' can be overridden by keys defined directly
+	merged := map[string]*Node{}
+
+	for i := 0; i+1 < len(yn.Content); i += 2 {
+		keyNode, valueNode := yn.Content[i], yn.Content[i+1]
+
+		// Merge keys
+		if keyNode.Kind == yaml.ScalarNode && keyNode.ShortTag() == "!!merge" {
+			p.mergeInto(merged, valueNode)
+			continue
+		}
+
+		// Only scalar keys are supported
+		if keyNode.Kind != yaml.ScalarNode {
+			p.errs = append(p.errs, CMParserError{
+				Message:  "only scalar keys are supported",
+				Location: p.valueLocation(keyNode),
+			})
+			continue
+		}
+
+		// Check if this key was already defined
+		if _, exists := objMap[keyNode.Value]; exists {
+			p.errs = append(p.errs, CMParserError{
+				Message:  fmt.Sprintf("can't redefine existing key: '%s'", keyNode.Value),
+				Location: p.valueLocation(keyNode),
+			})
+			continue
+		}
+
+		// Create node for the value and add name location
+		node := p.convertNode(valueNode)
+		node.NameLocation = p.valueLocation(keyNode)
+
+		objMap[keyNode.Value] = node
+	}
+
+	// Add merged keys that were not defined directly
+	for key, node := range merged {
+		if _, exists := objMap[key]; !exists {
+			objMap[key] = node
+		}
+	}
+
+	return &Node{
+		Type:          Object,
+		Value:         objMap,
+		ValueLocation: p.valueLocation(yn),
+	}
+}
+
+// mergeInto adds the keys of the mapping(s) referenced by a merge key
+// value to merged. Mappings listed first take precedence.
+func (p *yamlParser) mergeInto(merged map[string]*Node, yn *yaml.Node) {
+	source := yn
+	if source.Kind == yaml.AliasNode {
+		source = source.Alias
+	}
+
+	switch source.Kind {
+	case yaml.MappingNode:
+		for key, node := range p.expandAlias(yn, p.convertMapping).Value.(map[string]*Node) {
+			if _, exists := merged[key]; !exists {
+				merged[key] = node
+			}
+		}
+
+	case yaml.SequenceNode:
+		for _, element := range source.Content {
+			p.mergeInto(merged, element)
+		}
+
+	default:
+		p.errs = append(p.errs, CMParserError{
+			Message:  "merge key value must be a mapping or a list of mappings",
+			Location: p.valueLocation(yn),
+		})
+	}
+}
+
+// expandAlias converts the node referenced by an alias with convert, counting
+// the nodes converted against maxYamlExpandedNodes. Nodes that aren't
+// aliases are converted as they are.
+func (p *yamlParser) expandAlias(yn *yaml.Node, convert func(*yaml.Node) *Node) *Node {
+	if yn.Kind != yaml.AliasNode {
+		return convert(yn)
+	}
+
+	// Nested aliases are counted against the outermost one
+	if p.expandingAlias != nil {
+		return convert(yn.Alias)
+	}
+
+	p.expandingAlias = yn
+	defer func() { p.expandingAlias = nil }()
+
+	return convert(yn.Alias)
+}
+
+// countExpandedNode counts a node converted while expanding an alias, and
+// returns false, reporting an error the first time, once there are too many.
+func (p *yamlParser) countExpandedNode() bool {
+	p.expandedNodes++
+	if p.expandedNodes <= maxYamlExpandedNodes {
+		return true
+	}
+
+	if p.expandedNodes == maxYamlExpandedNodes+1 {
+		p.errs = append(p.errs, CMParserError{
+			Message:  fmt.Sprintf("aliases expand to more than %d nodes", maxYamlExpandedNodes),
+			Location: p.valueLocation(p.expandingAlias),
+		})
+	}
+
+	return false
+}
+
+// convertScalar converts a scalar node into a leaf node, using the tag
+// resolved by yaml.v3 to decide its type.
+func (p *yamlParser) convertScalar(yn *yaml.Node) *Node {
+	node := &Node{ValueLocation: p.valueLocation(yn)}
+
+	switch yn.ShortTag() {
+	case "!!null":
+		node.Type = Null
+		node.Value = nil
+
+	case "!!bool":
+		var value bool
+		if err := yn.Decode(&value); err != nil {
+			p.addDecodeError(yn, err)
+		}
+		node.Type = Bool
+		node.Value = value
+
+	case "!!int":
+		var value int
+		if err := yn.Decode(&value); err != nil {
+			p.addDecodeError(yn, err)
+		}
+		node.Type = Int
+		node.Value = value
+
+	case "!!float":
+		var value float64
+		if err := yn.Decode(&value); err != nil {
+			p.addDecodeError(yn, err)
+		}
+		node.Type = Float
+		node.Value = value
+
+	default: // Strings, timestamps and binary data are parsed as strings
+		node.Type = String
+		node.Value = yn.Value
+	}
+
+	return node
+}
+
+func (p *yamlParser) addDecodeError(yn *yaml.Node, err error) {
+	p.errs = append(p.errs, CMParserError{
+		Message:  err.Error(),
+		Location: p.valueLocation(yn),
+	})
+}
+
+// makeYamlError creates a CMParserError from an error returned by yaml.v3.
+// These errors only contain the line number, so the whole line is used.
+func (p *yamlParser) makeYamlError(err error) CMParserError {
+	parserError := CMParserError{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+
+	if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		parserError.Message = yamlErrorLineRegex.ReplaceAllString(parserError.Message, "")
+		parserError.Location = TokenLocation{
+			Start: CharLocation{Line: line - 1, Column: 0},
+			End:   CharLocation{Line: line - 1, Column: len(p.line(line - 1))},
+		}
+	} else if match := yamlUnknownAnchorRegex.FindStringSubmatch(err.Error()); match != nil {
+		// Find the first reference to the anchor
+		alias := []rune("*" + match[1])
+		for i, lineRunes := range p.lines {
+			if col := strings.Index(string(lineRunes), string(alias)); col >= 0 {
+				col = len([]rune(string(lineRunes)[:col]))
+				parserError.Location = TokenLocation{
+					Start: CharLocation{Line: i, Column: col},
+					End:   CharLocation{Line: i, Column: col + len(alias)},
+				}
+				break
+			}
+		}
+	}
+
+	return parserError
+}
+
+// valueLocation computes the location of the token(s) that make up a node.
+func (p *yamlParser) valueLocation(yn *yaml.Node) TokenLocation {
+	start := CharLocation{Line: yn.Line - 1, Column: yn.Column - 1}
+	return TokenLocation{Start: start, End: p.findEnd(yn, start)}
+}
+
+// findEnd finds the location right after the last character of a node.
+func (p *yamlParser) findEnd(yn *yaml.Node, start CharLocation) CharLocation {
+	switch yn.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		// Flow collections end in their closing bracket
+		if yn.Style&yaml.FlowStyle != 0 {
+			return p.findClosingBracket(start)
+		}
+
+		// Block collections end where their last value ends
+		if len(yn.Content) == 0 {
+			return start
+		}
+		last := yn.Content[len(yn.Content)-1]
+		return p.valueLocation(last).End
+
+	case yaml.AliasNode:
+		return CharLocation{Line: start.Line, Column: start.Column + 1 + len([]rune(yn.Value))}
+
+	case yaml.ScalarNode:
+		switch {
+		case yn.Style&yaml.DoubleQuotedStyle != 0:
+			return p.findClosingQuote(start, '"')
+		case yn.Style&yaml.SingleQuotedStyle != 0:
+			return p.findClosingQuote(start, '\'')
+		case yn.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+			return p.findBlockScalarEnd(start)
+		default:
+			return p.findPlainScalarEnd(yn, start)
+		}
+	}
+
+	return start
+}
+
+// findClosingBracket finds the end of a flow collection starting at start.
+func (p *yamlParser) findClosingBracket(start CharLocation) CharLocation {
+	depth := 0
+	var quote rune
+	for line := start.Line; line < len(p.lines); line++ {
+		column := 0
+		if line == start.Line {
+			column = start.Column
+		}
+
+		for ; column < len(p.lines[line]); column++ {
+			char := p.lines[line][column]
+
+			// Skip quoted content
+			if quote != 0 {
+				if char == '\\' && quote == '"' {
+					column++
+				} else if char == quote {
+					quote = 0
+				}
+				continue
+			}
+
+			switch char {
+			case '"', '\'':
+				quote = char
+			case '#':
+				// Comment, skip rest of the line
+				if column == 0 || p.lines[line][column-1] == ' ' || p.lines[line][column-1] == '\t' {
+					column = len(p.lines[line])
+				}
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+				if depth == 0 {
+					return CharLocation{Line: line, Column: column + 1}
+				}
+			}
+		}
+	}
+
+	return start
+}
+
+// findClosingQuote finds the end of a quoted scalar starting at start.
+func (p *yamlParser) findClosingQuote(start CharLocation, quote rune) CharLocation {
+	for line := start.Line; line < len(p.lines); line++ {
+		column := 0
+		if line == start.Line {
+			column = start.Column + 1 // Skip the opening quote
+		}
+
+		for ; column < len(p.lines[line]); column++ {
+			char := p.lines[line][column]
+			if quote == '"' && char == '\\' {
+				column++
+			} else if quote == '\'' && char == '\'' && column+1 < len(p.lines[line]) && p.lines[line][column+1] == '\'' {
+				column++
+			} else if char == quote {
+				return CharLocation{Line: line, Column: column + 1}
+			}
+		}
+	}
+
+	return start
+}
+
+// findBlockScalarEnd finds the end of a literal (|) or folded (>) scalar,
+// which is the end of the last non-empty line of its content.
+func (p *yamlParser) findBlockScalarEnd(start CharLocation) CharLocation {
+	end := CharLocation{Line: start.Line, Column: len(p.line(start.Line))}
+
+	contentIndent := -1
+	for line := start.Line + 1; line < len(p.lines); line++ {
+		text := string(p.lines[line])
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		indent := len(p.lines[line]) - len([]rune(strings.TrimLeft(text, " ")))
+		if contentIndent == -1 {
+			// The first non-empty line sets the indentation of the content.
+			// It must be more indented than the indicator's line.
+			if indent <= p.indentation(start.Line) {
+				break
+			}
+			contentIndent = indent
+		} else if indent < contentIndent {
+			break
+		}
+
+		end = CharLocation{Line: line, Column: len([]rune(strings.TrimRight(text, " \t")))}
+	}
+
+	return end
+}
+
+// findPlainScalarEnd finds the end of a plain (unquoted) scalar. Plain
+// scalars may span multiple lines, in which case lines are consumed until
+// the folded text covers the value of the node.
+func (p *yamlParser) findPlainScalarEnd(yn *yaml.Node, start CharLocation) CharLocation {
+	// Empty values (e.g. 'key:') have no token
+	if yn.Value == "" {
+		return start
+	}
+
+	line := p.line(start.Line)
+	column := start.Column
+	for ; column < len(line); column++ {
+		char := line[column]
+
+		// Comments end the scalar
+		if char == '#' && column > start.Column && (line[column-1] == ' ' || line[column-1] == '\t') {
+			break
+		}
+
+		// Flow indicators end the scalar inside flow collections
+		if (char == ',' || char == ']' || char == '}') && p.flowNodes[yn] {
+			break
+		}
+
+		// Mapping indicator ends the scalar when used as a key
+		if char == ':' && (column+1 == len(line) || line[column+1] == ' ' || line[column+1] == '\t') {
+			break
+		}
+	}
+
+	firstLineText := strings.TrimRight(string(line[start.Column:column]), " \t")
+	end := CharLocation{Line: start.Line, Column: start.Column + len([]rune(firstLineText))}
+
+	// Multi-line plain scalar; consume the following lines
+	// until all the words of the value are covered
+	covered := len(strings.Fields(firstLineText))
+	words := len(strings.Fields(yn.Value))
+	for next := start.Line + 1; covered < words && next < len(p.lines); next++ {
+		text := strings.TrimSpace(string(p.lines[next]))
+		if text == "" {
+			continue
+		}
+
+		covered += len(strings.Fields(text))
+		end = CharLocation{Line: next, Column: len([]rune(strings.TrimRight(string(p.lines[next]), " \t")))}
+	}
+
+	return end
+}
+
+// indentation returns the number of leading spaces in a line
+func (p *yamlParser) indentation(line int) int {
+	text := string(p.line(line))
+	return len([]rune(text)) - len([]rune(strings.TrimLeft(text, " ")))
+}
+
+func (p *yamlParser) line(line int) []rune {
+	if line < 0 || line >= len(p.lines) {
+		return []rune{}
+	}
+
+	return p.lines[line]
+}
@@ -0,0 +1,475 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type yamlParserTestCase struct {
+	input        []byte
+	expected     *Node
+	expectedErrs []CMParserError
+}
+
+func TestParseSimpleConfig_yamlParser(t *testing.T) {
+	// Input
+	testConfig, err := os.ReadFile("./test_configs/simple.yaml")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	// Test cases
+	testCases := []yamlParserTestCase{
+		{
+			input: testConfig,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"title": {
+						Type:          String,
+						Value:         "YAML Sample Configuration",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 7}, End: CharLocation{Line: 0, Column: 32}},
+					},
+					"defaults": {
+						Type: Object,
+						Value: map[string]*Node{
+							"host": {
+								Type:          String,
+								Value:         "192.168.1.100",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 2}, End: CharLocation{Line: 3, Column: 6}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 8}, End: CharLocation{Line: 3, Column: 21}},
+							},
+							"max_connections": {
+								Type:          Int,
+								Value:         6000,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 2}, End: CharLocation{Line: 4, Column: 17}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 19}, End: CharLocation{Line: 4, Column: 23}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 0}, End: CharLocation{Line: 2, Column: 8}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 10}, End: CharLocation{Line: 4, Column: 23}},
+					},
+					"database_settings": {
+						Type: Object,
+						Value: map[string]*Node{
+							"host": { // Merged from defaults
+								Type:          String,
+								Value:         "192.168.1.100",
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 2}, End: CharLocation{Line: 3, Column: 6}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 8}, End: CharLocation{Line: 3, Column: 21}},
+							},
+							"max_connections": { // Merged from defaults
+								Type:          Int,
+								Value:         6000,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 2}, End: CharLocation{Line: 4, Column: 17}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 19}, End: CharLocation{Line: 4, Column: 23}},
+							},
+							"port_numbers": {
+								Type: Array,
+								Value: []*Node{
+									{
+										Type:          Int,
+										Value:         8005,
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 18}, End: CharLocation{Line: 8, Column: 22}},
+									},
+									{
+										Type:          Int,
+										Value:         8006,
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 24}, End: CharLocation{Line: 8, Column: 28}},
+									},
+									{
+										Type:          Int,
+										Value:         8007,
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 30}, End: CharLocation{Line: 8, Column: 34}},
+									},
+								},
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 8, Column: 2}, End: CharLocation{Line: 8, Column: 14}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 16}, End: CharLocation{Line: 8, Column: 36}},
+							},
+							"is_active": {
+								Type:          Bool,
+								Value:         false,
+								NameLocation:  TokenLocation{Start: CharLocation{Line: 9, Column: 2}, End: CharLocation{Line: 9, Column: 11}},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 9, Column: 13}, End: CharLocation{Line: 9, Column: 18}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 0}, End: CharLocation{Line: 6, Column: 17}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 7, Column: 2}, End: CharLocation{Line: 9, Column: 18}},
+					},
+					"description": {
+						Type:          String,
+						Value:         "This is a multi-line\nliteral block\n",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 11, Column: 0}, End: CharLocation{Line: 11, Column: 11}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 11, Column: 13}, End: CharLocation{Line: 13, Column: 15}},
+					},
+					"timeout": {
+						Type:          Float,
+						Value:         2.5,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 14, Column: 0}, End: CharLocation{Line: 14, Column: 7}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 14, Column: 9}, End: CharLocation{Line: 14, Column: 12}},
+					},
+					"empty": {
+						Type:          Null,
+						Value:         nil,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 15, Column: 0}, End: CharLocation{Line: 15, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 15, Column: 6}, End: CharLocation{Line: 15, Column: 6}},
+					},
+				},
+				ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 15, Column: 6}},
+			},
+			expectedErrs: []CMParserError{},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &yamlParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v", errs)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v", test.expected, result)
+		}
+	}
+}
+
+func TestShortSamples_yamlParser(t *testing.T) {
+	// Input
+	var shortYamlConfig0 = []byte(`base: &port 8080
+ports:
+  - *port
+  - 9090
+`)
+
+	var shortYamlConfig1 = []byte(`servers: [{ name: alpha, port: 80 }, { name: "beta", port: 81 }]`)
+
+	var shortYamlConfig2 = []byte(`folded: >
+  first line
+  second line
+
+plain: this is a plain
+  scalar on two lines
+quoted: "a quoted \"value\"
+  spanning lines"
+single: 'it''s'
+`)
+
+	var shortYamlConfig3 = []byte(`- 1
+- two
+- null
+`)
+
+	testCases := []yamlParserTestCase{
+		{
+			input: shortYamlConfig0,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"base": {
+						Type:          Int,
+						Value:         8080,
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 4}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 6}, End: CharLocation{Line: 0, Column: 16}},
+					},
+					"ports": {
+						Type: Array,
+						Value: []*Node{
+							{ // Alias points to the alias location
+								Type:          Int,
+								Value:         8080,
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 4}, End: CharLocation{Line: 2, Column: 9}},
+							},
+							{
+								Type:          Int,
+								Value:         9090,
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 4}, End: CharLocation{Line: 3, Column: 8}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 1, Column: 0}, End: CharLocation{Line: 1, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 2}, End: CharLocation{Line: 3, Column: 8}},
+					},
+				},
+				ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 3, Column: 8}},
+			},
+			expectedErrs: nil,
+		},
+		{
+			input: shortYamlConfig1,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"servers": {
+						Type: Array,
+						Value: []*Node{
+							{
+								Type: Object,
+								Value: map[string]*Node{
+									"name": {
+										Type:          String,
+										Value:         "alpha",
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 12}, End: CharLocation{Line: 0, Column: 16}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 18}, End: CharLocation{Line: 0, Column: 23}},
+									},
+									"port": {
+										Type:          Int,
+										Value:         80,
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 25}, End: CharLocation{Line: 0, Column: 29}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 31}, End: CharLocation{Line: 0, Column: 33}},
+									},
+								},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 10}, End: CharLocation{Line: 0, Column: 35}},
+							},
+							{
+								Type: Object,
+								Value: map[string]*Node{
+									"name": {
+										Type:          String,
+										Value:         "beta",
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 39}, End: CharLocation{Line: 0, Column: 43}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 45}, End: CharLocation{Line: 0, Column: 51}},
+									},
+									"port": {
+										Type:          Int,
+										Value:         81,
+										NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 53}, End: CharLocation{Line: 0, Column: 57}},
+										ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 59}, End: CharLocation{Line: 0, Column: 61}},
+									},
+								},
+								ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 37}, End: CharLocation{Line: 0, Column: 63}},
+							},
+						},
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 7}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 9}, End: CharLocation{Line: 0, Column: 64}},
+					},
+				},
+				ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 64}},
+			},
+			expectedErrs: nil,
+		},
+		{
+			input: shortYamlConfig2,
+			expected: &Node{
+				Type: Object,
+				Value: map[string]*Node{
+					"folded": {
+						Type:          String,
+						Value:         "first line second line\n",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 6}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 8}, End: CharLocation{Line: 2, Column: 13}},
+					},
+					"plain": {
+						Type:          String,
+						Value:         "this is a plain scalar on two lines",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 4, Column: 0}, End: CharLocation{Line: 4, Column: 5}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 4, Column: 7}, End: CharLocation{Line: 5, Column: 21}},
+					},
+					"quoted": {
+						Type:          String,
+						Value:         "a quoted \"value\" spanning lines",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 6, Column: 0}, End: CharLocation{Line: 6, Column: 6}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 6, Column: 8}, End: CharLocation{Line: 7, Column: 17}},
+					},
+					"single": {
+						Type:          String,
+						Value:         "it's",
+						NameLocation:  TokenLocation{Start: CharLocation{Line: 8, Column: 0}, End: CharLocation{Line: 8, Column: 6}},
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 8, Column: 8}, End: CharLocation{Line: 8, Column: 15}},
+					},
+				},
+				ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 8, Column: 15}},
+			},
+			expectedErrs: nil,
+		},
+		{
+			input: shortYamlConfig3,
+			expected: &Node{
+				Type: Array,
+				Value: []*Node{
+					{
+						Type:          Int,
+						Value:         1,
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 2}, End: CharLocation{Line: 0, Column: 3}},
+					},
+					{
+						Type:          String,
+						Value:         "two",
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 1, Column: 2}, End: CharLocation{Line: 1, Column: 5}},
+					},
+					{
+						Type:          Null,
+						Value:         nil,
+						ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 2}, End: CharLocation{Line: 2, Column: 6}},
+					},
+				},
+				ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 2, Column: 6}},
+			},
+			expectedErrs: nil,
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &yamlParser{}
+		result, errs := parser.Parse(test.input)
+
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors: %#v, Input: %s", errs, test.input)
+		} else if !reflect.DeepEqual(test.expected, result) {
+			t.Errorf("Expected %#+v, got %#+v, Input: %s", test.expected, result, test.input)
+		}
+	}
+}
+
+func TestErrorConditions_yamlParser(t *testing.T) {
+	// Input
+	var errYamlConfig0 = []byte("name: Tom\nname: Pradyun\n")
+	var errYamlConfig1 = []byte("key: 1\n other: [\n")
+	var errYamlConfig2 = []byte("base: 1\n<<: *missing\n")
+
+	testCases := []yamlParserTestCase{
+		{
+			input:    errYamlConfig0,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "can't redefine existing key: 'name'",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 0},
+						End:   CharLocation{Line: 1, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			input:    errYamlConfig1,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "mapping values are not allowed in this context",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 0},
+						End:   CharLocation{Line: 1, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			input:    errYamlConfig2,
+			expected: nil,
+			expectedErrs: []CMParserError{
+				{
+					Message: "unknown anchor 'missing' referenced",
+					Location: TokenLocation{
+						Start: CharLocation{Line: 1, Column: 4},
+						End:   CharLocation{Line: 1, Column: 12},
+					},
+				},
+			},
+		},
+	}
+
+	// Run tests
+	for _, test := range testCases {
+		parser := &yamlParser{}
+		_, errs := parser.Parse(test.input)
+
+		if len(errs) == 0 {
+			t.Errorf("Expected errors, got none, Input: %s", test.input)
+		} else if !reflect.DeepEqual(test.expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v, Input: %s", test.expectedErrs, errs, test.input)
+		}
+	}
+}
+
+// TestNestedAliases_yamlParser tests that aliases of aliases, which expand
+// exponentially, are reported instead of being expanded without a limit.
+func TestNestedAliases_yamlParser(t *testing.T) {
+	// Each level is a list of nine aliases of the previous level
+	var nestedAliasesConfig = "a: &a [x, x, x, x, x, x, x, x, x]\n"
+	for level := 'b'; level <= 'i'; level++ {
+		aliases := strings.TrimSuffix(strings.Repeat(fmt.Sprintf("*%c,", level-1), 9), ",")
+		nestedAliasesConfig += fmt.Sprintf("%c: &%c [%s]\n", level, level, aliases)
+	}
+
+	// The first alias in f expands past the limit
+	expectedErrs := []CMParserError{
+		{
+			Message: "aliases expand to more than 100000 nodes",
+			Location: TokenLocation{
+				Start: CharLocation{Line: 5, Column: 7},
+				End:   CharLocation{Line: 5, Column: 9},
+			},
+		},
+	}
+
+	done := make(chan []CMParserError)
+	go func() {
+		parser := &yamlParser{}
+		_, errs := parser.Parse([]byte(nestedAliasesConfig))
+		done <- errs
+	}()
+
+	select {
+	case errs := <-done:
+		if !reflect.DeepEqual(expectedErrs, errs) {
+			t.Errorf("Expected %v, got %v", expectedErrs, errs)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Parsing nested aliases didn't finish in 5 seconds")
+	}
+}
+
+func TestParseDocuments_yamlParser(t *testing.T) {
+	// Input
+	var multiDocYamlConfig = []byte("name: app\n---\nname: worker\nreplicas: 2\n")
+
+	// Expected
+	expected := []*Node{
+		{
+			Type: Object,
+			Value: map[string]*Node{
+				"name": {
+					Type:          String,
+					Value:         "app",
+					NameLocation:  TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 4}},
+					ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 6}, End: CharLocation{Line: 0, Column: 9}},
+				},
+			},
+			ValueLocation: TokenLocation{Start: CharLocation{Line: 0, Column: 0}, End: CharLocation{Line: 0, Column: 9}},
+		},
+		{
+			Type: Object,
+			Value: map[string]*Node{
+				"name": {
+					Type:          String,
+					Value:         "worker",
+					NameLocation:  TokenLocation{Start: CharLocation{Line: 2, Column: 0}, End: CharLocation{Line: 2, Column: 4}},
+					ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 6}, End: CharLocation{Line: 2, Column: 12}},
+				},
+				"replicas": {
+					Type:          Int,
+					Value:         2,
+					NameLocation:  TokenLocation{Start: CharLocation{Line: 3, Column: 0}, End: CharLocation{Line: 3, Column: 8}},
+					ValueLocation: TokenLocation{Start: CharLocation{Line: 3, Column: 10}, End: CharLocation{Line: 3, Column: 11}},
+				},
+			},
+			ValueLocation: TokenLocation{Start: CharLocation{Line: 2, Column: 0}, End: CharLocation{Line: 3, Column: 11}},
+		},
+	}
+
+	// Run test
+	parser := &yamlParser{}
+	result, errs := parser.ParseDocuments(multiDocYamlConfig)
+
+	if len(errs) > 0 {
+		t.Errorf("Unexpected errors: %#v", errs)
+	} else if !reflect.DeepEqual(expected, result) {
+		t.Errorf("Expected %#+v, got %#+v", expected, result)
+	}
+}
@@ -14,12 +14,14 @@ import (
 type AnalyzeSpecRequest struct {
 	SpecFilePath    string `json:"spec_file_path"`
 	SpecFileContent []byte `json:"spec_file_content"`
+	ConfigFiles     string `json:"config_files"` // optional file, glob or directory replacing the config declaration
+	Strict          bool   `json:"strict"`       // whether keys not declared in the spec are reported
 }
 
 type AnalyzeSpecResponse struct {
 	Spec         *spec.Specification    `json:"spec"`
 	CheckResults []analyzer.CheckResult `json:"check_results"`
-	SpecError    *analyzer.SpecError    `json:"spec_error"`
+	SpecErrors   []analyzer.SpecError   `json:"spec_errors"`
 }
 
 // checkHandler returns a handler for the check endpoint.
@@ -38,17 +40,18 @@ func (server *Server) analyzeSpecHandler() http.HandlerFunc {
 		a := analyzer.NewAnalyzer(
 			spec.NewSpecParser(),
 			check.NewCheckEvaluator(),
+			check.NewCheckTypeChecker(),
 			files.NewFileFetcher(),
 			parsers.NewParserProvider(),
 		)
 
-		spec, res, specError := a.AnalyzeSpecification(p.SpecFilePath, p.SpecFileContent)
+		spec, res, specErrors := a.AnalyzeSpecification(p.SpecFilePath, p.SpecFileContent, p.ConfigFiles, p.Strict)
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(&AnalyzeSpecResponse{
 			Spec:         spec,
 			CheckResults: res,
-			SpecError:    specError,
+			SpecErrors:   specErrors,
 		}); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
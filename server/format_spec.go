@@ -0,0 +1,54 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/files"
+)
+
+type FormatSpecRequest struct {
+	Path    string `json:"path"`
+	Content []byte `json:"content"`
+}
+
+type FormatSpecResponse struct {
+	FormattedContent []byte                 `json:"formatted_content"`
+	SpecErrors       []spec.SpecParserError `json:"spec_errors"` // syntax errors, the content is not formatted if any
+}
+
+// formatSpecHandler returns a handler for the format_spec endpoint.
+func (server *Server) formatSpecHandler() http.HandlerFunc {
+	// Return handler for format endpoint
+	return func(w http.ResponseWriter, r *http.Request) {
+		var p FormatSpecRequest
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&p); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if p.Path != "" {
+			// Read file content
+			content, err := files.NewFileFetcher().FetchFile(p.Path)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			p.Content = content
+		}
+
+		formatted, specErrors := spec.FormatSpecification(p.Content)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&FormatSpecResponse{
+			FormattedContent: formatted,
+			SpecErrors:       specErrors,
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
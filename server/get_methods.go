@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/ConfigMate/configmate/analyzer/types"
+)
+
+type GetMethodsRequest struct {
+	Type string `json:"type"` // type whose methods are returned, every type if empty
+}
+
+type GetMethodsResponse struct {
+	Types []types.TypeInfo `json:"types"`
+	Error string           `json:"error"`
+}
+
+// getMethodsHandler returns a handler for the get_methods endpoint.
+func (server *Server) getMethodsHandler() http.HandlerFunc {
+	// Return handler for methods endpoint
+	return func(w http.ResponseWriter, r *http.Request) {
+		var p GetMethodsRequest
+
+		// The request body is optional
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&p); err != nil && err != io.EOF {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response := &GetMethodsResponse{Types: types.GetTypesInfo()}
+		if p.Type != "" {
+			methods := types.GetMethods(p.Type)
+			if methods == nil {
+				response = &GetMethodsResponse{Types: []types.TypeInfo{}, Error: "invalid type " + p.Type}
+			} else {
+				response = &GetMethodsResponse{Types: []types.TypeInfo{{Name: p.Type, Methods: methods}}}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
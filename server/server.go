@@ -28,6 +28,8 @@ func CreateServer(port int) *Server {
 	// Add handlers
 	http.HandleFunc("/api/analyze_spec", server.analyzeSpecHandler())
 	http.HandleFunc("/api/get_semantic_tokens", server.getSemanticTokensHandler())
+	http.HandleFunc("/api/format_spec", server.formatSpecHandler())
+	http.HandleFunc("/api/get_methods", server.getMethodsHandler())
 
 	return server
 }
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"encoding/json"
+
+	"github.com/ConfigMate/configmate/analyzer"
+)
+
+// jsonOutput is the document written by FormatJSON.
+type jsonOutput struct {
+	CheckResults []analyzer.CheckResult `json:"check_results"`
+	SpecErrors   []analyzer.SpecError   `json:"spec_errors"`
+}
+
+// FormatJSON formats the check results and the specification
+// errors as an indented JSON document.
+func FormatJSON(res []analyzer.CheckResult, specErrors []analyzer.SpecError) (string, error) {
+	if res == nil {
+		res = []analyzer.CheckResult{}
+	}
+	if specErrors == nil {
+		specErrors = []analyzer.SpecError{}
+	}
+
+	output, err := json.MarshalIndent(&jsonOutput{
+		CheckResults: res,
+		SpecErrors:   specErrors,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(output) + "\n", nil
+}
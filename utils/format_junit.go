@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+
+	checks  int // number of checks of the field
+	failed  int // number of failed checks of the field
+	skipped int // number of skipped checks of the field
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// FormatJUnit formats the check results as a JUnit XML report. Each config
+// file is a test suite and each field a test case, which fails if any of
+// its checks at least as severe as minSeverity failed. The other failed
+// checks are printed in the output of the test case. Specification errors
+// are reported as test case errors.
+func FormatJUnit(res []analyzer.CheckResult, specErrors []analyzer.SpecError, minSeverity string) (string, error) {
+	report := &junitTestSuites{Name: "configm"}
+
+	if len(specErrors) > 0 {
+		suite := &junitTestSuite{Name: "specification"}
+		for _, specError := range specErrors {
+			// Add details of the error
+			details := append([]string{}, specError.ErrorMsgs...)
+			for _, token := range specError.TokenList {
+				details = append(details, fmt.Sprintf("at %s", formatTokenPosition(token)))
+			}
+
+			suite.TestCases = append(suite.TestCases, &junitTestCase{
+				Name:      specError.AnalyzerMsg,
+				ClassName: "specification",
+				Error: &junitMessage{
+					Message: specError.AnalyzerMsg,
+					Text:    strings.Join(details, "\n"),
+				},
+			})
+			suite.Errors++
+		}
+		report.Suites = append(report.Suites, suite)
+		report.Errors = suite.Errors
+	}
+
+	suites := make(map[string]*junitTestSuite)
+	testCases := make(map[string]*junitTestCase)
+	for _, result := range res {
+		// Get test suite of the config file
+		suite, ok := suites[result.File]
+		if !ok {
+			suite = &junitTestSuite{Name: result.File}
+			if suite.Name == "" {
+				suite.Name = "configm"
+			}
+			suites[result.File] = suite
+			report.Suites = append(report.Suites, suite)
+		}
+
+		// Get test case of the field
+		name := result.Field.Field.String()
+		if result.Document > 0 {
+			name = fmt.Sprintf("%s (document %d)", name, result.Document)
+		}
+		testCase, ok := testCases[result.File+"\x00"+name]
+		if !ok {
+			testCase = &junitTestCase{Name: name, ClassName: suite.Name}
+			testCases[result.File+"\x00"+name] = testCase
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		// Add check to test case
+		testCase.checks++
+		check := result.Field.Checks[result.CheckNum].Check
+		switch result.Status {
+		case analyzer.CheckFailed:
+			details := fmt.Sprintf("%s: %s", check, result.ResultComment)
+			for _, token := range result.TokenList {
+				details = fmt.Sprintf("%s (at %s)", details, formatTokenPosition(token))
+			}
+
+			// Less severe failures don't fail the test case
+			if belowMinSeverity(result, minSeverity) {
+				details = fmt.Sprintf("%s: %s", result.Severity, details)
+				testCase.SystemOut = strings.TrimPrefix(testCase.SystemOut+"\n"+details, "\n")
+				continue
+			}
+
+			testCase.failed++
+			if testCase.Failure == nil {
+				testCase.Failure = &junitMessage{}
+			}
+			testCase.Failure.Text = strings.TrimPrefix(testCase.Failure.Text+"\n"+details, "\n")
+		case analyzer.CheckSkipped:
+			testCase.skipped++
+			if testCase.Skipped == nil {
+				testCase.Skipped = &junitMessage{Message: result.ResultComment}
+			}
+		}
+	}
+
+	// Compute the result of each test case
+	for _, suite := range report.Suites {
+		for _, testCase := range suite.TestCases {
+			suite.Tests++
+			if testCase.Error != nil {
+				// Errors were counted with the specification errors
+				continue
+			} else if testCase.Failure != nil {
+				testCase.Failure.Message = fmt.Sprintf("%d of %d checks failed", testCase.failed, testCase.checks)
+				testCase.Skipped = nil
+				suite.Failures++
+			} else if testCase.skipped == testCase.checks {
+				suite.Skipped++
+			} else {
+				// Only fields with all checks skipped are skipped
+				testCase.Skipped = nil
+			}
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+
+	output, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(output) + "\n", nil
+}
@@ -5,11 +5,41 @@ import (
 	"strings"
 
 	"github.com/ConfigMate/configmate/analyzer"
+	"github.com/ConfigMate/configmate/analyzer/spec"
 )
 
 const linesPaddingForErrors = 2
 const rightPaddingForMultilineErrorArrows = 2
 
+// OutputFormats are the formats results can be printed in. Text is
+// formatted with FormatCheckResult and FormatSpecError, and the other
+// formats with FormatResults.
+var OutputFormats = []string{"text", "json", "junit", "sarif", "tap"}
+
+// FormatResults formats the check results and the specification errors
+// in one of the machine readable output formats. Failed checks less severe
+// than minSeverity aren't reported as failures in the formats without severities.
+func FormatResults(format string, res []analyzer.CheckResult, specErrors []analyzer.SpecError, minSeverity string) (string, error) {
+	switch format {
+	case "json":
+		return FormatJSON(res, specErrors)
+	case "junit":
+		return FormatJUnit(res, specErrors, minSeverity)
+	case "sarif":
+		return FormatSARIF(res, specErrors)
+	case "tap":
+		return FormatTAP(res, specErrors, minSeverity), nil
+	}
+
+	return "", fmt.Errorf("unsupported output format '%s', expected one of: %s", format, strings.Join(OutputFormats, ", "))
+}
+
+// belowMinSeverity checks if a check is less severe than minSeverity,
+// so that its failure isn't counted.
+func belowMinSeverity(result analyzer.CheckResult, minSeverity string) bool {
+	return spec.SeverityLevel(result.Severity) < spec.SeverityLevel(minSeverity)
+}
+
 func FormatCheckResult(res analyzer.CheckResult, fileLinesMap map[string]map[int]string) string {
 	var status, comment, check, fieldType, optional string
 
@@ -24,8 +54,9 @@ func FormatCheckResult(res analyzer.CheckResult, fileLinesMap map[string]map[int
 		comment = ColorText(res.ResultComment, Yellow)
 		comment = fmt.Sprintf("- %s", comment)
 	} else {
-		status = ColorText("FAILED", Red)
-		comment = ColorText(res.ResultComment, Red)
+		failed, color := failedStatus(res.Severity)
+		status = ColorText(failed, color)
+		comment = ColorText(res.ResultComment, color)
 		comment = fmt.Sprintf("- %s", comment)
 	}
 
@@ -41,7 +72,17 @@ func FormatCheckResult(res analyzer.CheckResult, fileLinesMap map[string]map[int
 	// Format the values
 	formatted := fmt.Sprintf(format, status, res.Field.Field, fieldType, optional, check, comment)
 
-	if res.Field.Default != "" {
+	if res.File != "" {
+		formatted = fmt.Sprintf("%s\tConfig: %s\n", formatted, res.File)
+	}
+
+	if res.Document > 0 {
+		formatted = fmt.Sprintf("%s\tDocument: %d\n", formatted, res.Document)
+	}
+
+	if res.FromDefault {
+		formatted = fmt.Sprintf("%s\tDefault: %v %s\n", formatted, res.Field.Default, ColorText("(field not found, checked default value)", Yellow))
+	} else if res.Field.Default != "" {
 		formatted = fmt.Sprintf("%s\tDefault: %v\n", formatted, res.Field.Default)
 	}
 
@@ -64,6 +105,75 @@ func FormatCheckResult(res analyzer.CheckResult, fileLinesMap map[string]map[int
 	return formatted
 }
 
+// failedStatus returns the status of a failed check and its color, which
+// depend on the severity of the check.
+func failedStatus(severity string) (string, StdOutColor) {
+	switch severity {
+	case spec.SeverityWarning:
+		return "WARNING", Yellow
+	case spec.SeverityInfo:
+		return "INFO", Blue
+	}
+
+	return "FAILED", Red
+}
+
+// FormatSummary formats the number of passed, failed and skipped
+// checks of each config file, in the order the files were checked.
+func FormatSummary(res []analyzer.CheckResult) string {
+	type fileSummary struct {
+		passed, failed, skipped int
+	}
+
+	// Count results per file
+	files := []string{}
+	summaries := make(map[string]*fileSummary)
+	for _, result := range res {
+		summary, ok := summaries[result.File]
+		if !ok {
+			summary = &fileSummary{}
+			summaries[result.File] = summary
+			files = append(files, result.File)
+		}
+
+		switch result.Status {
+		case analyzer.CheckPassed:
+			summary.passed++
+		case analyzer.CheckFailed:
+			summary.failed++
+		case analyzer.CheckSkipped:
+			summary.skipped++
+		}
+	}
+
+	// Format a line for each file and a line with the totals
+	formatted := "Summary:\n"
+	total := fileSummary{}
+	for _, file := range files {
+		summary := summaries[file]
+		formatted = fmt.Sprintf("%s\t%s: %s\n", formatted, file, formatSummaryCounts(summary.passed, summary.failed, summary.skipped))
+		total.passed += summary.passed
+		total.failed += summary.failed
+		total.skipped += summary.skipped
+	}
+	formatted = fmt.Sprintf("%s\tTotal (%d files): %s\n", formatted, len(files), formatSummaryCounts(total.passed, total.failed, total.skipped))
+
+	return formatted
+}
+
+func formatSummaryCounts(passed, failed, skipped int) string {
+	failedText := fmt.Sprintf("%d failed", failed)
+	if failed > 0 {
+		failedText = ColorText(failedText, Red)
+	}
+
+	return fmt.Sprintf("%s, %s, %s",
+		ColorText(fmt.Sprintf("%d passed", passed), Green),
+		failedText,
+		ColorText(fmt.Sprintf("%d skipped", skipped), Yellow),
+	)
+}
+
 func FormatSpecError(specError analyzer.SpecError, fileLinesMap map[string]map[int]string) string {
 	// Specification Error header
 	header := ColorText("Specification Error:", Red)
@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ConfigMate/configmate/analyzer"
+	"github.com/ConfigMate/configmate/analyzer/spec"
+	"github.com/ConfigMate/configmate/parsers"
+)
+
+// sampleCheckResults returns a passed, a failed and a skipped check.
+func sampleCheckResults() []analyzer.CheckResult {
+	field := spec.FieldSpec{
+		Field: &parsers.NodeKey{Segments: []string{"server", "port"}},
+		Type:  "int",
+		Checks: []spec.CheckWithLocation{
+			{Check: "gte(25)"},
+			{Check: "lte(100)"},
+			{Check: "eq(host.port)"},
+		},
+	}
+	location := analyzer.TokenLocationWithFile{
+		File: "./config.json",
+		Location: parsers.TokenLocation{
+			Start: parsers.CharLocation{Line: 2, Column: 10},
+			End:   parsers.CharLocation{Line: 2, Column: 13},
+		},
+	}
+
+	return []analyzer.CheckResult{
+		{Status: analyzer.CheckPassed, Field: field, CheckNum: 0, File: "./config.json"},
+		{Status: analyzer.CheckFailed, ResultComment: "443 is greater than 100", Field: field, CheckNum: 1, File: "./config.json", TokenList: []analyzer.TokenLocationWithFile{location}},
+		{Status: analyzer.CheckSkipped, ResultComment: "host.port is missing", Field: field, CheckNum: 2, File: "./config.json"},
+	}
+}
+
+// TestFormatTAP tests the FormatTAP function.
+func TestFormatTAP(t *testing.T) {
+	specErrors := []analyzer.SpecError{
+		{
+			AnalyzerMsg: "Field server.host not found in file ./config.json",
+			ErrorMsgs:   []string{},
+			TokenList: []analyzer.TokenLocationWithFile{
+				{
+					File: "./spec.cms",
+					Location: parsers.TokenLocation{
+						Start: parsers.CharLocation{Line: 4, Column: 8},
+						End:   parsers.CharLocation{Line: 4, Column: 12},
+					},
+				},
+			},
+		},
+	}
+
+	expected := `TAP version 13
+1..4
+ok 1 - ./config.json: server.port: gte(25)
+not ok 2 - ./config.json: server.port: lte(100)
+  ---
+  message: "443 is greater than 100"
+  at:
+    - "./config.json:3:11"
+  ...
+ok 3 - ./config.json: server.port: eq(host.port) # SKIP host.port is missing
+not ok 4 - Specification Error: Field server.host not found in file ./config.json
+  ---
+  at:
+    - "./spec.cms:5:9"
+  ...
+`
+
+	if actual := FormatTAP(sampleCheckResults(), specErrors, spec.SeverityError); actual != expected {
+		t.Errorf("FormatTAP returned:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
+
+// TestFormatJUnit tests the FormatJUnit function.
+func TestFormatJUnit(t *testing.T) {
+	output, err := FormatJUnit(sampleCheckResults(), nil, spec.SeverityError)
+	if err != nil {
+		t.Fatalf("FormatJUnit returned error %s", err)
+	}
+
+	report := junitTestSuites{}
+	if err := xml.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("FormatJUnit returned invalid XML: %s", err)
+	}
+
+	// The three checks belong to a single failed field
+	if report.Tests != 1 || report.Failures != 1 || report.Skipped != 0 || len(report.Suites) != 1 {
+		t.Fatalf("FormatJUnit returned unexpected report:\n%s", output)
+	}
+	testCase := report.Suites[0].TestCases[0]
+	if testCase.Name != "server.port" || testCase.ClassName != "./config.json" {
+		t.Errorf("FormatJUnit returned unexpected test case %s (%s)", testCase.Name, testCase.ClassName)
+	}
+	if testCase.Failure == nil || testCase.Failure.Message != "1 of 3 checks failed" {
+		t.Errorf("FormatJUnit returned unexpected failure:\n%s", output)
+	}
+}
+
+// TestFormatMinSeverity tests that failed checks less severe than the minimum
+// severity are TODO test points in TAP and don't fail JUnit test cases.
+func TestFormatMinSeverity(t *testing.T) {
+	res := sampleCheckResults()
+	res[1].Severity = spec.SeverityWarning
+
+	expected := `TAP version 13
+1..3
+ok 1 - ./config.json: server.port: gte(25)
+not ok 2 - ./config.json: server.port: lte(100) # TODO warning
+  ---
+  message: "443 is greater than 100"
+  at:
+    - "./config.json:3:11"
+  ...
+ok 3 - ./config.json: server.port: eq(host.port) # SKIP host.port is missing
+`
+
+	if actual := FormatTAP(res, nil, spec.SeverityError); actual != expected {
+		t.Errorf("FormatTAP returned:\n%s\nexpected:\n%s", actual, expected)
+	}
+	if actual := FormatTAP(res, nil, spec.SeverityWarning); strings.Contains(actual, "# TODO") {
+		t.Errorf("FormatTAP returned TODO for a check as severe as the minimum severity:\n%s", actual)
+	}
+
+	output, err := FormatJUnit(res, nil, spec.SeverityError)
+	if err != nil {
+		t.Fatalf("FormatJUnit returned error %s", err)
+	}
+
+	report := junitTestSuites{}
+	if err := xml.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("FormatJUnit returned invalid XML: %s", err)
+	}
+
+	// The field passes, with the failed check in its output
+	if report.Tests != 1 || report.Failures != 0 || report.Skipped != 0 || len(report.Suites) != 1 {
+		t.Fatalf("FormatJUnit returned unexpected report:\n%s", output)
+	}
+	testCase := report.Suites[0].TestCases[0]
+	if testCase.Failure != nil || testCase.SystemOut != "warning: lte(100): 443 is greater than 100 (at ./config.json:3:11)" {
+		t.Errorf("FormatJUnit returned unexpected test case:\n%s", output)
+	}
+}
+
+// TestFormatSARIF tests the FormatSARIF function.
+func TestFormatSARIF(t *testing.T) {
+	output, err := FormatSARIF(sampleCheckResults(), nil)
+	if err != nil {
+		t.Fatalf("FormatSARIF returned error %s", err)
+	}
+
+	log := sarifLog{}
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("FormatSARIF returned invalid JSON: %s", err)
+	}
+
+	// Only the failed check is reported, with a location starting at 1
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("FormatSARIF returned unexpected log:\n%s", output)
+	}
+	expectedLocation := sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: "config.json"},
+		Region:           sarifRegion{StartLine: 3, StartColumn: 11, EndLine: 3, EndColumn: 14},
+	}
+	if actual := log.Runs[0].Results[0].Locations[0].PhysicalLocation; actual != expectedLocation {
+		t.Errorf("FormatSARIF returned location %#v, expected %#v", actual, expectedLocation)
+	}
+}
+
+// TestFormatCheckResultSeverity tests that failed checks are formatted with
+// the status and color of their severity, and reported with its SARIF level.
+func TestFormatCheckResultSeverity(t *testing.T) {
+	fileLinesMap := map[string]map[int]string{
+		"./config.json": {0: "{", 1: "  \"server\": {", 2: "    \"port\": 443", 3: "  }", 4: "}"},
+	}
+
+	tests := []struct {
+		severity       string
+		expectedStatus string
+		expectedLevel  string
+	}{
+		{severity: "", expectedStatus: ColorText("FAILED", Red), expectedLevel: "error"},
+		{severity: spec.SeverityError, expectedStatus: ColorText("FAILED", Red), expectedLevel: "error"},
+		{severity: spec.SeverityWarning, expectedStatus: ColorText("WARNING", Yellow), expectedLevel: "warning"},
+		{severity: spec.SeverityInfo, expectedStatus: ColorText("INFO", Blue), expectedLevel: "note"},
+	}
+
+	for _, test := range tests {
+		result := sampleCheckResults()[1]
+		result.Severity = test.severity
+
+		if output := FormatCheckResult(result, fileLinesMap); !strings.HasPrefix(output, test.expectedStatus+":") {
+			t.Errorf("FormatCheckResult with severity '%s' returned:\n%s\nexpected status %q", test.severity, output, test.expectedStatus)
+		}
+
+		output, err := FormatSARIF([]analyzer.CheckResult{result}, nil)
+		if err != nil {
+			t.Fatalf("FormatSARIF returned error %s", err)
+		}
+		log := sarifLog{}
+		if err := json.Unmarshal([]byte(output), &log); err != nil {
+			t.Fatalf("FormatSARIF returned invalid JSON: %s", err)
+		}
+		if level := log.Runs[0].Results[0].Level; level != test.expectedLevel {
+			t.Errorf("FormatSARIF with severity '%s' returned level %s, expected %s", test.severity, level, test.expectedLevel)
+		}
+	}
+}
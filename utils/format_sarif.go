@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer"
+	"github.com/ConfigMate/configmate/analyzer/spec"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	sarifFailedCheckRule = "failed-check"
+	sarifSpecErrorRule   = "spec-error"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// FormatSARIF formats the failed checks and the specification errors as
+// a SARIF 2.1.0 log, so that code scanning tools can annotate the lines
+// of the config files.
+func FormatSARIF(res []analyzer.CheckResult, specErrors []analyzer.SpecError) (string, error) {
+	results := []sarifResult{}
+
+	for _, specError := range specErrors {
+		// Add details of the error to the message
+		message := specError.AnalyzerMsg
+		if len(specError.ErrorMsgs) > 0 {
+			message = fmt.Sprintf("%s: %s", message, strings.Join(specError.ErrorMsgs, "; "))
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    sarifSpecErrorRule,
+			Level:     "error",
+			Message:   sarifMessage{Text: message},
+			Locations: sarifLocations(specError.TokenList),
+		})
+	}
+
+	for _, result := range res {
+		if result.Status != analyzer.CheckFailed {
+			continue
+		}
+
+		message := fmt.Sprintf("Check %s failed for field %s",
+			result.Field.Checks[result.CheckNum].Check, result.Field.Field.String(),
+		)
+		if result.ResultComment != "" {
+			message = fmt.Sprintf("%s: %s", message, result.ResultComment)
+		}
+		if result.FromDefault {
+			message = fmt.Sprintf("%s (field not found, default value checked)", message)
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    sarifFailedCheckRule,
+			Level:     sarifLevel(result.Severity),
+			Message:   sarifMessage{Text: message},
+			Locations: sarifLocations(result.TokenList),
+		})
+	}
+
+	output, err := json.MarshalIndent(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "configm",
+				InformationURI: "https://github.com/ConfigMate/configmate",
+				Rules: []sarifRule{
+					{ID: sarifFailedCheckRule, ShortDescription: sarifMessage{Text: "A check of the specification failed."}},
+					{ID: sarifSpecErrorRule, ShortDescription: sarifMessage{Text: "A field or check of the specification could not be analyzed."}},
+				},
+			}},
+			Results: results,
+		}},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(output) + "\n", nil
+}
+
+// sarifLocations converts token locations, which start at 0, into
+// SARIF locations, which start at 1 and use relative URIs.
+func sarifLocations(tokens []analyzer.TokenLocationWithFile) []sarifLocation {
+	locations := []sarifLocation{}
+	for _, token := range tokens {
+		locations = append(locations, sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI: strings.TrimPrefix(filepath.ToSlash(token.File), "./"),
+				},
+				Region: sarifRegion{
+					StartLine:   token.Location.Start.Line + 1,
+					StartColumn: token.Location.Start.Column + 1,
+					EndLine:     token.Location.End.Line + 1,
+					EndColumn:   token.Location.End.Column + 1,
+				},
+			},
+		})
+	}
+
+	return locations
+}
+
+// sarifLevel returns the SARIF level of a failed check of the given severity.
+func sarifLevel(severity string) string {
+	switch severity {
+	case spec.SeverityWarning:
+		return "warning"
+	case spec.SeverityInfo:
+		return "note"
+	}
+
+	return "error"
+}
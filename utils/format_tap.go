@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ConfigMate/configmate/analyzer"
+)
+
+// FormatTAP formats the check results as a TAP (Test Anything Protocol)
+// version 13 stream, with one test point per check. Specification errors
+// are added as failed test points after the checks. Failed checks less
+// severe than minSeverity are marked as TODO, so they don't count as failures.
+func FormatTAP(res []analyzer.CheckResult, specErrors []analyzer.SpecError, minSeverity string) string {
+	formatted := "TAP version 13\n"
+
+	formatted = fmt.Sprintf("%s1..%d\n", formatted, len(res)+len(specErrors))
+	for i, result := range res {
+		description := tapEscape(checkResultName(result))
+
+		switch result.Status {
+		case analyzer.CheckPassed:
+			formatted = fmt.Sprintf("%sok %d - %s\n", formatted, i+1, description)
+		case analyzer.CheckSkipped:
+			formatted = fmt.Sprintf("%sok %d - %s # SKIP %s\n", formatted, i+1, description, tapEscape(result.ResultComment))
+		case analyzer.CheckFailed:
+			if belowMinSeverity(result, minSeverity) {
+				formatted = fmt.Sprintf("%snot ok %d - %s # TODO %s\n", formatted, i+1, description, result.Severity)
+			} else {
+				formatted = fmt.Sprintf("%snot ok %d - %s\n", formatted, i+1, description)
+			}
+
+			// Add YAML diagnostics block
+			formatted = fmt.Sprintf("%s  ---\n", formatted)
+			formatted = fmt.Sprintf("%s  message: %q\n", formatted, result.ResultComment)
+			formatted = fmt.Sprintf("%s%s", formatted, tapTokenPositions(result.TokenList))
+			formatted = fmt.Sprintf("%s  ...\n", formatted)
+		}
+	}
+
+	for i, specError := range specErrors {
+		formatted = fmt.Sprintf("%snot ok %d - Specification Error: %s\n", formatted, len(res)+i+1, tapEscape(specError.AnalyzerMsg))
+
+		// Add YAML diagnostics block
+		formatted = fmt.Sprintf("%s  ---\n", formatted)
+		if len(specError.ErrorMsgs) > 0 {
+			formatted = fmt.Sprintf("%s  errors:\n", formatted)
+			for _, errorMsg := range specError.ErrorMsgs {
+				formatted = fmt.Sprintf("%s    - %q\n", formatted, errorMsg)
+			}
+		}
+		formatted = fmt.Sprintf("%s%s", formatted, tapTokenPositions(specError.TokenList))
+		formatted = fmt.Sprintf("%s  ...\n", formatted)
+	}
+
+	return formatted
+}
+
+// checkResultName returns a name for the check of a result, including
+// the config file, the document and whether the default value was checked.
+func checkResultName(result analyzer.CheckResult) string {
+	name := fmt.Sprintf("%s: %s", result.Field.Field.String(), result.Field.Checks[result.CheckNum].Check)
+	if result.Document > 0 {
+		name = fmt.Sprintf("%s (document %d)", name, result.Document)
+	}
+	if result.FromDefault {
+		name = fmt.Sprintf("%s (default value)", name)
+	}
+	if result.File != "" {
+		name = fmt.Sprintf("%s: %s", result.File, name)
+	}
+
+	return name
+}
+
+// formatTokenPosition returns the position of a token as file:line:column,
+// with lines and columns starting at 1.
+func formatTokenPosition(token analyzer.TokenLocationWithFile) string {
+	return fmt.Sprintf("%s:%d:%d", token.File, token.Location.Start.Line+1, token.Location.Start.Column+1)
+}
+
+// tapTokenPositions formats the positions of tokens as a YAML list
+// for a TAP diagnostics block.
+func tapTokenPositions(tokens []analyzer.TokenLocationWithFile) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	formatted := "  at:\n"
+	for _, token := range tokens {
+		formatted = fmt.Sprintf("%s    - %q\n", formatted, formatTokenPosition(token))
+	}
+
+	return formatted
+}
+
+// tapEscape escapes the characters with a meaning in TAP descriptions.
+func tapEscape(text string) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	return strings.ReplaceAll(text, "#", "\\#")
+}